@@ -35,6 +35,12 @@ type BasicLit struct {
 	Value    string
 }
 
+// "a #{x} b", Parts alternate between string BasicLits and expressions
+type InterpExpr struct {
+	Quote token.Pos
+	Parts []Expr
+}
+
 type ParenExpr struct {
 	Lparen token.Pos
 	X      Expr
@@ -42,8 +48,9 @@ type ParenExpr struct {
 }
 
 type SelectorExpr struct {
-	X   Expr
-	Sel *Ident
+	X    Expr
+	Sel  *Ident
+	Safe bool // x?.sel, nil when x is nil
 }
 
 type IndexExpr struct {
@@ -58,6 +65,7 @@ type SliceExpr struct {
 	Lbrack token.Pos
 	Low    Expr
 	High   Expr
+	Step   Expr
 	Rbrack token.Pos
 }
 
@@ -87,12 +95,64 @@ type ArrayExpr struct {
 	Rbrack token.Pos
 }
 
+// if c { a } else { b }, a branch gives the value of its last expression
+// statement. Else is nil or a block, else-if nests another IfExpr in it
+type IfExpr struct {
+	If   token.Pos
+	Cond Expr
+	Body *BlockStmt
+	Else Stmt
+}
+
+// c ? x : y
+type CondExpr struct {
+	Cond     Expr
+	Question token.Pos
+	X        Expr
+	Colon    token.Pos
+	Y        Expr
+}
+
+// lo..hi, an inclusive range pattern of a match
+type RangeExpr struct {
+	Low   Expr
+	OpPos token.Pos
+	High  Expr
+}
+
+// match x { case pattern if guard: ... }, Body holds CaseClauses whose List
+// are alternative patterns. the value is that of the matching clause
+type MatchExpr struct {
+	Match token.Pos
+	X     Expr
+	Body  *BlockStmt
+}
+
+// x ?? y, y when x is nil
+type CoalesceExpr struct {
+	X     Expr
+	OpPos token.Pos
+	Y     Expr
+}
+
+// ...x, collects the remaining elements in an array pattern
+type SpreadExpr struct {
+	Ellipsis token.Pos
+	X        Expr
+}
+
 type SetExpr struct {
 	Lbrack token.Pos
 	Elems  []Expr
 	Rbrack token.Pos
 }
 
+type TupleExpr struct {
+	Lparen token.Pos
+	Elems  []Expr
+	Rparen token.Pos
+}
+
 type Field struct {
 	Name     Expr
 	ColonPos token.Pos
@@ -105,30 +165,74 @@ type DictExpr struct {
 	Rbrace token.Pos
 }
 
+// T{k: v} or T{a, b}, a go value of the type T
+type CompositeLit struct {
+	Type   Expr
+	Lbrace token.Pos
+	Fields []*Field // keyed, nil when the elements are positional
+	Elems  []Expr
+	Rbrace token.Pos
+}
+
+// for k, v in x if cond, one loop of a comprehension
+type CompClause struct {
+	For  token.Pos
+	Vars []Expr
+	X    Expr
+	Cond Expr
+}
+
+// [elem for x in xs if cond]
+type ArrayCompExpr struct {
+	Lbrack  token.Pos
+	Elem    Expr
+	Clauses []*CompClause
+	Rbrack  token.Pos
+}
+
+// #{key: value for k, v in d}
+type DictCompExpr struct {
+	Lbrace  token.Pos
+	Field   *Field
+	Clauses []*CompClause
+	Rbrace  token.Pos
+}
+
 type FuncDeclExpr struct {
 	Func     token.Pos
 	Recv     *Ident
 	RecvType *Ident
 	Name     *Ident
-	Args     []*Ident
+	Args     []Expr // idents, or array and dict patterns
 	Body     *BlockStmt
 
 	LocalNames []string
 }
 
-func (Ident) exprNode()        {}
-func (BasicLit) exprNode()     {}
-func (ParenExpr) exprNode()    {}
-func (SelectorExpr) exprNode() {}
-func (IndexExpr) exprNode()    {}
-func (SliceExpr) exprNode()    {}
-func (CallExpr) exprNode()     {}
-func (UnaryExpr) exprNode()    {}
-func (BinaryExpr) exprNode()   {}
-func (ArrayExpr) exprNode()    {}
-func (SetExpr) exprNode()      {}
-func (DictExpr) exprNode()     {}
-func (FuncDeclExpr) exprNode() {}
+func (Ident) exprNode()         {}
+func (BasicLit) exprNode()      {}
+func (InterpExpr) exprNode()    {}
+func (ParenExpr) exprNode()     {}
+func (SelectorExpr) exprNode()  {}
+func (IndexExpr) exprNode()     {}
+func (SliceExpr) exprNode()     {}
+func (CallExpr) exprNode()      {}
+func (UnaryExpr) exprNode()     {}
+func (BinaryExpr) exprNode()    {}
+func (ArrayExpr) exprNode()     {}
+func (IfExpr) exprNode()        {}
+func (CondExpr) exprNode()      {}
+func (CoalesceExpr) exprNode()  {}
+func (RangeExpr) exprNode()     {}
+func (MatchExpr) exprNode()     {}
+func (SpreadExpr) exprNode()    {}
+func (SetExpr) exprNode()       {}
+func (TupleExpr) exprNode()     {}
+func (DictExpr) exprNode()      {}
+func (CompositeLit) exprNode()  {}
+func (ArrayCompExpr) exprNode() {}
+func (DictCompExpr) exprNode()  {}
+func (FuncDeclExpr) exprNode()  {}
 
 func (n *Ident) Accept(v Visitor) {
 	v.VisitIdent(n)
@@ -138,6 +242,10 @@ func (n *BasicLit) Accept(v Visitor) {
 	v.VisitBasicLit(n)
 }
 
+func (n *InterpExpr) Accept(v Visitor) {
+	v.VisitInterpExpr(n)
+}
+
 func (n *ParenExpr) Accept(v Visitor) {
 	v.VisitParenExpr(n)
 }
@@ -170,14 +278,54 @@ func (n *ArrayExpr) Accept(v Visitor) {
 	v.VisitArrayExpr(n)
 }
 
+func (n *IfExpr) Accept(v Visitor) {
+	v.VisitIfExpr(n)
+}
+
+func (n *CondExpr) Accept(v Visitor) {
+	v.VisitCondExpr(n)
+}
+
+func (n *CoalesceExpr) Accept(v Visitor) {
+	v.VisitCoalesceExpr(n)
+}
+
+func (n *RangeExpr) Accept(v Visitor) {
+	v.VisitRangeExpr(n)
+}
+
+func (n *MatchExpr) Accept(v Visitor) {
+	v.VisitMatchExpr(n)
+}
+
+func (n *SpreadExpr) Accept(v Visitor) {
+	v.VisitSpreadExpr(n)
+}
+
 func (n *SetExpr) Accept(v Visitor) {
 	v.VisitSetExpr(n)
 }
 
+func (n *TupleExpr) Accept(v Visitor) {
+	v.VisitTupleExpr(n)
+}
+
 func (n *DictExpr) Accept(v Visitor) {
 	v.VisitDictExpr(n)
 }
 
+func (n *CompositeLit) Accept(v Visitor) {
+	v.VisitCompositeLit(n)
+}
+
+func (n *ArrayCompExpr) Accept(v Visitor) {
+	v.VisitArrayCompExpr(n)
+}
+
+func (n *DictCompExpr) Accept(v Visitor) {
+	v.VisitDictCompExpr(n)
+}
+
 func (n *FuncDeclExpr) Accept(v Visitor) {
 	v.VisitFuncDeclExpr(n)
 }
@@ -217,6 +365,11 @@ type ReturnStmt struct {
 	Results []Expr
 }
 
+type YieldStmt struct {
+	Yield token.Pos
+	Value Expr
+}
+
 type BranchStmt struct {
 	TokPos token.Pos
 	Tok    token.Token
@@ -238,6 +391,7 @@ type IfStmt struct {
 type CaseClause struct {
 	Case  token.Pos
 	List  []Expr
+	Guard Expr // case p if guard:, match only
 	Colon token.Pos
 	Body  []Stmt
 }
@@ -279,6 +433,7 @@ func (IncDecStmt) stmtNode() {}
 func (AssignStmt) stmtNode() {}
 func (GoStmt) stmtNode()     {}
 func (ReturnStmt) stmtNode() {}
+func (YieldStmt) stmtNode()  {}
 func (BranchStmt) stmtNode() {}
 func (BlockStmt) stmtNode()  {}
 func (IfStmt) stmtNode()     {}
@@ -313,6 +468,10 @@ func (n *ReturnStmt) Accept(v Visitor) {
 	v.VisitReturnStmt(n)
 }
 
+func (n *YieldStmt) Accept(v Visitor) {
+	v.VisitYieldStmt(n)
+}
+
 func (n *BranchStmt) Accept(v Visitor) {
 	v.VisitBranchStmt(n)
 }
@@ -3,6 +3,7 @@ package ast
 type Visitor interface {
 	VisitIdent(node *Ident)
 	VisitBasicLit(node *BasicLit)
+	VisitInterpExpr(node *InterpExpr)
 	VisitParenExpr(node *ParenExpr)
 	VisitSelectorExpr(node *SelectorExpr)
 	VisitIndexExpr(node *IndexExpr)
@@ -11,8 +12,18 @@ type Visitor interface {
 	VisitUnaryExpr(node *UnaryExpr)
 	VisitBinaryExpr(node *BinaryExpr)
 	VisitArrayExpr(node *ArrayExpr)
+	VisitIfExpr(node *IfExpr)
+	VisitCondExpr(node *CondExpr)
+	VisitCoalesceExpr(node *CoalesceExpr)
+	VisitRangeExpr(node *RangeExpr)
+	VisitMatchExpr(node *MatchExpr)
+	VisitSpreadExpr(node *SpreadExpr)
 	VisitSetExpr(node *SetExpr)
+	VisitTupleExpr(node *TupleExpr)
 	VisitDictExpr(node *DictExpr)
+	VisitCompositeLit(node *CompositeLit)
+	VisitArrayCompExpr(node *ArrayCompExpr)
+	VisitDictCompExpr(node *DictCompExpr)
 	VisitFuncDeclExpr(node *FuncDeclExpr)
 	VisitExprStmt(node *ExprStmt)
 	VisitSendStmt(node *SendStmt)
@@ -20,6 +31,7 @@ type Visitor interface {
 	VisitAssignStmt(node *AssignStmt)
 	VisitGoStmt(node *GoStmt)
 	VisitReturnStmt(node *ReturnStmt)
+	VisitYieldStmt(node *YieldStmt)
 	VisitBranchStmt(node *BranchStmt)
 	VisitBlockStmt(node *BlockStmt)
 	VisitIfStmt(node *IfStmt)
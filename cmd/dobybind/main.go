@@ -0,0 +1,260 @@
+// dobybind generates the registration of go packages into the doby runtime.
+// it loads each package with go/types and registers every exported function,
+// variable, constant and type. scripts call a type to convert to it, like
+// time.Duration(n), and build structs with T{...} or new(T).
+//
+//	go run ./cmd/dobybind -go go1.21 -o rt/stdlib.go strings strconv time
+//
+// with -go, names the api files of GOROOT list as added after that release
+// are left out, so the output builds with it whatever go generated it.
+// generic functions and types, and interfaces, have no go value to register
+// and are skipped
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/constant"
+	"go/format"
+	"go/importer"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var output string
+var pkgName string
+var funcName string
+var goVersion string
+
+func init() {
+	flag.StringVar(&output, "o", "", "output file, stdout when empty")
+	flag.StringVar(&pkgName, "pkg", "rt", "package of the generated file")
+	flag.StringVar(&funcName, "func", "registerStdlib", "name of the generated Runtime method")
+	flag.StringVar(&goVersion, "go", "", "oldest go release the output must build with, like go1.21")
+}
+
+// the minor version of go1.N
+func minor(version string) (int, bool) {
+	n, err := strconv.Atoi(strings.TrimPrefix(version, "go1."))
+	return n, err == nil && strings.HasPrefix(version, "go1.")
+}
+
+// newerNames reads the api files of GOROOT, the names are "path.Name" of
+// the funcs, types, vars and consts first listed after version. a const
+// whose value changed is listed again, it is not new
+func newerNames(version string) (map[string]bool, error) {
+	oldest, ok := minor(version)
+	if !ok {
+		return nil, fmt.Errorf("bad go version %q", version)
+	}
+	files, err := filepath.Glob(filepath.Join(runtime.GOROOT(), "api", "go1*.txt"))
+	if err != nil {
+		return nil, err
+	}
+	older, newer := map[string]bool{}, map[string]bool{}
+	for _, file := range files {
+		// go1.txt is the first release
+		n, _ := minor(strings.TrimSuffix(filepath.Base(file), ".txt"))
+		names := newer
+		if n <= oldest {
+			names = older
+		}
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		// pkg net/http, func NewCrossOriginProtection() *CrossOriginProtection #73626
+		for _, line := range strings.Split(string(data), "\n") {
+			pkg, decl, ok := strings.Cut(strings.TrimPrefix(line, "pkg "), ", ")
+			fields := strings.Fields(decl)
+			// a new field or method of an older type is a line of its own,
+			// "type Server struct, HTTP2 *HTTP2Config"
+			if !ok || len(fields) < 2 || len(fields) > 2 && (fields[2] == "struct," || fields[2] == "interface,") {
+				continue
+			}
+			// pkg syscall (linux-386), ...
+			pkg = strings.Fields(pkg)[0]
+			switch fields[0] {
+			case "func", "type", "var", "const":
+				name := strings.FieldsFunc(fields[1], func(r rune) bool { return r == '(' || r == '[' })[0]
+				names[pkg+"."+name] = true
+			}
+		}
+	}
+	for name := range older {
+		delete(newer, name)
+	}
+	return newer, nil
+}
+
+type binding struct {
+	path  string
+	name  string   // the package name used in the generated code
+	funcs []string // name: value lines for RegisterFuncMap
+	vars  []string // name: value lines for RegisterVars
+	types []string // (*T)(nil) lines for RegisterTypes
+}
+
+func load(imp types.Importer, path string, newer map[string]bool) (*binding, error) {
+	pkg, err := imp.Import(path)
+	if err != nil {
+		return nil, err
+	}
+	b := &binding{path: path, name: pkg.Name()}
+
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		obj := scope.Lookup(name)
+		if !obj.Exported() || newer[path+"."+name] {
+			continue
+		}
+		qualified := b.name + "." + name
+		switch obj := obj.(type) {
+		case *types.Func:
+			if obj.Type().(*types.Signature).TypeParams().Len() > 0 {
+				continue
+			}
+			b.funcs = append(b.funcs, fmt.Sprintf("%q: %s,", name, qualified))
+		case *types.TypeName:
+			if registrable(obj) {
+				b.types = append(b.types, fmt.Sprintf("(*%s)(nil),", qualified))
+			}
+		case *types.Var:
+			b.vars = append(b.vars, fmt.Sprintf("%q: %s,", name, qualified))
+		case *types.Const:
+			if val := constValue(obj, qualified); val != "" {
+				b.vars = append(b.vars, fmt.Sprintf("%q: %s,", name, val))
+			}
+		}
+	}
+	return b, nil
+}
+
+// named types without type parameters, interfaces have no values to make
+func registrable(obj *types.TypeName) bool {
+	if obj.IsAlias() {
+		return false
+	}
+	named, ok := obj.Type().(*types.Named)
+	if !ok || named.TypeParams().Len() > 0 {
+		return false
+	}
+	_, iface := named.Underlying().(*types.Interface)
+	return !iface
+}
+
+// untyped constants get a type their value fits, typed ones keep theirs
+func constValue(obj *types.Const, qualified string) string {
+	basic, ok := obj.Type().(*types.Basic)
+	if !ok || basic.Info()&types.IsUntyped == 0 {
+		return qualified
+	}
+	val := obj.Val()
+	switch basic.Kind() {
+	case types.UntypedBool, types.UntypedString:
+		return qualified
+	case types.UntypedRune:
+		return "rune(" + qualified + ")"
+	case types.UntypedInt:
+		if _, exact := constant.Int64Val(val); exact {
+			return "int64(" + qualified + ")"
+		}
+		if _, exact := constant.Uint64Val(val); exact {
+			return "uint64(" + qualified + ")"
+		}
+		return ""
+	case types.UntypedFloat:
+		return "float64(" + qualified + ")"
+	case types.UntypedComplex:
+		return "complex128(" + qualified + ")"
+	}
+	return ""
+}
+
+func generate(bindings []*binding) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by dobybind. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\nimport (\n", pkgName)
+	for _, b := range bindings {
+		if b.name != path.Base(b.path) {
+			fmt.Fprintf(&buf, "%s %q\n", b.name, b.path)
+		} else {
+			fmt.Fprintf(&buf, "%q\n", b.path)
+		}
+	}
+	fmt.Fprintf(&buf, ")\n\n")
+
+	fmt.Fprintf(&buf, "func (self *Runtime) %s() {\n", funcName)
+	for i, b := range bindings {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		if len(b.funcs) > 0 {
+			fmt.Fprintf(&buf, "self.RegisterFuncMap(%q, map[string]interface{}{\n%s\n})\n",
+				b.path, strings.Join(b.funcs, "\n"))
+		}
+		if len(b.vars) > 0 {
+			fmt.Fprintf(&buf, "self.RegisterVars(%q, map[string]interface{}{\n%s\n})\n",
+				b.path, strings.Join(b.vars, "\n"))
+		}
+		if len(b.types) > 0 {
+			fmt.Fprintf(&buf, "self.RegisterTypes(%q, []interface{}{\n%s\n})\n",
+				b.path, strings.Join(b.types, "\n"))
+		}
+	}
+	fmt.Fprintf(&buf, "}\n")
+	return buf.Bytes()
+}
+
+func main() {
+	flag.Parse()
+	paths := flag.Args()
+	if len(paths) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: dobybind [-go go1.N] [-o file] [-pkg name] [-func name] package...")
+		os.Exit(2)
+	}
+	sort.Strings(paths)
+
+	var newer map[string]bool
+	if goVersion != "" {
+		var err error
+		if newer, err = newerNames(goVersion); err != nil {
+			fmt.Fprintf(os.Stderr, "dobybind: %s\n", err)
+			os.Exit(1)
+		}
+	}
+
+	imp := importer.ForCompiler(token.NewFileSet(), "source", nil)
+	bindings := []*binding{}
+	for _, p := range paths {
+		b, err := load(imp, p, newer)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "dobybind: %s\n", err)
+			os.Exit(1)
+		}
+		bindings = append(bindings, b)
+	}
+
+	src, err := format.Source(generate(bindings))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "dobybind: %s\n", err)
+		os.Exit(1)
+	}
+	if output == "" {
+		os.Stdout.Write(src)
+		return
+	}
+	if err := ioutil.WriteFile(output, src, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "dobybind: %s\n", err)
+		os.Exit(1)
+	}
+}
@@ -12,6 +12,8 @@ import (
 type Attr struct {
 	env *env.Env
 	fun *ast.FuncDeclExpr
+
+	Quiet bool // do not print warnings
 }
 
 func NewAttr() *Attr {
@@ -20,6 +22,9 @@ func NewAttr() *Attr {
 }
 
 func (self *Attr) log(fmtstr string, args ...interface{}) {
+	if self.Quiet {
+		return
+	}
 	fmt.Printf(fmtstr, args...)
 	fmt.Println()
 }
@@ -34,7 +39,7 @@ func (self *Attr) checkIdentRef(node ast.Expr) {
 			}
 		}
 		_, env := self.env.LookUp(arg.Name)
-		if arg.Name != "_" && env == nil && !keyword {
+		if arg.Name != "_" && env == nil && !keyword && !ContainsString(builtinNames, arg.Name) {
 			self.log("'%s' not found", arg.Name)
 		}
 	default:
@@ -48,6 +53,34 @@ func (self *Attr) checkIdentListRef(nodes []ast.Expr) {
 	}
 }
 
+// put the names bound by an assignment target or pattern into the env,
+// returns false for targets like a[i] and a.b which bind nothing
+func (self *Attr) defineNames(node ast.Expr) bool {
+	switch arg := node.(type) {
+	case *ast.Ident:
+		val, _ := self.env.LookUp(arg.Name)
+		// set lexical variable
+		if val == nil && self.fun != nil {
+			self.fun.LocalNames = append(self.fun.LocalNames, arg.Name)
+		}
+		self.env.Put(arg.Name, arg)
+	case *ast.ArrayExpr:
+		for _, elem := range arg.Elems {
+			self.defineNames(elem)
+		}
+	case *ast.SpreadExpr:
+		self.defineNames(arg.X)
+	case *ast.DictExpr:
+		for _, field := range arg.Fields {
+			self.checkDictKey(field.Name)
+			self.defineNames(field.Value)
+		}
+	default:
+		return false
+	}
+	return true
+}
+
 // exprs
 
 func (self *Attr) VisitIdent(node *ast.Ident) {
@@ -56,6 +89,10 @@ func (self *Attr) VisitIdent(node *ast.Ident) {
 func (self *Attr) VisitBasicLit(node *ast.BasicLit) {
 }
 
+func (self *Attr) VisitInterpExpr(node *ast.InterpExpr) {
+	self.checkIdentListRef(node.Parts)
+}
+
 func (self *Attr) VisitParenExpr(node *ast.ParenExpr) {
 	self.checkIdentRef(node.X)
 }
@@ -77,6 +114,9 @@ func (self *Attr) VisitSliceExpr(node *ast.SliceExpr) {
 	if node.High != nil {
 		self.checkIdentRef(node.High)
 	}
+	if node.Step != nil {
+		self.checkIdentRef(node.Step)
+	}
 }
 
 func (self *Attr) VisitCallExpr(node *ast.CallExpr) {
@@ -97,29 +137,96 @@ func (self *Attr) VisitArrayExpr(node *ast.ArrayExpr) {
 	self.checkIdentListRef(node.Elems)
 }
 
+func (self *Attr) VisitIfExpr(node *ast.IfExpr) {
+	self.checkIdentRef(node.Cond)
+	node.Body.Accept(self)
+	if node.Else != nil {
+		node.Else.Accept(self)
+	}
+}
+
+func (self *Attr) VisitCondExpr(node *ast.CondExpr) {
+	self.checkIdentRef(node.Cond)
+	self.checkIdentRef(node.X)
+	self.checkIdentRef(node.Y)
+}
+
+func (self *Attr) VisitCoalesceExpr(node *ast.CoalesceExpr) {
+	self.checkIdentRef(node.X)
+	self.checkIdentRef(node.Y)
+}
+
+func (self *Attr) VisitSpreadExpr(node *ast.SpreadExpr) {
+	self.checkIdentRef(node.X)
+}
+
 func (self *Attr) VisitSetExpr(node *ast.SetExpr) {
 	self.checkIdentListRef(node.Elems)
 }
 
+func (self *Attr) VisitTupleExpr(node *ast.TupleExpr) {
+	self.checkIdentListRef(node.Elems)
+}
+
+func (self *Attr) checkDictKey(key ast.Expr) {
+	if !isSymbolKey(key) {
+		self.checkIdentRef(key)
+	}
+}
+
 func (self *Attr) VisitDictExpr(node *ast.DictExpr) {
 	for _, field := range node.Fields {
-		self.checkIdentRef(field.Name)
+		self.checkDictKey(field.Name)
 		self.checkIdentRef(field.Value)
 	}
 }
 
+func (self *Attr) VisitCompositeLit(node *ast.CompositeLit) {
+	self.checkIdentRef(node.Type)
+	for _, field := range node.Fields {
+		self.checkDictKey(field.Name)
+		self.checkIdentRef(field.Value)
+	}
+	self.checkIdentListRef(node.Elems)
+}
+
+// loop variables of a comprehension are defined like range variables, so
+// the element and conditions after them may refer to them
+func (self *Attr) checkCompClauses(clauses []*ast.CompClause) {
+	for _, clause := range clauses {
+		self.checkIdentRef(clause.X)
+		for _, v := range clause.Vars {
+			self.defineNames(v)
+		}
+		if clause.Cond != nil {
+			self.checkIdentRef(clause.Cond)
+		}
+	}
+}
+
+func (self *Attr) VisitArrayCompExpr(node *ast.ArrayCompExpr) {
+	self.checkCompClauses(node.Clauses)
+	self.checkIdentRef(node.Elem)
+}
+
+func (self *Attr) VisitDictCompExpr(node *ast.DictCompExpr) {
+	self.checkCompClauses(node.Clauses)
+	self.checkIdentRef(node.Field.Name)
+	self.checkIdentRef(node.Field.Value)
+}
+
 func (self *Attr) VisitFuncDeclExpr(node *ast.FuncDeclExpr) {
 	if node.Name != nil {
 		self.env.Put(node.Name.Name, node.Name)
 	}
 
 	self.Enter()
+	fnBak := self.fun
+	self.fun = node
 	for _, arg := range node.Args {
-		self.env.Put(arg.Name, arg)
+		self.defineNames(arg)
 	}
 
-	fnBak := self.fun
-	self.fun = node
 	node.Body.Accept(self)
 	self.fun = fnBak
 	self.Leave()
@@ -147,13 +254,8 @@ func (self *Attr) VisitAssignStmt(node *ast.AssignStmt) {
 		}
 	} else {
 		for _, arg := range node.Lhs {
-			if ident, ok := arg.(*ast.Ident); ok {
-				val, _ := self.env.LookUp(ident.Name)
-				// set lexical variable
-				if val == nil && self.fun != nil {
-					self.fun.LocalNames = append(self.fun.LocalNames, ident.Name)
-				}
-				self.env.Put(ident.Name, ident)
+			if !self.defineNames(arg) {
+				arg.Accept(self)
 			}
 		}
 	}
@@ -161,13 +263,6 @@ func (self *Attr) VisitAssignStmt(node *ast.AssignStmt) {
 	for _, arg := range node.Rhs {
 		self.checkIdentRef(arg)
 	}
-
-	for _, lh := range node.Lhs {
-		lh, ok := lh.(*ast.Ident)
-		if ok {
-			self.env.Put(lh.Name, lh)
-		}
-	}
 }
 
 func (self *Attr) VisitGoStmt(node *ast.GoStmt) {
@@ -178,6 +273,10 @@ func (self *Attr) VisitReturnStmt(node *ast.ReturnStmt) {
 	self.checkIdentListRef(node.Results)
 }
 
+func (self *Attr) VisitYieldStmt(node *ast.YieldStmt) {
+	self.checkIdentRef(node.Value)
+}
+
 func (self *Attr) VisitBranchStmt(node *ast.BranchStmt) {
 }
 
@@ -220,11 +319,9 @@ func (self *Attr) VisitForStmt(node *ast.ForStmt) {
 }
 
 func (self *Attr) VisitRangeStmt(node *ast.RangeStmt) {
-	key := node.KeyValue[0].(*ast.Ident)
-	self.env.Put(key.Name, key)
-
-	val := node.KeyValue[1].(*ast.Ident)
-	self.env.Put(val.Name, val)
+	for _, kv := range node.KeyValue {
+		self.defineNames(kv)
+	}
 
 	self.checkIdentRef(node.X)
 	self.Enter()
@@ -2,6 +2,7 @@ package comp
 
 import (
 	"fmt"
+	"math/big"
 	"os"
 	"strconv"
 	"strings"
@@ -20,6 +21,18 @@ type IRBuilder struct {
 	lexer              *parser.Lexer
 	continueInstrStack []*instr.JumpInstr
 	moduleNames        []string
+	// names bound in a scope of their own, like the variables of a
+	// comprehension, are kept in hidden locals while the scope is built
+	renames map[string]string
+	// hidden locals of the range loops the current function is inside,
+	// a return closes what they iterate
+	ranges []int
+	// building a func literal, which can't be a generator
+	literal bool
+
+	// Fail gets a compile error instead of it being printed before exiting,
+	// it must not return
+	Fail func(msg string)
 }
 
 func NewIRBuilder() *IRBuilder {
@@ -71,11 +84,14 @@ func (self *IRBuilder) DumpClosureProto() {
 }
 
 func (self *IRBuilder) Fatalf(pos token.Pos, format string, a ...interface{}) {
+	msg := fmt.Sprintf("Error: "+format+"\n", a...)
 	if pos > 0 {
-		self.lexer.PrintPosInfo(int(pos))
+		msg = self.lexer.PosInfo(int(pos)) + msg
 	}
-	fmt.Printf("Error: "+format, a...)
-	fmt.Println()
+	if self.Fail != nil {
+		self.Fail(msg)
+	}
+	fmt.Print(msg)
 	os.Exit(1)
 }
 
@@ -85,6 +101,7 @@ var OpFuncs = map[token.Token]string{
 	token.MUL:            "__mul__",
 	token.QUO:            "__quo__",
 	token.REM:            "__rem__",
+	token.POW:            "__pow__",
 	token.AND:            "__and__",
 	token.OR:             "__or__",
 	token.NOT:            "__not__",
@@ -100,17 +117,76 @@ var OpFuncs = map[token.Token]string{
 	token.LEQ:            "__leq__",
 	token.GEQ:            "__geq__",
 	token.NEQ:            "__neq__",
-	token.ADD_ASSIGN:     "__add_assign__",
-	token.SUB_ASSIGN:     "__sub_assign__",
-	token.MUL_ASSIGN:     "__mul_assign__",
-	token.QUO_ASSIGN:     "__quo_assign__",
-	token.REM_ASSIGN:     "__rem_assign__",
-	token.AND_ASSIGN:     "__and_assign__",
-	token.OR_ASSIGN:      "__or_assign__",
-	token.XOR_ASSIGN:     "__xor_assign__",
-	token.SHL_ASSIGN:     "__shl_assign__",
-	token.SHR_ASSIGN:     "__shr_assign__",
-	token.AND_NOT_ASSIGN: "__and_not_assign__",
+	token.MATCH_OP:       "__match__",
+	token.ADD_ASSIGN:     "__add__",
+	token.SUB_ASSIGN:     "__sub__",
+	token.MUL_ASSIGN:     "__mul__",
+	token.QUO_ASSIGN:     "__quo__",
+	token.REM_ASSIGN:     "__rem__",
+	token.POW_ASSIGN:     "__pow__",
+	token.AND_ASSIGN:     "__and__",
+	token.OR_ASSIGN:      "__or__",
+	token.XOR_ASSIGN:     "__xor__",
+	token.SHL_ASSIGN:     "__shl__",
+	token.SHR_ASSIGN:     "__shr__",
+	token.AND_NOT_ASSIGN: "__and_not__",
+}
+
+// go builtins, found in the builtin module unless a variable hides them
+var builtinNames = []string{"new", "append"}
+
+// the local a name refers to in the scope being built
+func (self *IRBuilder) resolve(name string) string {
+	if hidden, ok := self.renames[name]; ok {
+		return hidden
+	}
+	return name
+}
+
+// scope starts a scope where the names are kept in hidden locals, or are
+// not renamed when hidden is nil. it returns the function which ends it
+func (self *IRBuilder) scope(names []string, hidden func(name string) string) func() {
+	saved := self.renames
+	self.renames = map[string]string{}
+	for k, v := range saved {
+		self.renames[k] = v
+	}
+	for _, name := range names {
+		if hidden == nil {
+			delete(self.renames, name)
+		} else {
+			self.renames[name] = hidden(name)
+		}
+	}
+	return func() { self.renames = saved }
+}
+
+func targetNames(targets []ast.Expr) (names []string) {
+	for _, target := range targets {
+		names = append(names, patternNames(target)...)
+	}
+	return
+}
+
+// the names bound by an assignment target or pattern
+func patternNames(node ast.Expr) (names []string) {
+	switch v := node.(type) {
+	case *ast.Ident:
+		if v.Name != "_" {
+			names = append(names, v.Name)
+		}
+	case *ast.ArrayExpr:
+		for _, elem := range v.Elems {
+			names = append(names, patternNames(elem)...)
+		}
+	case *ast.SpreadExpr:
+		names = patternNames(v.X)
+	case *ast.DictExpr:
+		for _, field := range v.Fields {
+			names = append(names, patternNames(field.Value)...)
+		}
+	}
+	return
 }
 
 func ContainsString(ss []string, s string) bool {
@@ -134,23 +210,28 @@ func (self *IRBuilder) VisitIdent(node *ast.Ident) {
 	} else if node.Name == "false" {
 		self.emit(instr.PushFalse())
 	} else {
-		exist, offset := self.cc.LookUpLocal(node.Name)
+		name := self.resolve(node.Name)
+		exist, offset := self.cc.LookUpLocal(name)
 		if exist {
 			self.emit(instr.LoadLocal(offset))
 			return
 		}
-		exist, offset = self.cc.LookUpUpval(node.Name)
+		exist, offset = self.cc.LookUpUpval(name)
 		if exist {
 			self.emit(instr.LoadUpval(offset))
 			return
 		}
 
-		exist, depth, offset := self.cc.LookUpOuter(node.Name)
+		exist, depth, offset := self.cc.LookUpOuter(name)
 		if exist {
-			offset := self.cc.AddUpvalVariable(node.Name, depth, offset)
+			offset := self.cc.AddUpvalVariable(name, depth, offset)
 			self.emit(instr.LoadUpval(offset))
 		} else if ContainsString(self.moduleNames, node.Name) {
 			self.emit(instr.PushModule(node.Name))
+		} else if ContainsString(builtinNames, node.Name) {
+			self.emit(instr.PushSymbol(node.Name))
+			self.emit(instr.PushModule("builtin"))
+			self.emit(instr.SendMethodAt("__get_property__", 1, int(node.NamePos)))
 		} else {
 			self.Fatalf(node.NamePos, "'%s' not Found", node.Name)
 		}
@@ -160,74 +241,164 @@ func (self *IRBuilder) VisitIdent(node *ast.Ident) {
 func (self *IRBuilder) VisitBasicLit(node *ast.BasicLit) {
 	switch node.Kind {
 	case token.INT:
-		val, err := strconv.Atoi(node.Value)
-		if err != nil {
+		val, err := strconv.ParseInt(node.Value, 0, 64)
+		if err == nil {
+			self.emit(instr.PushInt(int(val)))
+			break
+		}
+		bigVal, ok := new(big.Int).SetString(node.Value, 0)
+		if !ok {
 			self.Fatalf(node.ValuePos, "%s convert to int failed: %v", node.Value, err)
 		}
-		self.emit(instr.PushInt(val))
+		self.emit(instr.PushBigInt(bigVal))
 	case token.FLOAT:
 		val, err := strconv.ParseFloat(node.Value, 64)
 		if err != nil {
 			self.Fatalf(node.ValuePos, "%s convert to float failed: %v", node.Value, err)
 		}
 		self.emit(instr.PushFloat(val))
+	case token.IMAG:
+		val, err := parseImag(node.Value)
+		if err != nil {
+			self.Fatalf(node.ValuePos, "%s convert to complex failed: %v", node.Value, err)
+		}
+		self.emit(instr.PushComplex(complex(0, val)))
 	case token.STRING:
-		val := strings.Trim(node.Value, "\"")
-		self.emit(instr.PushString(val))
+		self.emit(instr.PushString(node.Value))
+	case token.SYMBOL:
+		self.emit(instr.PushSymbol(node.Value))
+	case token.REGEXP:
+		i := strings.LastIndex(node.Value, "/")
+		pattern, flags := node.Value[:i], node.Value[i+1:]
+		re, err := instr.CompileRegexp(pattern, flags)
+		if err != nil {
+			self.Fatalf(node.ValuePos, "invalid regexp /%s/%s: %v", pattern, flags, err)
+		}
+		self.emit(instr.PushRegexp(pattern, flags, re))
 	case token.CHAR:
-		val := strings.Trim(node.Value, "'")
-		self.emit(instr.PushString(val))
+		val, _, _, err := strconv.UnquoteChar(node.Value[1:len(node.Value)-1], '\'')
+		if err != nil {
+			self.Fatalf(node.ValuePos, "%s convert to rune failed: %v", node.Value, err)
+		}
+		self.emit(instr.PushRune(val))
 	}
 }
 
+// imaginary literals like 017i are decimal, only 0x/0o/0b prefixes change base
+func parseImag(lit string) (float64, error) {
+	lit = strings.TrimSuffix(lit, "i")
+	if len(lit) > 1 && lit[0] == '0' && strings.ContainsAny(lit[1:2], "xXoObB") &&
+		!strings.ContainsAny(lit, ".pP") {
+		val, err := strconv.ParseInt(lit, 0, 64)
+		return float64(val), err
+	}
+	return strconv.ParseFloat(lit, 64)
+}
+
+func (self *IRBuilder) VisitInterpExpr(node *ast.InterpExpr) {
+	n := 0
+	for _, part := range node.Parts {
+		if lit, ok := part.(*ast.BasicLit); ok && lit.Value == "" {
+			continue
+		}
+		self.buildExpr(part)
+		n++
+	}
+	self.emit(instr.Concat(n))
+}
+
 func (self *IRBuilder) VisitParenExpr(node *ast.ParenExpr) {
 	node.X.Accept(self)
 }
 
 func (self *IRBuilder) VisitSelectorExpr(node *ast.SelectorExpr) {
-	self.emit(instr.PushString(node.Sel.Name))
+	if node.Safe {
+		self.buildNilSafe(node.X, func(xOffset int) {
+			self.emit(instr.PushSymbol(node.Sel.Name))
+			self.emit(instr.LoadLocal(xOffset))
+			self.emit(instr.SendMethodAt("__get_property__", 1, int(node.Sel.NamePos)))
+		})
+		return
+	}
+
+	self.emit(instr.PushSymbol(node.Sel.Name))
 	self.buildExpr(node.X)
-	self.emit(instr.SendMethod("__get_property__", 1))
+	self.emit(instr.SendMethodAt("__get_property__", 1, int(node.Sel.NamePos)))
+}
+
+var safeSeq int = 0
+
+// buildNilSafe evaluates x once into a hidden local and runs body on it,
+// the value is nil instead when x is nil
+func (self *IRBuilder) buildNilSafe(x ast.Expr, body func(xOffset int)) {
+	xOffset := self.cc.AddLocalVariable(fmt.Sprintf("#safe#x%d#", safeSeq))
+	safeSeq++
+
+	self.buildExpr(x)
+	self.emit(instr.SetLocal(xOffset))
+	self.emit(instr.LoadLocal(xOffset))
+	nilJump := instr.JumpIfNil(-1)
+	self.emit(nilJump)
+	body(xOffset)
+	endJump := instr.Jump(-1)
+	self.emit(endJump)
+	nilJump.Target = self.emit(instr.Label("safe_nil"))
+	self.emit(instr.PushNil())
+	endJump.Target = self.emit(instr.Label("safe_end"))
 }
 
 func (self *IRBuilder) VisitIndexExpr(node *ast.IndexExpr) {
 	self.buildExpr(node.Index)
 	self.buildExpr(node.X)
-	self.emit(instr.SendMethod("__get_index__", 1))
+	self.emit(instr.SendMethodAt("__get_index__", 1, int(node.Lbrack)))
 }
 
-func (self *IRBuilder) VisitSliceExpr(node *ast.SliceExpr) {
-	if node.Low == nil {
-		self.emit(instr.PushNil())
-	} else {
-		self.buildExpr(node.Low)
-	}
-
-	if node.High == nil {
-		self.emit(instr.PushNil())
-	} else {
-		self.buildExpr(node.High)
+// push low, high and step of a slice, nil for the omitted ones
+func (self *IRBuilder) buildSliceBounds(node *ast.SliceExpr) {
+	for _, bound := range []ast.Expr{node.Low, node.High, node.Step} {
+		if bound == nil {
+			self.emit(instr.PushNil())
+		} else {
+			self.buildExpr(bound)
+		}
 	}
+}
 
+func (self *IRBuilder) VisitSliceExpr(node *ast.SliceExpr) {
+	self.buildSliceBounds(node)
 	self.buildExpr(node.X)
-	self.emit(instr.SendMethod("__slice__", 2))
+	self.emit(instr.SendMethodAt("__slice__", 3, int(node.Lbrack)))
 }
 
 func (self *IRBuilder) VisitCallExpr(node *ast.CallExpr) {
+	// x?.m(args) is nil when x is nil, the args are not evaluated then
+	if sel, ok := node.Fun.(*ast.SelectorExpr); ok && sel.Safe {
+		self.buildNilSafe(sel.X, func(xOffset int) {
+			for _, arg := range node.Args {
+				self.buildExpr(arg)
+			}
+			self.emit(instr.PushSymbol(sel.Sel.Name))
+			self.emit(instr.LoadLocal(xOffset))
+			self.emit(instr.SendMethodAt("__get_property__", 1, int(sel.Sel.NamePos)))
+			self.emit(instr.SendMethodAt("__call__", len(node.Args), int(node.Lparen)))
+		})
+		return
+	}
+
 	for _, arg := range node.Args {
 		self.buildExpr(arg)
 	}
 
 	self.buildExpr(node.Fun)
-	self.emit(instr.SendMethod("__call__", len(node.Args)))
+	self.emit(instr.SendMethodAt("__call__", len(node.Args), int(node.Lparen)))
 }
 
 func (self *IRBuilder) VisitUnaryExpr(node *ast.UnaryExpr) {
 	self.buildExpr(node.X)
 	if node.Op == token.NOT {
-		self.emit(instr.SendMethod("__not__", 0))
+		self.emit(instr.SendMethodAt("__not__", 0, int(node.OpPos)))
 	} else if node.Op == token.SUB {
-		self.emit(instr.SendMethod("__minus__", 0))
+		self.emit(instr.SendMethodAt("__minus__", 0, int(node.OpPos)))
 	}
 }
 
@@ -235,7 +406,7 @@ func (self *IRBuilder) VisitBinaryExpr(node *ast.BinaryExpr) {
 	self.buildExpr(node.Y)
 	self.buildExpr(node.X)
 
-	self.emit(instr.SendMethod(OpFuncs[node.Op], 1))
+	self.emit(instr.SendMethodAt(OpFuncs[node.Op], 1, int(node.OpPos)))
 }
 
 func (self *IRBuilder) VisitArrayExpr(node *ast.ArrayExpr) {
@@ -245,6 +416,77 @@ func (self *IRBuilder) VisitArrayExpr(node *ast.ArrayExpr) {
 	self.emit(instr.NewArray(len(node.Elems)))
 }
 
+// buildBlockValue runs a branch of an if-expression, leaving the value of its
+// last statement when that is an expression and nil otherwise
+func (self *IRBuilder) buildBlockValue(block ast.Stmt) {
+	list := []ast.Stmt{block}
+	if b, ok := block.(*ast.BlockStmt); ok {
+		list = b.List
+	}
+	if len(list) == 0 {
+		self.emit(instr.PushNil())
+		return
+	}
+	for _, stmt := range list[:len(list)-1] {
+		stmt.Accept(self)
+	}
+	if last, ok := list[len(list)-1].(*ast.ExprStmt); ok {
+		self.buildExpr(last.X)
+	} else {
+		list[len(list)-1].Accept(self)
+		self.emit(instr.PushNil())
+	}
+}
+
+func (self *IRBuilder) VisitIfExpr(node *ast.IfExpr) {
+	self.buildExpr(node.Cond)
+	elseJump := instr.JumpIfFalse(-1)
+	self.emit(elseJump)
+	self.buildBlockValue(node.Body)
+	endJump := instr.Jump(-1)
+	self.emit(endJump)
+	elseJump.Target = self.emit(instr.Label("if_else_label"))
+	if node.Else != nil {
+		self.buildBlockValue(node.Else)
+	} else {
+		self.emit(instr.PushNil())
+	}
+	endJump.Target = self.emit(instr.Label("if_end_label"))
+}
+
+func (self *IRBuilder) VisitCondExpr(node *ast.CondExpr) {
+	self.buildExpr(node.Cond)
+	elseJump := instr.JumpIfFalse(-1)
+	self.emit(elseJump)
+	self.buildExpr(node.X)
+	endJump := instr.Jump(-1)
+	self.emit(endJump)
+	elseJump.Target = self.emit(instr.Label("cond_else_label"))
+	self.buildExpr(node.Y)
+	endJump.Target = self.emit(instr.Label("cond_end_label"))
+}
+
+func (self *IRBuilder) VisitCoalesceExpr(node *ast.CoalesceExpr) {
+	xOffset := self.cc.AddLocalVariable(fmt.Sprintf("#safe#x%d#", safeSeq))
+	safeSeq++
+
+	self.buildExpr(node.X)
+	self.emit(instr.SetLocal(xOffset))
+	self.emit(instr.LoadLocal(xOffset))
+	nilJump := instr.JumpIfNil(-1)
+	self.emit(nilJump)
+	self.emit(instr.LoadLocal(xOffset))
+	endJump := instr.Jump(-1)
+	self.emit(endJump)
+	nilJump.Target = self.emit(instr.Label("coalesce_nil"))
+	self.buildExpr(node.Y)
+	endJump.Target = self.emit(instr.Label("coalesce_end"))
+}
+
+func (self *IRBuilder) VisitSpreadExpr(node *ast.SpreadExpr) {
+	self.Fatalf(node.Ellipsis, "... is only allowed in array patterns")
+}
+
 func (self *IRBuilder) VisitSetExpr(node *ast.SetExpr) {
 	for _, elem := range node.Elems {
 		self.buildExpr(elem)
@@ -252,14 +494,147 @@ func (self *IRBuilder) VisitSetExpr(node *ast.SetExpr) {
 	self.emit(instr.NewSet(len(node.Elems)))
 }
 
+func (self *IRBuilder) VisitTupleExpr(node *ast.TupleExpr) {
+	for _, elem := range node.Elems {
+		self.buildExpr(elem)
+	}
+	self.emit(instr.NewTuple(len(node.Elems)))
+}
+
+// a bare name as a key of a dict literal, a dict pattern or a struct
+// literal is a symbol, #{name: 1} is #{:name: 1} and #{name: n} = d
+// destructures d[:name]. #{(k): 1} is keyed by the value of k
+func isSymbolKey(key ast.Expr) bool {
+	return isBinding(key)
+}
+
+func (self *IRBuilder) buildDictKey(key ast.Expr) {
+	if isSymbolKey(key) {
+		self.emit(instr.PushSymbol(key.(*ast.Ident).Name))
+	} else {
+		self.buildExpr(key)
+	}
+}
+
 func (self *IRBuilder) VisitDictExpr(node *ast.DictExpr) {
 	for _, field := range node.Fields {
-		self.buildExpr(field.Name)
+		self.buildDictKey(field.Name)
 		self.buildExpr(field.Value)
 	}
 	self.emit(instr.NewDict(len(node.Fields)))
 }
 
+// the fields are passed to the type as a dict, positional elements as an
+// array
+func (self *IRBuilder) VisitCompositeLit(node *ast.CompositeLit) {
+	if node.Fields != nil {
+		for _, field := range node.Fields {
+			self.buildDictKey(field.Name)
+			self.buildExpr(field.Value)
+		}
+		self.emit(instr.NewDict(len(node.Fields)))
+	} else {
+		for _, elem := range node.Elems {
+			self.buildExpr(elem)
+		}
+		self.emit(instr.NewArray(len(node.Elems)))
+	}
+	self.buildExpr(node.Type)
+	self.emit(instr.SendMethodAt("__composite__", 1, int(node.Lbrace)))
+}
+
+var compSeq int = 0
+
+// buildCompLoops emits a range loop per clause, innermost around body. one
+// loop variable takes the values, two take keys and values
+func (self *IRBuilder) buildCompLoops(clauses []*ast.CompClause, body func()) {
+	if len(clauses) == 0 {
+		body()
+		return
+	}
+
+	clause := clauses[0]
+	if len(clause.Vars) < 1 || len(clause.Vars) > 2 {
+		self.Fatalf(clause.For, "comprehension needs one or two loop variables")
+	}
+	seq := compSeq
+	iterOffset := self.cc.AddLocalVariable(fmt.Sprintf("#comp%d#", compSeq))
+	xOffset := self.cc.AddLocalVariable(fmt.Sprintf("#comp#x%d#", compSeq))
+	key := clause.Vars[0]
+	val := clause.Vars[len(clause.Vars)-1]
+	if len(clause.Vars) == 1 {
+		key = &ast.Ident{NamePos: clause.For, Name: fmt.Sprintf("#comp#k%d#", compSeq)}
+	}
+	compSeq++
+
+	self.emit(instr.PushInt(0))
+	self.emit(instr.SetLocal(iterOffset))
+	self.buildExpr(clause.X)
+	self.emit(instr.SetLocal(xOffset))
+	self.emit(instr.OpenIter(xOffset))
+
+	// the loop variables are fresh locals, outer ones of the same name are
+	// left alone
+	end := self.scope(targetNames(clause.Vars), func(name string) string {
+		return fmt.Sprintf("#comp%d#%s#", seq, name)
+	})
+	defer end()
+
+	beginLabel := self.emit(instr.Label("comp_start"))
+	self.emit(instr.LoadLocal(iterOffset))
+	self.emit(instr.LoadLocal(xOffset))
+	self.emit(instr.SendMethodAt("__iter__", 1, int(clause.For)))
+	condJump := instr.JumpIfFalse(-1)
+	self.emit(condJump)
+	self.storeTo(clause.For, val, true)
+	self.storeTo(clause.For, key, true)
+
+	if clause.Cond != nil {
+		self.buildExpr(clause.Cond)
+		skipJump := instr.JumpIfFalse(-1)
+		self.emit(skipJump)
+		self.buildCompLoops(clauses[1:], body)
+		skipJump.Target = self.emit(instr.Label("comp_next"))
+	} else {
+		self.buildCompLoops(clauses[1:], body)
+	}
+
+	self.emit(instr.PushInt(1))
+	self.emit(instr.LoadLocal(iterOffset))
+	self.emit(instr.SendMethod("__add__", 1))
+	self.emit(instr.SetLocal(iterOffset))
+	self.emit(instr.Jump(beginLabel))
+	condJump.Target = self.emit(instr.Label("comp_end"))
+}
+
+func (self *IRBuilder) VisitArrayCompExpr(node *ast.ArrayCompExpr) {
+	accOffset := self.cc.AddLocalVariable(fmt.Sprintf("#comp#acc%d#", compSeq))
+	compSeq++
+
+	self.emit(instr.NewArray(0))
+	self.emit(instr.SetLocal(accOffset))
+	self.buildCompLoops(node.Clauses, func() {
+		self.buildExpr(node.Elem)
+		self.emit(instr.Append(accOffset))
+	})
+	self.emit(instr.LoadLocal(accOffset))
+}
+
+func (self *IRBuilder) VisitDictCompExpr(node *ast.DictCompExpr) {
+	accOffset := self.cc.AddLocalVariable(fmt.Sprintf("#comp#acc%d#", compSeq))
+	compSeq++
+
+	self.emit(instr.NewDict(0))
+	self.emit(instr.SetLocal(accOffset))
+	self.buildCompLoops(node.Clauses, func() {
+		self.buildExpr(node.Field.Name)
+		self.buildExpr(node.Field.Value)
+		self.emit(instr.LoadLocal(accOffset))
+		self.emit(instr.SendMethodAt("__set_index__", 2, int(node.Field.ColonPos)))
+	})
+	self.emit(instr.LoadLocal(accOffset))
+}
+
 func (self *IRBuilder) VisitFuncDeclExpr(node *ast.FuncDeclExpr) {
 	funNameOffset := 0
 	if node.Name != nil {
@@ -271,13 +646,31 @@ func (self *IRBuilder) VisitFuncDeclExpr(node *ast.FuncDeclExpr) {
 	}
 
 	n := self.PushClosureProto()
-	for _, arg := range node.Args {
-		self.cc.AddLocalVariable(arg.Name)
+	ranges, literal := self.ranges, self.literal
+	self.ranges, self.literal = nil, node.Name == nil
+	defer func() { self.ranges, self.literal = ranges, literal }()
+	// params hide the names of an enclosing scope
+	end := self.scope(targetNames(node.Args), nil)
+	defer end()
+	// a pattern param arrives in a hidden local and is destructured first
+	for i, arg := range node.Args {
+		name := fmt.Sprintf("#arg%d#", i)
+		if ident, ok := arg.(*ast.Ident); ok {
+			name = ident.Name
+		}
+		self.cc.AddLocalVariable(name)
+		self.cc.AddArg(name)
 	}
 	for i := len(node.Args) - 1; i >= 0; i-- {
 		self.emit(instr.SetLocal(i))
 	}
-	node.Body.Accept(self)
+	for i, arg := range node.Args {
+		if _, ok := arg.(*ast.Ident); !ok {
+			self.emit(instr.LoadLocal(i))
+			self.storeTo(node.Func, arg, true)
+		}
+	}
+	self.buildFuncBody(node.Body)
 	self.PopClosureProto()
 
 	self.emit(instr.PushClosure(n))
@@ -287,6 +680,23 @@ func (self *IRBuilder) VisitFuncDeclExpr(node *ast.FuncDeclExpr) {
 	}
 }
 
+// a body ending in an expression returns its value, without return it
+// gives nil
+func (self *IRBuilder) buildFuncBody(body *ast.BlockStmt) {
+	n := len(body.List)
+	if n == 0 {
+		return
+	}
+	for _, stmt := range body.List[:n-1] {
+		stmt.Accept(self)
+	}
+	if stmt, ok := body.List[n-1].(*ast.ExprStmt); ok {
+		self.VisitReturnStmt(&ast.ReturnStmt{Results: []ast.Expr{stmt.X}})
+	} else {
+		body.List[n-1].Accept(self)
+	}
+}
+
 // stmts
 
 func (self *IRBuilder) VisitExprStmt(node *ast.ExprStmt) {
@@ -297,83 +707,205 @@ func (self *IRBuilder) VisitSendStmt(node *ast.SendStmt) {
 }
 
 func (self *IRBuilder) VisitIncDecStmt(node *ast.IncDecStmt) {
-	self.buildExpr(node.X)
+	one := &ast.BasicLit{ValuePos: node.TokPos, Kind: token.INT, Value: "1"}
 	if node.Tok == token.INC {
-		self.emit(instr.SendMethod("__inc__", 0))
+		self.buildUpdate(node.TokPos, node.X, "__add__", one)
 	} else if node.Tok == token.DEC {
-		self.emit(instr.SendMethod("__dec__", 0))
+		self.buildUpdate(node.TokPos, node.X, "__sub__", one)
 	}
 }
 
 func (self *IRBuilder) VisitAssignStmt(node *ast.AssignStmt) {
 	if node.Tok == token.ASSIGN {
-		for i := 0; i < len(node.Rhs); i++ {
-			self.buildExpr(node.Rhs[i])
-		}
-
-		for i := len(node.Lhs) - 1; i >= 0; i-- {
-			switch v := node.Lhs[i].(type) {
-			case *ast.Ident:
-				exist, offset := self.cc.LookUpLocal(v.Name)
-				if exist {
-					self.emit(instr.SetLocal(offset))
-					continue
-				}
-				exist, offset = self.cc.LookUpUpval(v.Name)
-				if exist {
-					self.emit(instr.SetUpval(offset))
-					continue
-				}
-				_, depth, offset := self.cc.LookUpOuter(v.Name)
-				if depth < 1 {
-					offset := self.cc.AddLocalVariable(v.Name)
-					self.emit(instr.SetLocal(offset))
-					continue
-				} else {
-					offset := self.cc.AddUpvalVariable(v.Name, depth, offset)
-					self.emit(instr.SetUpval(offset))
-					continue
-				}
+		// a single element or property store needs no temporary
+		if len(node.Lhs) == 1 && len(node.Rhs) == 1 {
+			switch v := node.Lhs[0].(type) {
 			case *ast.IndexExpr:
 				self.buildExpr(v.Index)
 				self.buildExpr(node.Rhs[0])
 				self.buildExpr(v.X)
-				self.emit(instr.SendMethod("__set_index__", 2))
+				self.emit(instr.SendMethodAt("__set_index__", 2, int(v.Lbrack)))
+				return
+			case *ast.SliceExpr:
+				self.buildSliceBounds(v)
+				self.buildExpr(node.Rhs[0])
+				self.buildExpr(v.X)
+				self.emit(instr.SendMethodAt("__set_slice__", 4, int(v.Lbrack)))
+				return
 			case *ast.SelectorExpr:
-				self.emit(instr.PushString(v.Sel.Name))
+				if v.Safe {
+					break
+				}
+				self.emit(instr.PushSymbol(v.Sel.Name))
 				self.buildExpr(node.Rhs[0])
 				self.buildExpr(v.X)
-				self.emit(instr.SendMethod("__set_property__", 2))
+				self.emit(instr.SendMethodAt("__set_property__", 2, int(v.Sel.NamePos)))
+				return
 			}
 		}
+
+		for i := 0; i < len(node.Rhs); i++ {
+			self.buildExpr(node.Rhs[i])
+		}
+		for i := len(node.Lhs) - 1; i >= 0; i-- {
+			self.storeTo(node.TokPos, node.Lhs[i], false)
+		}
 	} else {
 		for i := 0; i < len(node.Lhs); i++ {
-			self.buildExpr(node.Rhs[i])
+			self.buildUpdate(node.TokPos, node.Lhs[i], OpFuncs[node.Tok], node.Rhs[i])
+		}
+	}
+}
 
-			switch v := node.Lhs[i].(type) {
-			case *ast.Ident:
-				exist, offset := self.cc.LookUpLocal(v.Name)
-				if exist {
-					self.emit(instr.LoadLocal(offset))
-					goto out
-				}
-				exist, offset = self.cc.LookUpUpval(v.Name)
-				if exist {
-					self.emit(instr.LoadUpval(offset))
-					goto out
+var storeSeq int = 0
+
+// storeTo pops the value on top of the stack into an lvalue. array and dict
+// patterns destructure the value, define makes the names in the target
+// locals of the current function, as for params and range variables
+func (self *IRBuilder) storeTo(pos token.Pos, lhs ast.Expr, define bool) {
+	switch v := lhs.(type) {
+	case *ast.Ident:
+		if define {
+			self.emit(instr.SetLocal(self.cc.AddLocalVariable(self.resolve(v.Name))))
+		} else {
+			self.storeVariable(v.Name)
+		}
+	case *ast.IndexExpr:
+		valOffset := self.cc.AddLocalVariable(fmt.Sprintf("#store#v%d#", storeSeq))
+		storeSeq++
+		self.emit(instr.SetLocal(valOffset))
+		self.buildExpr(v.Index)
+		self.emit(instr.LoadLocal(valOffset))
+		self.buildExpr(v.X)
+		self.emit(instr.SendMethodAt("__set_index__", 2, int(v.Lbrack)))
+	case *ast.SliceExpr:
+		valOffset := self.cc.AddLocalVariable(fmt.Sprintf("#store#v%d#", storeSeq))
+		storeSeq++
+		self.emit(instr.SetLocal(valOffset))
+		self.buildSliceBounds(v)
+		self.emit(instr.LoadLocal(valOffset))
+		self.buildExpr(v.X)
+		self.emit(instr.SendMethodAt("__set_slice__", 4, int(v.Lbrack)))
+	case *ast.SelectorExpr:
+		if v.Safe {
+			self.Fatalf(pos, "cannot assign to a ?. selector")
+		}
+		valOffset := self.cc.AddLocalVariable(fmt.Sprintf("#store#v%d#", storeSeq))
+		storeSeq++
+		self.emit(instr.SetLocal(valOffset))
+		self.emit(instr.PushSymbol(v.Sel.Name))
+		self.emit(instr.LoadLocal(valOffset))
+		self.buildExpr(v.X)
+		self.emit(instr.SendMethodAt("__set_property__", 2, int(v.Sel.NamePos)))
+	case *ast.ArrayExpr:
+		rest := -1
+		for i, elem := range v.Elems {
+			if _, ok := elem.(*ast.SpreadExpr); ok {
+				if rest >= 0 {
+					self.Fatalf(v.Lbrack, "more than one ... in an array pattern")
 				}
-			case *ast.IndexExpr:
-				self.buildExpr(v.Index)
-				self.buildExpr(v.X)
-				self.emit(instr.SendMethod("__get_index__", 1))
-			case *ast.SelectorExpr:
-				self.emit(instr.PushString(v.Sel.Name))
-				self.buildExpr(v.X)
-				self.emit(instr.SendMethod("__get_property__", 1))
+				rest = i
+			}
+		}
+		num := len(v.Elems)
+		if rest >= 0 {
+			num--
+		}
+		self.emit(instr.Unpack(num, rest, int(v.Lbrack)))
+		for i := len(v.Elems) - 1; i >= 0; i-- {
+			if spread, ok := v.Elems[i].(*ast.SpreadExpr); ok {
+				self.storeTo(spread.Ellipsis, spread.X, define)
+			} else {
+				self.storeTo(v.Lbrack, v.Elems[i], define)
 			}
-		out:
-			self.emit(instr.SendMethod(OpFuncs[node.Tok], 1))
 		}
+	case *ast.DictExpr:
+		for _, field := range v.Fields {
+			self.buildDictKey(field.Name)
+		}
+		self.emit(instr.UnpackDict(len(v.Fields), int(v.Lbrace)))
+		for i := len(v.Fields) - 1; i >= 0; i-- {
+			self.storeTo(v.Fields[i].ColonPos, v.Fields[i].Value, define)
+		}
+	default:
+		self.Fatalf(pos, "cannot assign to %T", lhs)
+	}
+}
+
+// store the value on top of the stack into a variable, defining it as a
+// local when it is not visible yet
+func (self *IRBuilder) storeVariable(name string) {
+	name = self.resolve(name)
+	exist, offset := self.cc.LookUpLocal(name)
+	if exist {
+		self.emit(instr.SetLocal(offset))
+		return
+	}
+	exist, offset = self.cc.LookUpUpval(name)
+	if exist {
+		self.emit(instr.SetUpval(offset))
+		return
+	}
+	_, depth, offset := self.cc.LookUpOuter(name)
+	if depth < 1 {
+		offset := self.cc.AddLocalVariable(name)
+		self.emit(instr.SetLocal(offset))
+	} else {
+		offset := self.cc.AddUpvalVariable(name, depth, offset)
+		self.emit(instr.SetUpval(offset))
+	}
+}
+
+var updateSeq int = 0
+
+// x op= y and x++ compute x op y and store the result back into x, numbers
+// and strings are values and are never changed in place. the container and
+// index of an element are kept in hidden locals so they are evaluated once
+func (self *IRBuilder) buildUpdate(pos token.Pos, lhs ast.Expr, method string, rhs ast.Expr) {
+	switch v := lhs.(type) {
+	case *ast.Ident:
+		self.buildExpr(rhs)
+		self.buildExpr(v)
+		self.emit(instr.SendMethodAt(method, 1, int(pos)))
+		self.storeVariable(v.Name)
+	case *ast.IndexExpr:
+		xOffset := self.cc.AddLocalVariable(fmt.Sprintf("#update#x%d#", updateSeq))
+		idxOffset := self.cc.AddLocalVariable(fmt.Sprintf("#update#i%d#", updateSeq))
+		updateSeq++
+
+		self.buildExpr(v.X)
+		self.emit(instr.SetLocal(xOffset))
+		self.buildExpr(v.Index)
+		self.emit(instr.SetLocal(idxOffset))
+
+		self.emit(instr.LoadLocal(idxOffset))
+		self.buildExpr(rhs)
+		self.emit(instr.LoadLocal(idxOffset))
+		self.emit(instr.LoadLocal(xOffset))
+		self.emit(instr.SendMethodAt("__get_index__", 1, int(v.Lbrack)))
+		self.emit(instr.SendMethodAt(method, 1, int(pos)))
+		self.emit(instr.LoadLocal(xOffset))
+		self.emit(instr.SendMethodAt("__set_index__", 2, int(v.Lbrack)))
+	case *ast.SelectorExpr:
+		if v.Safe {
+			self.Fatalf(pos, "cannot assign to a ?. selector")
+		}
+		xOffset := self.cc.AddLocalVariable(fmt.Sprintf("#update#x%d#", updateSeq))
+		updateSeq++
+
+		self.buildExpr(v.X)
+		self.emit(instr.SetLocal(xOffset))
+
+		self.emit(instr.PushSymbol(v.Sel.Name))
+		self.buildExpr(rhs)
+		self.emit(instr.PushSymbol(v.Sel.Name))
+		self.emit(instr.LoadLocal(xOffset))
+		self.emit(instr.SendMethodAt("__get_property__", 1, int(v.Sel.NamePos)))
+		self.emit(instr.SendMethodAt(method, 1, int(pos)))
+		self.emit(instr.LoadLocal(xOffset))
+		self.emit(instr.SendMethodAt("__set_property__", 2, int(v.Sel.NamePos)))
+	default:
+		self.Fatalf(pos, "cannot assign to %T", lhs)
 	}
 }
 
@@ -386,9 +918,27 @@ func (self *IRBuilder) VisitReturnStmt(node *ast.ReturnStmt) {
 	for _, res := range node.Results {
 		self.buildExpr(res)
 	}
+	for i := len(self.ranges) - 1; i >= 0; i-- {
+		self.emit(instr.CloseIter(self.ranges[i]))
+	}
 	self.emit(instr.RaiseReturn(len(node.Results)))
 }
 
+func (self *IRBuilder) VisitYieldStmt(node *ast.YieldStmt) {
+	if self.cc.OuterClosureProto() == nil {
+		self.Fatalf(node.Yield, "yield outside of a function")
+	}
+	// a callback like arr.Each(func(x) { yield x }) would become a
+	// generator of its own, which is never what was meant
+	if self.literal {
+		self.Fatalf(node.Yield, "yield in a func literal, only a named func can be a generator")
+	}
+	// any function containing a yield is a generator
+	self.cc.SetGenerator()
+	self.buildExpr(node.Value)
+	self.emit(instr.Yield())
+}
+
 func (self *IRBuilder) VisitBranchStmt(node *ast.BranchStmt) {
 	if node.Tok == token.BREAK {
 		self.emit(instr.RaiseBreak())
@@ -526,11 +1076,14 @@ func (self *IRBuilder) VisitRangeStmt(node *ast.RangeStmt) {
 	pushBlockInstr := instr.PushBlock(-1)
 	self.emit(pushBlockInstr)
 
-	keyName := node.KeyValue[0].(*ast.Ident).Name
-	valName := node.KeyValue[1].(*ast.Ident).Name
-
-	keyOffset := self.cc.AddLocalVariable(keyName)
-	valOffset := self.cc.AddLocalVariable(valName)
+	// for k = range x, the value is stored in a hidden local
+	key := node.KeyValue[0]
+	var val ast.Expr
+	if len(node.KeyValue) > 1 {
+		val = node.KeyValue[1]
+	} else {
+		val = &ast.Ident{NamePos: node.For, Name: fmt.Sprintf("#iter#v%d#", iterSeq)}
+	}
 	iterOffset := self.cc.AddLocalVariable(fmt.Sprintf("#iter%d#", iterSeq))
 	xOffset := self.cc.AddLocalVariable(fmt.Sprintf("#iter#x%d#", iterSeq))
 	iterSeq++
@@ -540,6 +1093,7 @@ func (self *IRBuilder) VisitRangeStmt(node *ast.RangeStmt) {
 
 	self.buildExpr(node.X)
 	self.emit(instr.SetLocal(xOffset))
+	self.emit(instr.OpenIter(xOffset))
 
 	beginLabel := self.emit(instr.Label("for_range_start"))
 	self.emit(instr.LoadLocal(iterOffset))
@@ -548,11 +1102,15 @@ func (self *IRBuilder) VisitRangeStmt(node *ast.RangeStmt) {
 	self.emit(instr.SendMethod("__iter__", 1))
 	condJump := instr.JumpIfFalse(-1)
 	self.emit(condJump)
-	self.emit(instr.SetLocal(valOffset))
-	self.emit(instr.SetLocal(keyOffset))
+	self.storeTo(node.For, val, true)
+	self.storeTo(node.For, key, true)
+	self.ranges = append(self.ranges, xOffset)
 	node.Body.Accept(self)
+	self.ranges = self.ranges[:len(self.ranges)-1]
+	self.emit(instr.PushInt(1))
 	self.emit(instr.LoadLocal(iterOffset))
-	self.emit(instr.SendMethod("__inc__", 0))
+	self.emit(instr.SendMethod("__add__", 1))
+	self.emit(instr.SetLocal(iterOffset))
 	self.emit(instr.Jump(beginLabel))
 	endLabel := self.emit(instr.Label("for_range_end"))
 	condJump.Target = endLabel
@@ -560,6 +1118,8 @@ func (self *IRBuilder) VisitRangeStmt(node *ast.RangeStmt) {
 	popBlockInstr := instr.PopBlock(-1)
 	pc := self.emit(popBlockInstr)
 	pushBlockInstr.Target = pc
+	// a break lands here too
+	self.emit(instr.CloseIter(xOffset))
 }
 
 func (self *IRBuilder) VisitImportStmt(node *ast.ImportStmt) {
@@ -0,0 +1,316 @@
+package comp
+
+import (
+	"fmt"
+
+	"github.com/jxwr/doby/ast"
+	"github.com/jxwr/doby/vm/instr"
+)
+
+/// match
+
+// T(p) is a type pattern when T is one of these, see Runtime.MatchType
+var patternTypes = map[string]bool{
+	"int": true, "bigint": true, "float": true, "complex": true, "number": true,
+	"string": true, "symbol": true, "rune": true, "bytes": true, "bool": true, "nil": true,
+	"array": true, "dict": true, "set": true, "tuple": true, "function": true, "generator": true,
+}
+
+func typePattern(pat ast.Expr) (name string, arg ast.Expr, ok bool) {
+	call, isCall := pat.(*ast.CallExpr)
+	if !isCall || len(call.Args) > 1 {
+		return
+	}
+	fun, isIdent := call.Fun.(*ast.Ident)
+	if !isIdent || !patternTypes[fun.Name] {
+		return
+	}
+	if len(call.Args) == 1 {
+		arg = call.Args[0]
+	}
+	return fun.Name, arg, true
+}
+
+func isBinding(pat ast.Expr) bool {
+	ident, ok := pat.(*ast.Ident)
+	return ok && ident.Name != "nil" && ident.Name != "true" && ident.Name != "false"
+}
+
+// the names a pattern binds, in order and without repeats
+func bindingNames(pat ast.Expr, names []string) []string {
+	if _, arg, ok := typePattern(pat); ok {
+		if arg != nil {
+			names = bindingNames(arg, names)
+		}
+		return names
+	}
+
+	switch p := pat.(type) {
+	case *ast.Ident:
+		if isBinding(p) && p.Name != "_" && !ContainsString(names, p.Name) {
+			names = append(names, p.Name)
+		}
+	case *ast.ArrayExpr:
+		for _, elem := range p.Elems {
+			names = bindingNames(elem, names)
+		}
+	case *ast.SpreadExpr:
+		names = bindingNames(p.X, names)
+	case *ast.DictExpr:
+		for _, field := range p.Fields {
+			names = bindingNames(field.Value, names)
+		}
+	}
+	return names
+}
+
+// definePattern defines the names a pattern binds and checks the values
+// it refers to
+func (self *Attr) definePattern(pat ast.Expr) {
+	if _, arg, ok := typePattern(pat); ok {
+		if arg != nil {
+			self.definePattern(arg)
+		}
+		return
+	}
+
+	switch p := pat.(type) {
+	case *ast.Ident:
+		if isBinding(p) && p.Name != "_" {
+			self.defineNames(p)
+		}
+	case *ast.ArrayExpr:
+		for _, elem := range p.Elems {
+			self.definePattern(elem)
+		}
+	case *ast.SpreadExpr:
+		self.definePattern(p.X)
+	case *ast.DictExpr:
+		for _, field := range p.Fields {
+			self.checkDictKey(field.Name)
+			self.definePattern(field.Value)
+		}
+	case *ast.RangeExpr:
+		self.checkIdentRef(p.Low)
+		self.checkIdentRef(p.High)
+	default:
+		self.checkIdentRef(p)
+	}
+}
+
+func (self *Attr) VisitRangeExpr(node *ast.RangeExpr) {
+	self.checkIdentRef(node.Low)
+	self.checkIdentRef(node.High)
+}
+
+func (self *Attr) VisitMatchExpr(node *ast.MatchExpr) {
+	self.checkIdentRef(node.X)
+	for _, stmt := range node.Body.List {
+		clause := stmt.(*ast.CaseClause)
+		for _, pat := range clause.List {
+			self.definePattern(pat)
+		}
+		if clause.Guard != nil {
+			self.checkIdentRef(clause.Guard)
+		}
+		self.Enter()
+		for _, s := range clause.Body {
+			s.Accept(self)
+		}
+		self.Leave()
+	}
+}
+
+var matchSeq int = 0
+
+// buildPattern tests the value in local subj against a pattern and binds
+// its names to the hidden locals named by hidden, every failing test jumps
+// to a target patched by the caller
+func (self *IRBuilder) buildPattern(pat ast.Expr, subj int, hidden func(string) string,
+	fails *[]*instr.JumpIfFalseInstr) {
+	test := func(match instr.Instr) {
+		self.emit(match)
+		jump := instr.JumpIfFalse(-1)
+		self.emit(jump)
+		*fails = append(*fails, jump)
+	}
+
+	// the elements of a matched array or dict are unpacked into hidden
+	// locals and matched in turn
+	unpackTo := func(n int) []int {
+		offsets := make([]int, n)
+		for i := range offsets {
+			offsets[i] = self.cc.AddLocalVariable(fmt.Sprintf("#match#v%d#", matchSeq))
+			matchSeq++
+		}
+		for i := n - 1; i >= 0; i-- {
+			self.emit(instr.SetLocal(offsets[i]))
+		}
+		return offsets
+	}
+
+	if name, arg, ok := typePattern(pat); ok {
+		self.emit(instr.LoadLocal(subj))
+		test(instr.MatchType(name))
+		if arg != nil {
+			self.buildPattern(arg, subj, hidden, fails)
+		}
+		return
+	}
+
+	switch p := pat.(type) {
+	case *ast.Ident:
+		if !isBinding(p) {
+			break
+		}
+		if p.Name != "_" {
+			self.emit(instr.LoadLocal(subj))
+			self.emit(instr.SetLocal(self.cc.AddLocalVariable(hidden(p.Name))))
+		}
+		return
+	case *ast.RangeExpr:
+		self.buildExpr(p.Low)
+		self.buildExpr(p.High)
+		self.emit(instr.LoadLocal(subj))
+		test(instr.MatchRange())
+		return
+	case *ast.ArrayExpr:
+		rest := -1
+		for i, elem := range p.Elems {
+			if _, ok := elem.(*ast.SpreadExpr); ok {
+				if rest >= 0 {
+					self.Fatalf(p.Lbrack, "more than one ... in an array pattern")
+				}
+				rest = i
+			}
+		}
+		num := len(p.Elems)
+		if rest >= 0 {
+			num--
+		}
+		self.emit(instr.LoadLocal(subj))
+		test(instr.MatchArray(num, rest))
+		self.emit(instr.LoadLocal(subj))
+		self.emit(instr.Unpack(num, rest, int(p.Lbrack)))
+		offsets := unpackTo(len(p.Elems))
+		for i, elem := range p.Elems {
+			if spread, ok := elem.(*ast.SpreadExpr); ok {
+				elem = spread.X
+			}
+			self.buildPattern(elem, offsets[i], hidden, fails)
+		}
+		return
+	case *ast.DictExpr:
+		for _, field := range p.Fields {
+			self.buildDictKey(field.Name)
+		}
+		self.emit(instr.LoadLocal(subj))
+		test(instr.MatchDict(len(p.Fields)))
+		self.emit(instr.LoadLocal(subj))
+		for _, field := range p.Fields {
+			self.buildDictKey(field.Name)
+		}
+		self.emit(instr.UnpackDict(len(p.Fields), int(p.Lbrace)))
+		offsets := unpackTo(len(p.Fields))
+		for i, field := range p.Fields {
+			self.buildPattern(field.Value, offsets[i], hidden, fails)
+		}
+		return
+	}
+
+	// anything else is a value compared with the subject
+	self.buildExpr(pat)
+	self.emit(instr.LoadLocal(subj))
+	test(instr.MatchValue())
+}
+
+// a clause matches when one of its patterns does and the guard holds, the
+// value of the match is the value of the clause body as in an if-expression
+func (self *IRBuilder) VisitMatchExpr(node *ast.MatchExpr) {
+	subj := self.cc.AddLocalVariable(fmt.Sprintf("#match%d#", matchSeq))
+	matchSeq++
+
+	self.buildExpr(node.X)
+	self.emit(instr.SetLocal(subj))
+
+	endJumps := []*instr.JumpInstr{}
+	for _, stmt := range node.Body.List {
+		clause := stmt.(*ast.CaseClause)
+
+		// the names are bound to hidden locals first, a failed pattern or
+		// guard leaves the variables alone
+		names := []string{}
+		for _, pat := range clause.List {
+			names = bindingNames(pat, names)
+		}
+		seq := matchSeq
+		matchSeq++
+		hidden := func(name string) string {
+			return fmt.Sprintf("#match%d#%s#", seq, name)
+		}
+
+		// default has no patterns
+		var failJump *instr.JumpInstr
+		if clause.List != nil {
+			matched := []*instr.JumpInstr{}
+			for _, pat := range clause.List {
+				fails := []*instr.JumpIfFalseInstr{}
+				self.buildPattern(pat, subj, hidden, &fails)
+				jump := instr.Jump(-1)
+				self.emit(jump)
+				matched = append(matched, jump)
+
+				nextPc := self.emit(instr.Label("match_next_pattern"))
+				for _, fail := range fails {
+					fail.Target = nextPc
+				}
+			}
+			failJump = instr.Jump(-1)
+			self.emit(failJump)
+			bodyPc := self.emit(instr.Label("match_body"))
+			for _, jump := range matched {
+				jump.Target = bodyPc
+			}
+		}
+
+		var guardJump *instr.JumpIfFalseInstr
+		if clause.Guard != nil {
+			end := self.scope(names, hidden)
+			self.buildExpr(clause.Guard)
+			end()
+			guardJump = instr.JumpIfFalse(-1)
+			self.emit(guardJump)
+		}
+		for _, name := range names {
+			self.emit(instr.LoadLocal(self.cc.AddLocalVariable(hidden(name))))
+			self.emit(instr.SetLocal(self.cc.AddLocalVariable(self.resolve(name))))
+		}
+		self.buildBlockValue(&ast.BlockStmt{Lbrace: clause.Colon, List: clause.Body})
+		endJump := instr.Jump(-1)
+		self.emit(endJump)
+		endJumps = append(endJumps, endJump)
+
+		nextPc := self.emit(instr.Label("match_next_case"))
+		if failJump != nil {
+			failJump.Target = nextPc
+		}
+		if guardJump != nil {
+			guardJump.Target = nextPc
+		}
+	}
+
+	self.emit(instr.LoadLocal(subj))
+	self.emit(instr.NoMatch(int(node.Match)))
+
+	endPc := self.emit(instr.Label("match_end"))
+	for _, jump := range endJumps {
+		jump.Target = endPc
+	}
+}
+
+// outside of a pattern lo..hi is a range of integers
+func (self *IRBuilder) VisitRangeExpr(node *ast.RangeExpr) {
+	self.buildExpr(node.Low)
+	self.buildExpr(node.High)
+	self.emit(instr.NewRange(int(node.OpPos)))
+}
@@ -2,6 +2,8 @@ package comp
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/jxwr/doby/ast"
 	"github.com/jxwr/doby/token"
@@ -55,7 +57,32 @@ func (self *PrettyPrinter) VisitIdent(node *ast.Ident) {
 func (self *PrettyPrinter) VisitBasicLit(node *ast.BasicLit) {
 	self.debug(node)
 
-	puts(node.Value)
+	if node.Kind == token.STRING {
+		puts(strconv.Quote(node.Value))
+	} else if node.Kind == token.SYMBOL {
+		puts(":" + node.Value)
+	} else if node.Kind == token.REGEXP {
+		puts("/" + node.Value)
+	} else {
+		puts(node.Value)
+	}
+}
+
+func (self *PrettyPrinter) VisitInterpExpr(node *ast.InterpExpr) {
+	self.debug(node)
+
+	puts("\"")
+	for _, part := range node.Parts {
+		if lit, ok := part.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+			q := strconv.Quote(lit.Value)
+			puts(strings.Replace(q[1:len(q)-1], "#{", "\\#{", -1))
+		} else {
+			puts("#{")
+			part.Accept(self)
+			puts("}")
+		}
+	}
+	puts("\"")
 }
 
 func (self *PrettyPrinter) VisitParenExpr(node *ast.ParenExpr) {
@@ -70,7 +97,11 @@ func (self *PrettyPrinter) VisitSelectorExpr(node *ast.SelectorExpr) {
 	self.debug(node)
 
 	node.X.Accept(self)
-	puts(".")
+	if node.Safe {
+		puts("?.")
+	} else {
+		puts(".")
+	}
 	node.Sel.Accept(self)
 }
 
@@ -88,9 +119,17 @@ func (self *PrettyPrinter) VisitSliceExpr(node *ast.SliceExpr) {
 
 	node.X.Accept(self)
 	puts("[")
-	node.Low.Accept(self)
+	if node.Low != nil {
+		node.Low.Accept(self)
+	}
 	puts(":")
-	node.High.Accept(self)
+	if node.High != nil {
+		node.High.Accept(self)
+	}
+	if node.Step != nil {
+		puts(":")
+		node.Step.Accept(self)
+	}
 	puts("]")
 }
 
@@ -139,6 +178,61 @@ func (self *PrettyPrinter) VisitArrayExpr(node *ast.ArrayExpr) {
 	puts("]")
 }
 
+func (self *PrettyPrinter) VisitIfExpr(node *ast.IfExpr) {
+	self.debug(node)
+
+	puts("if ")
+	node.Cond.Accept(self)
+	puts(" ")
+	node.Body.Accept(self)
+	if node.Else != nil {
+		puts(" else ")
+		node.Else.Accept(self)
+	}
+}
+
+func (self *PrettyPrinter) VisitCondExpr(node *ast.CondExpr) {
+	self.debug(node)
+
+	node.Cond.Accept(self)
+	puts(" ? ")
+	node.X.Accept(self)
+	puts(" : ")
+	node.Y.Accept(self)
+}
+
+func (self *PrettyPrinter) VisitCoalesceExpr(node *ast.CoalesceExpr) {
+	self.debug(node)
+
+	node.X.Accept(self)
+	puts(" ?? ")
+	node.Y.Accept(self)
+}
+
+func (self *PrettyPrinter) VisitRangeExpr(node *ast.RangeExpr) {
+	self.debug(node)
+
+	node.Low.Accept(self)
+	puts("..")
+	node.High.Accept(self)
+}
+
+func (self *PrettyPrinter) VisitMatchExpr(node *ast.MatchExpr) {
+	self.debug(node)
+
+	puts("match ")
+	node.X.Accept(self)
+	puts(" ")
+	node.Body.Accept(self)
+}
+
+func (self *PrettyPrinter) VisitSpreadExpr(node *ast.SpreadExpr) {
+	self.debug(node)
+
+	puts("...")
+	node.X.Accept(self)
+}
+
 func (self *PrettyPrinter) VisitSetExpr(node *ast.SetExpr) {
 	self.debug(node)
 
@@ -152,6 +246,19 @@ func (self *PrettyPrinter) VisitSetExpr(node *ast.SetExpr) {
 	puts("]")
 }
 
+func (self *PrettyPrinter) VisitTupleExpr(node *ast.TupleExpr) {
+	self.debug(node)
+
+	puts("#(")
+	for i, elem := range node.Elems {
+		elem.Accept(self)
+		if i < len(node.Elems)-1 {
+			puts(", ")
+		}
+	}
+	puts(")")
+}
+
 func (self *PrettyPrinter) VisitDictExpr(node *ast.DictExpr) {
 	self.debug(node)
 	puts("#{")
@@ -167,6 +274,65 @@ func (self *PrettyPrinter) VisitDictExpr(node *ast.DictExpr) {
 	puts("}")
 }
 
+func (self *PrettyPrinter) VisitCompositeLit(node *ast.CompositeLit) {
+	self.debug(node)
+	node.Type.Accept(self)
+	puts("{")
+	for i, field := range node.Fields {
+		field.Name.Accept(self)
+		puts(": ")
+		field.Value.Accept(self)
+		if i < len(node.Fields)-1 {
+			puts(", ")
+		}
+	}
+	for i, elem := range node.Elems {
+		elem.Accept(self)
+		if i < len(node.Elems)-1 {
+			puts(", ")
+		}
+	}
+	puts("}")
+}
+
+func (self *PrettyPrinter) printCompClauses(clauses []*ast.CompClause) {
+	for _, clause := range clauses {
+		puts(" for ")
+		for i, v := range clause.Vars {
+			v.Accept(self)
+			if i < len(clause.Vars)-1 {
+				puts(", ")
+			}
+		}
+		puts(" in ")
+		clause.X.Accept(self)
+		if clause.Cond != nil {
+			puts(" if ")
+			clause.Cond.Accept(self)
+		}
+	}
+}
+
+func (self *PrettyPrinter) VisitArrayCompExpr(node *ast.ArrayCompExpr) {
+	self.debug(node)
+
+	puts("[")
+	node.Elem.Accept(self)
+	self.printCompClauses(node.Clauses)
+	puts("]")
+}
+
+func (self *PrettyPrinter) VisitDictCompExpr(node *ast.DictCompExpr) {
+	self.debug(node)
+
+	puts("#{")
+	node.Field.Name.Accept(self)
+	puts(":")
+	node.Field.Value.Accept(self)
+	self.printCompClauses(node.Clauses)
+	puts("}")
+}
+
 func (self *PrettyPrinter) VisitFuncDeclExpr(node *ast.FuncDeclExpr) {
 	self.debug(node)
 
@@ -260,6 +426,14 @@ func (self *PrettyPrinter) VisitReturnStmt(node *ast.ReturnStmt) {
 	self.putln()
 }
 
+func (self *PrettyPrinter) VisitYieldStmt(node *ast.YieldStmt) {
+	self.debug(node)
+
+	puts("yield ")
+	node.Value.Accept(self)
+	self.putln()
+}
+
 func (self *PrettyPrinter) VisitBranchStmt(node *ast.BranchStmt) {
 	self.debug(node)
 
@@ -310,6 +484,10 @@ func (self *PrettyPrinter) VisitCaseClause(node *ast.CaseClause) {
 			puts(", ")
 		}
 	}
+	if node.Guard != nil {
+		puts(" if ")
+		node.Guard.Accept(self)
+	}
 	puts(":")
 	self.putln()
 	self.indent++
@@ -361,9 +539,12 @@ func (self *PrettyPrinter) VisitRangeStmt(node *ast.RangeStmt) {
 
 	puts("for ")
 	self.showNewLine = false
-	node.KeyValue[0].Accept(self)
-	puts(", ")
-	node.KeyValue[1].Accept(self)
+	for i, kv := range node.KeyValue {
+		kv.Accept(self)
+		if i < len(node.KeyValue)-1 {
+			puts(", ")
+		}
+	}
 	puts(" = range ")
 	node.X.Accept(self)
 	puts(" ")
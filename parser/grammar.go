@@ -1,41 +1,44 @@
+// Code generated by goyacc -o grammar.go -p Doby grammar.y. DO NOT EDIT.
 
 //line grammar.y:2
 
 package parser
+
 import __yyfmt__ "fmt"
+
 //line grammar.y:3
-		
+
 import (
-       "github.com/jxwr/doby/ast"
-       "github.com/jxwr/doby/token"
+	"github.com/jxwr/doby/ast"
+	"github.com/jxwr/doby/token"
 )
 
 var ProgramAst []ast.Stmt
 
 type Tok struct {
-    Lit string
-    Line int
-    Col int
-    Pos token.Pos
+	Lit  string
+	Line int
+	Col  int
+	Pos  token.Pos
 }
 
 func (t Tok) String() string {
-    return t.Lit
+	return t.Lit
 }
 
-
 //line grammar.y:27
 type DobySymType struct {
-	yys int
-    node ast.Node
-    expr ast.Expr
-    expr_list []ast.Expr
-    stmt ast.Stmt
-    stmt_list []ast.Stmt
-    field *ast.Field
-    field_list []*ast.Field
-    ident_list []*ast.Ident
-    tok Tok
+	yys         int
+	node        ast.Node
+	expr        ast.Expr
+	expr_list   []ast.Expr
+	stmt        ast.Stmt
+	stmt_list   []ast.Stmt
+	field       *ast.Field
+	field_list  []*ast.Field
+	clause      *ast.CompClause
+	clause_list []*ast.CompClause
+	tok         Tok
 }
 
 const EOF = 57346
@@ -44,91 +47,118 @@ const COMMENT = 57348
 const IDENT = 57349
 const INT = 57350
 const FLOAT = 57351
-const STRING = 57352
-const CHAR = 57353
-const SHL = 57354
-const SHR = 57355
-const AND_NOT = 57356
-const ADD_ASSIGN = 57357
-const SUB_ASSIGN = 57358
-const MUL_ASSIGN = 57359
-const QUO_ASSIGN = 57360
-const REM_ASSIGN = 57361
-const AND_ASSIGN = 57362
-const OR_ASSIGN = 57363
-const XOR_ASSIGN = 57364
-const SHL_ASSIGN = 57365
-const SHR_ASSIGN = 57366
-const AND_NOT_ASSIGN = 57367
-const LAND = 57368
-const LOR = 57369
-const ARROW = 57370
-const INC = 57371
-const DEC = 57372
-const EQL = 57373
-const NEQ = 57374
-const LEQ = 57375
-const GEQ = 57376
-const DEFINE = 57377
-const ELLIPSIS = 57378
-const ADD = 57379
-const SUB = 57380
-const MUL = 57381
-const QUO = 57382
-const REM = 57383
-const AND = 57384
-const OR = 57385
-const XOR = 57386
-const LSS = 57387
-const GTR = 57388
-const ASSIGN = 57389
-const NOT = 57390
-const LPAREN = 57391
-const LBRACK = 57392
-const LBRACE = 57393
-const COMMA = 57394
-const PERIOD = 57395
-const RPAREN = 57396
-const RBRACK = 57397
-const RBRACE = 57398
-const SEMICOLON = 57399
-const COLON = 57400
-const BREAK = 57401
-const CASE = 57402
-const CHAN = 57403
-const CONTINUE = 57404
-const CONST = 57405
-const DEFAULT = 57406
-const DEFER = 57407
-const ELSE = 57408
-const FALLTHROUGH = 57409
-const FOR = 57410
-const FUNC = 57411
-const GO = 57412
-const GOTO = 57413
-const IF = 57414
-const IMPORT = 57415
-const INTERFACE = 57416
-const MAP = 57417
-const PACKAGE = 57418
-const RANGE = 57419
-const RETURN = 57420
-const SELECT = 57421
-const STRUCT = 57422
-const SWITCH = 57423
-const TYPE = 57424
-const VAR = 57425
-const UMINUS = 57426
-
-var DobyToknames = []string{
+const IMAG = 57352
+const STRING = 57353
+const CHAR = 57354
+const SYMBOL = 57355
+const REGEXP = 57356
+const STRING_HEAD = 57357
+const STRING_MID = 57358
+const STRING_TAIL = 57359
+const SHL = 57360
+const SHR = 57361
+const AND_NOT = 57362
+const ADD_ASSIGN = 57363
+const SUB_ASSIGN = 57364
+const MUL_ASSIGN = 57365
+const QUO_ASSIGN = 57366
+const REM_ASSIGN = 57367
+const POW_ASSIGN = 57368
+const AND_ASSIGN = 57369
+const OR_ASSIGN = 57370
+const XOR_ASSIGN = 57371
+const SHL_ASSIGN = 57372
+const SHR_ASSIGN = 57373
+const AND_NOT_ASSIGN = 57374
+const LAND = 57375
+const LOR = 57376
+const ARROW = 57377
+const INC = 57378
+const DEC = 57379
+const EQL = 57380
+const NEQ = 57381
+const LEQ = 57382
+const GEQ = 57383
+const DEFINE = 57384
+const ELLIPSIS = 57385
+const ADD = 57386
+const SUB = 57387
+const MUL = 57388
+const QUO = 57389
+const REM = 57390
+const POW = 57391
+const AND = 57392
+const OR = 57393
+const XOR = 57394
+const LSS = 57395
+const GTR = 57396
+const ASSIGN = 57397
+const NOT = 57398
+const QUESTION = 57399
+const QUES_QUES = 57400
+const QUES_PERIOD = 57401
+const DOTDOT = 57402
+const LPAREN = 57403
+const LBRACK = 57404
+const LBRACE = 57405
+const COMMA = 57406
+const PERIOD = 57407
+const RPAREN = 57408
+const RBRACK = 57409
+const RBRACE = 57410
+const SEMICOLON = 57411
+const COLON = 57412
+const MATCH_OP = 57413
+const BREAK = 57414
+const CASE = 57415
+const CHAN = 57416
+const CONTINUE = 57417
+const CONST = 57418
+const DEFAULT = 57419
+const DEFER = 57420
+const ELSE = 57421
+const FALLTHROUGH = 57422
+const FOR = 57423
+const FUNC = 57424
+const GO = 57425
+const GOTO = 57426
+const IF = 57427
+const IMPORT = 57428
+const IN = 57429
+const INTERFACE = 57430
+const MAP = 57431
+const PACKAGE = 57432
+const RANGE = 57433
+const RETURN = 57434
+const SELECT = 57435
+const STRUCT = 57436
+const SWITCH = 57437
+const TYPE = 57438
+const VAR = 57439
+const YIELD = 57440
+const MATCH = 57441
+const IF_EXPR = 57442
+const LIT_LBRACE = 57443
+const UMINUS = 57444
+
+var DobyToknames = [...]string{
+	"$end",
+	"error",
+	"$unk",
 	"EOF",
 	"EOL",
 	"COMMENT",
 	"IDENT",
 	"INT",
 	"FLOAT",
+	"IMAG",
 	"STRING",
 	"CHAR",
+	"SYMBOL",
+	"REGEXP",
+	"STRING_HEAD",
+	"STRING_MID",
+	"STRING_TAIL",
 	"SHL",
 	"SHR",
 	"AND_NOT",
@@ -137,6 +167,7 @@ var DobyToknames = []string{
 	"MUL_ASSIGN",
 	"QUO_ASSIGN",
 	"REM_ASSIGN",
+	"POW_ASSIGN",
 	"AND_ASSIGN",
 	"OR_ASSIGN",
 	"XOR_ASSIGN",
@@ -159,6 +190,7 @@ var DobyToknames = []string{
 	"MUL",
 	"QUO",
 	"REM",
+	"POW",
 	"AND",
 	"OR",
 	"XOR",
@@ -166,6 +198,10 @@ var DobyToknames = []string{
 	"GTR",
 	"ASSIGN",
 	"NOT",
+	"QUESTION",
+	"QUES_QUES",
+	"QUES_PERIOD",
+	"DOTDOT",
 	"LPAREN",
 	"LBRACK",
 	"LBRACE",
@@ -176,6 +212,7 @@ var DobyToknames = []string{
 	"RBRACE",
 	"SEMICOLON",
 	"COLON",
+	"MATCH_OP",
 	"BREAK",
 	"CASE",
 	"CHAN",
@@ -191,6 +228,7 @@ var DobyToknames = []string{
 	"GOTO",
 	"IF",
 	"IMPORT",
+	"IN",
 	"INTERFACE",
 	"MAP",
 	"PACKAGE",
@@ -201,332 +239,454 @@ var DobyToknames = []string{
 	"SWITCH",
 	"TYPE",
 	"VAR",
+	"YIELD",
+	"MATCH",
+	"IF_EXPR",
+	"LIT_LBRACE",
 	"UMINUS",
+	"'#'",
 }
-var DobyStatenames = []string{}
+
+var DobyStatenames = [...]string{}
 
 const DobyEofCode = 1
 const DobyErrCode = 2
-const DobyMaxDepth = 200
+const DobyInitialStackSize = 16
 
 //line yacctab:1
-var DobyExca = []int{
+var DobyExca = [...]int16{
 	-1, 0,
-	5, 122,
-	57, 122,
-	-2, 12,
+	5, 173,
+	69, 173,
+	-2, 20,
 	-1, 1,
 	1, -1,
 	-2, 0,
-	-1, 18,
-	5, 72,
-	51, 72,
-	56, 72,
-	57, 72,
-	60, 72,
-	64, 72,
-	-2, 13,
-	-1, 24,
-	5, 122,
-	56, 122,
-	57, 122,
-	-2, 12,
-	-1, 54,
-	1, 127,
-	5, 126,
-	57, 126,
-	-2, 12,
-	-1, 94,
-	5, 88,
-	51, 88,
-	56, 88,
-	57, 88,
-	60, 88,
-	64, 88,
-	-2, 65,
-	-1, 105,
-	57, 72,
-	-2, 13,
-	-1, 158,
-	5, 126,
-	56, 126,
-	57, 126,
-	60, 126,
-	64, 126,
-	-2, 12,
-	-1, 190,
-	5, 122,
-	56, 122,
-	57, 122,
-	60, 122,
-	64, 122,
-	-2, 12,
-	-1, 211,
-	5, 122,
-	56, 122,
-	57, 122,
-	60, 122,
-	64, 122,
-	-2, 12,
+	-1, 19,
+	5, 119,
+	63, 119,
+	68, 119,
+	69, 119,
+	73, 119,
+	77, 119,
+	-2, 21,
+	-1, 26,
+	5, 173,
+	68, 173,
+	69, 173,
+	-2, 20,
+	-1, 75,
+	1, 178,
+	5, 177,
+	69, 177,
+	-2, 20,
+	-1, 123,
+	5, 136,
+	63, 136,
+	68, 136,
+	69, 136,
+	73, 136,
+	77, 136,
+	-2, 102,
+	-1, 135,
+	69, 119,
+	-2, 21,
+	-1, 207,
+	5, 177,
+	68, 177,
+	69, 177,
+	73, 177,
+	77, 177,
+	-2, 20,
+	-1, 258,
+	5, 173,
+	68, 173,
+	69, 173,
+	73, 173,
+	77, 173,
+	-2, 20,
+	-1, 291,
+	5, 173,
+	68, 173,
+	69, 173,
+	73, 173,
+	77, 173,
+	-2, 20,
+	-1, 326,
+	5, 173,
+	68, 173,
+	69, 173,
+	73, 173,
+	77, 173,
+	-2, 20,
 }
 
-const DobyNprod = 128
 const DobyPrivate = 57344
 
-var DobyTokenNames []string
-var DobyStates []string
-
-const DobyLast = 1131
-
-var DobyAct = []int{
-
-	97, 18, 11, 178, 176, 101, 188, 2, 163, 186,
-	164, 81, 190, 166, 165, 81, 81, 211, 196, 171,
-	202, 95, 239, 225, 30, 18, 99, 18, 158, 105,
-	232, 54, 98, 202, 216, 226, 202, 81, 203, 58,
-	57, 113, 114, 56, 162, 81, 24, 184, 102, 108,
-	109, 110, 237, 198, 180, 18, 18, 3, 120, 158,
-	123, 124, 125, 126, 127, 128, 129, 130, 131, 132,
-	133, 134, 135, 136, 137, 138, 139, 140, 141, 142,
-	55, 119, 143, 55, 100, 221, 103, 43, 44, 45,
-	46, 47, 217, 43, 44, 45, 46, 47, 116, 164,
-	199, 206, 159, 165, 200, 167, 160, 107, 168, 234,
-	157, 55, 117, 118, 194, 177, 36, 223, 49, 204,
-	1, 179, 183, 43, 49, 161, 17, 16, 50, 48,
-	51, 15, 14, 13, 50, 48, 51, 94, 12, 81,
-	115, 208, 218, 10, 9, 185, 61, 62, 63, 53,
-	59, 60, 61, 62, 63, 53, 58, 57, 8, 18,
-	56, 81, 58, 57, 195, 52, 56, 191, 19, 7,
-	187, 52, 6, 59, 60, 61, 62, 63, 64, 65,
-	66, 5, 207, 4, 205, 58, 57, 18, 175, 56,
-	96, 18, 41, 18, 215, 40, 39, 106, 212, 177,
-	177, 38, 222, 219, 220, 37, 224, 42, 35, 34,
-	33, 32, 18, 31, 18, 0, 117, 230, 231, 228,
-	111, 0, 177, 0, 0, 0, 233, 122, 0, 235,
-	0, 0, 236, 43, 44, 45, 46, 47, 0, 0,
-	0, 238, 240, 0, 210, 0, 0, 0, 0, 0,
-	214, 145, 146, 147, 148, 149, 150, 151, 152, 153,
-	154, 155, 156, 0, 49, 0, 0, 0, 0, 0,
-	0, 229, 0, 0, 50, 48, 51, 24, 0, 0,
-	0, 172, 173, 104, 0, 22, 0, 0, 23, 43,
-	44, 45, 46, 47, 28, 53, 20, 0, 25, 29,
-	0, 0, 0, 0, 21, 27, 0, 26, 0, 0,
-	0, 52, 0, 43, 44, 45, 46, 47, 0, 174,
-	49, 43, 44, 45, 46, 47, 0, 0, 0, 0,
-	50, 48, 51, 189, 0, 0, 0, 0, 145, 0,
-	0, 0, 0, 197, 49, 0, 0, 0, 0, 0,
-	0, 53, 49, 0, 50, 48, 51, 24, 0, 193,
-	0, 0, 50, 48, 51, 22, 0, 52, 23, 43,
-	44, 45, 46, 47, 28, 53, 20, 0, 25, 29,
-	0, 0, 0, 53, 21, 27, 0, 26, 0, 0,
-	144, 52, 43, 44, 45, 46, 47, 0, 0, 52,
-	49, 43, 44, 45, 46, 47, 0, 0, 0, 0,
-	50, 48, 51, 112, 0, 43, 44, 45, 46, 47,
-	121, 0, 0, 49, 0, 0, 0, 0, 0, 0,
-	0, 53, 49, 50, 48, 51, 0, 0, 0, 0,
-	0, 0, 50, 48, 51, 0, 49, 52, 59, 60,
-	61, 62, 63, 64, 53, 66, 50, 48, 51, 0,
-	58, 57, 0, 53, 56, 0, 0, 0, 0, 0,
-	52, 0, 0, 0, 0, 0, 0, 53, 0, 52,
-	67, 68, 69, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 52, 76, 77, 0, 0, 0, 75,
-	72, 73, 74, 0, 0, 59, 60, 61, 62, 63,
-	64, 65, 66, 70, 71, 0, 0, 58, 57, 0,
-	0, 56, 0, 182, 0, 0, 181, 67, 68, 69,
+const DobyLast = 1890
+
+var DobyAct = [...]int16{
+	126, 19, 51, 235, 237, 2, 42, 12, 267, 223,
+	159, 212, 224, 131, 109, 225, 268, 280, 254, 109,
+	185, 232, 124, 26, 127, 291, 86, 19, 129, 19,
+	305, 135, 128, 265, 258, 243, 78, 298, 80, 79,
+	292, 215, 77, 225, 256, 300, 331, 225, 310, 213,
+	3, 311, 249, 214, 236, 109, 250, 211, 269, 275,
+	109, 336, 32, 221, 207, 321, 140, 141, 142, 145,
+	146, 147, 240, 151, 297, 154, 19, 19, 102, 130,
+	160, 133, 162, 163, 164, 165, 166, 167, 168, 169,
+	170, 171, 172, 173, 174, 175, 176, 177, 178, 179,
+	180, 181, 182, 186, 187, 188, 189, 190, 322, 240,
+	191, 308, 275, 241, 309, 81, 82, 83, 84, 85,
+	86, 87, 88, 89, 183, 213, 155, 156, 76, 214,
+	78, 104, 80, 79, 109, 216, 77, 208, 207, 219,
+	157, 158, 75, 217, 209, 301, 26, 90, 91, 92,
+	241, 234, 278, 132, 226, 239, 330, 242, 246, 238,
+	246, 227, 100, 101, 106, 107, 108, 98, 95, 96,
+	97, 233, 102, 81, 82, 83, 84, 85, 86, 87,
+	88, 89, 93, 94, 153, 137, 103, 105, 78, 104,
+	80, 79, 26, 253, 77, 323, 83, 84, 85, 86,
+	99, 206, 76, 275, 109, 276, 76, 302, 19, 78,
+	262, 80, 79, 39, 303, 77, 259, 247, 109, 247,
+	271, 273, 255, 248, 109, 277, 245, 149, 148, 150,
+	102, 138, 139, 56, 1, 123, 264, 210, 152, 18,
+	20, 17, 16, 274, 282, 239, 279, 234, 234, 238,
+	286, 102, 288, 289, 281, 19, 15, 14, 155, 19,
+	13, 19, 296, 125, 293, 11, 10, 283, 284, 109,
+	9, 136, 263, 109, 8, 304, 299, 7, 239, 234,
+	306, 6, 238, 5, 307, 4, 234, 264, 313, 47,
+	48, 46, 19, 315, 45, 19, 44, 314, 49, 319,
+	43, 320, 184, 317, 318, 290, 312, 64, 52, 143,
+	50, 295, 282, 34, 54, 53, 41, 324, 40, 55,
+	38, 161, 325, 37, 327, 36, 35, 19, 329, 333,
+	33, 0, 332, 239, 335, 0, 0, 238, 334, 0,
+	0, 0, 0, 0, 337, 316, 0, 0, 0, 0,
+	0, 193, 194, 195, 196, 197, 198, 199, 200, 201,
+	202, 203, 204, 205, 56, 57, 58, 59, 60, 61,
+	62, 63, 74, 0, 0, 0, 81, 82, 83, 84,
+	85, 86, 87, 88, 89, 222, 0, 0, 0, 228,
+	230, 78, 0, 80, 79, 0, 0, 77, 0, 0,
+	72, 0, 66, 0, 0, 0, 81, 82, 83, 84,
+	85, 86, 87, 67, 89, 0, 0, 0, 65, 68,
+	26, 78, 0, 80, 79, 0, 134, 77, 0, 24,
+	0, 0, 25, 102, 0, 0, 0, 0, 30, 73,
+	21, 0, 27, 31, 0, 0, 0, 0, 0, 22,
+	29, 0, 28, 0, 257, 23, 70, 69, 0, 193,
+	71, 0, 0, 102, 0, 0, 266, 90, 91, 92,
+	270, 0, 272, 0, 0, 0, 0, 0, 0, 0,
+	0, 143, 100, 101, 106, 107, 108, 98, 95, 96,
+	97, 0, 0, 81, 82, 83, 84, 85, 86, 87,
+	88, 89, 93, 94, 0, 0, 103, 105, 78, 104,
+	80, 79, 0, 0, 77, 0, 0, 0, 0, 0,
+	99, 56, 57, 58, 59, 60, 61, 62, 63, 74,
+	0, 98, 95, 96, 97, 0, 0, 81, 82, 83,
+	84, 85, 86, 87, 88, 89, 93, 94, 0, 0,
+	102, 0, 78, 104, 80, 79, 0, 72, 77, 66,
+	0, 0, 0, 0, 99, 0, 0, 0, 0, 0,
+	67, 0, 0, 0, 0, 65, 68, 26, 0, 0,
+	0, 0, 0, 0, 0, 0, 24, 0, 0, 25,
+	0, 0, 0, 0, 102, 30, 73, 21, 0, 27,
+	31, 0, 0, 90, 91, 92, 22, 29, 0, 28,
+	0, 0, 23, 70, 69, 0, 0, 71, 100, 101,
+	0, 0, 0, 98, 95, 96, 97, 0, 0, 81,
+	82, 83, 84, 85, 86, 87, 88, 89, 93, 94,
+	0, 0, 103, 105, 78, 104, 80, 79, 26, 0,
+	77, 0, 0, 0, 0, 0, 99, 0, 0, 0,
+	0, 90, 91, 92, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 100, 101, 0, 0,
+	0, 98, 95, 96, 97, 0, 102, 81, 82, 83,
+	84, 85, 86, 87, 88, 89, 93, 94, 0, 0,
+	103, 105, 78, 104, 80, 79, 0, 0, 77, 0,
+	0, 0, 0, 0, 99, 0, 0, 0, 0, 90,
+	91, 92, 0, 0, 0, 0, 0, 0, 328, 0,
+	0, 0, 0, 0, 100, 101, 0, 0, 0, 98,
+	95, 96, 97, 0, 102, 81, 82, 83, 84, 85,
+	86, 87, 88, 89, 93, 94, 0, 0, 103, 105,
+	78, 104, 80, 79, 0, 0, 77, 0, 0, 0,
+	0, 326, 99, 0, 0, 0, 0, 90, 91, 92,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 76, 77, 0, 0, 0, 75, 72, 73, 74,
-	0, 0, 59, 60, 61, 62, 63, 64, 65, 66,
-	70, 71, 0, 0, 58, 57, 0, 0, 56, 67,
-	68, 69, 0, 201, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 76, 77, 0, 0, 0, 75, 72,
-	73, 74, 0, 0, 59, 60, 61, 62, 63, 64,
-	65, 66, 70, 71, 0, 0, 58, 57, 0, 0,
-	56, 67, 68, 69, 213, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 76, 77, 0, 0, 0,
-	75, 72, 73, 74, 0, 0, 59, 60, 61, 62,
-	63, 64, 65, 66, 70, 71, 0, 0, 58, 57,
-	0, 0, 56, 67, 68, 69, 192, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 76, 77, 78,
-	79, 80, 75, 72, 73, 74, 0, 0, 59, 60,
-	61, 62, 63, 64, 65, 66, 70, 71, 0, 0,
-	58, 57, 24, 0, 56, 67, 68, 69, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 0, 0, 76,
-	77, 0, 0, 0, 75, 72, 73, 74, 0, 0,
-	59, 60, 61, 62, 63, 64, 65, 66, 70, 71,
-	0, 0, 58, 57, 0, 0, 56, 0, 227, 67,
-	68, 69, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 76, 77, 0, 0, 0, 75, 72,
-	73, 74, 0, 0, 59, 60, 61, 62, 63, 64,
-	65, 66, 70, 71, 0, 0, 58, 57, 0, 0,
-	56, 0, 209, 67, 68, 69, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 76, 77, 78,
-	79, 80, 75, 72, 73, 74, 0, 0, 59, 60,
-	61, 62, 63, 64, 65, 66, 70, 71, 0, 0,
-	58, 57, 0, 0, 56, 67, 68, 69, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 0, 0, 76,
-	77, 0, 0, 0, 75, 72, 73, 74, 0, 0,
-	59, 60, 61, 62, 63, 64, 65, 66, 70, 71,
-	0, 0, 58, 57, 0, 0, 56, 170, 67, 68,
-	69, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 76, 77, 0, 0, 0, 75, 72, 73,
-	74, 0, 0, 59, 60, 61, 62, 63, 64, 65,
-	66, 70, 71, 0, 0, 58, 57, 24, 0, 56,
-	67, 68, 69, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 76, 77, 0, 0, 0, 75,
-	72, 73, 74, 0, 0, 59, 60, 61, 62, 63,
-	64, 65, 66, 70, 71, 0, 0, 58, 57, 0,
-	0, 56, 67, 68, 69, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 76, 0, 0, 0,
-	0, 75, 72, 73, 74, 0, 0, 59, 60, 61,
-	62, 63, 64, 65, 66, 70, 71, 0, 0, 58,
-	57, 0, 0, 56, 67, 68, 69, 0, 0, 0,
+	0, 0, 100, 101, 0, 0, 0, 98, 95, 96,
+	97, 0, 102, 81, 82, 83, 84, 85, 86, 87,
+	88, 89, 93, 94, 0, 0, 103, 105, 78, 104,
+	80, 79, 0, 0, 77, 0, 0, 0, 294, 0,
+	99, 0, 0, 0, 0, 90, 91, 92, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 75, 72, 73, 74, 0, 0, 59,
-	60, 61, 62, 63, 64, 65, 66, 70, 71, 0,
-	0, 58, 57, 0, 0, 56, 75, 72, 73, 74,
-	0, 0, 59, 60, 61, 62, 63, 64, 65, 66,
-	70, 71, 0, 0, 58, 57, 0, 0, 56, 75,
-	72, 73, 74, 0, 0, 59, 60, 61, 62, 63,
-	64, 65, 66, 0, 0, 0, 0, 58, 57, 0,
-	0, 56, 83, 84, 85, 86, 87, 88, 89, 90,
-	91, 92, 93, 83, 84, 85, 86, 87, 88, 89,
-	90, 91, 92, 93, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 169, 0, 0, 0, 0, 81,
-	0, 0, 0, 0, 0, 82, 0, 0, 0, 0,
-	81,
+	100, 101, 0, 0, 0, 98, 95, 96, 97, 0,
+	102, 81, 82, 83, 84, 85, 86, 87, 88, 89,
+	93, 94, 0, 0, 103, 105, 78, 104, 80, 79,
+	0, 0, 77, 0, 0, 0, 0, 251, 99, 0,
+	0, 0, 0, 90, 91, 92, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 100, 101,
+	0, 0, 0, 98, 95, 96, 97, 0, 102, 81,
+	82, 83, 84, 85, 86, 87, 88, 89, 93, 94,
+	0, 0, 103, 105, 78, 104, 80, 79, 0, 0,
+	77, 0, 0, 0, 260, 0, 99, 0, 0, 0,
+	0, 90, 91, 92, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 100, 101, 0, 0,
+	0, 98, 95, 96, 97, 0, 102, 81, 82, 83,
+	84, 85, 86, 87, 88, 89, 93, 94, 0, 0,
+	103, 105, 78, 104, 80, 79, 0, 0, 77, 0,
+	0, 0, 0, 252, 99, 0, 0, 0, 0, 90,
+	91, 92, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 100, 101, 0, 0, 0, 98,
+	95, 96, 97, 0, 102, 81, 82, 83, 84, 85,
+	86, 87, 88, 89, 93, 94, 0, 0, 103, 105,
+	78, 104, 80, 79, 0, 0, 77, 0, 244, 0,
+	0, 0, 99, 0, 0, 0, 0, 90, 91, 92,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 100, 101, 0, 0, 0, 98, 95, 96,
+	97, 0, 102, 81, 82, 83, 84, 85, 86, 87,
+	88, 89, 93, 94, 0, 0, 103, 105, 78, 104,
+	80, 79, 132, 0, 77, 0, 0, 0, 0, 0,
+	99, 0, 0, 0, 0, 90, 91, 92, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	100, 101, 0, 0, 0, 98, 95, 96, 97, 0,
+	102, 81, 82, 83, 84, 85, 86, 87, 88, 89,
+	93, 94, 0, 0, 103, 105, 78, 104, 80, 79,
+	0, 0, 77, 0, 0, 0, 0, 0, 99, 0,
+	0, 0, 0, 90, 91, 92, 0, 0, 225, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 100, 101,
+	0, 0, 0, 98, 95, 96, 97, 0, 102, 81,
+	82, 83, 84, 85, 86, 87, 88, 89, 93, 94,
+	0, 0, 103, 105, 78, 104, 80, 79, 0, 0,
+	77, 220, 0, 0, 0, 0, 99, 0, 0, 0,
+	0, 90, 91, 92, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 100, 101, 0, 0,
+	0, 98, 95, 96, 97, 0, 102, 81, 82, 83,
+	84, 85, 86, 87, 88, 89, 93, 94, 0, 0,
+	103, 105, 78, 104, 80, 79, 0, 0, 77, 0,
+	0, 0, 0, 0, 99, 0, 0, 0, 0, 90,
+	91, 92, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 100, 101, 0, 0, 0, 98,
+	95, 96, 97, 0, 102, 81, 82, 83, 84, 85,
+	86, 87, 88, 89, 93, 94, 90, 91, 92, 0,
+	78, 104, 80, 79, 0, 0, 77, 0, 0, 0,
+	0, 100, 99, 0, 0, 0, 98, 95, 96, 97,
+	0, 0, 81, 82, 83, 84, 85, 86, 87, 88,
+	89, 93, 94, 90, 91, 92, 0, 78, 104, 80,
+	79, 0, 102, 77, 0, 0, 0, 0, 0, 99,
+	0, 0, 0, 98, 95, 96, 97, 0, 0, 81,
+	82, 83, 84, 85, 86, 87, 88, 89, 93, 94,
+	0, 0, 0, 0, 78, 104, 80, 79, 0, 102,
+	77, 0, 0, 0, 0, 287, 99, 56, 57, 58,
+	59, 60, 61, 62, 63, 74, 0, 285, 0, 56,
+	57, 58, 59, 60, 61, 62, 63, 74, 0, 0,
+	0, 0, 0, 0, 0, 0, 102, 0, 0, 0,
+	0, 0, 0, 72, 0, 66, 0, 0, 81, 82,
+	83, 84, 85, 86, 0, 72, 67, 66, 0, 0,
+	0, 65, 68, 78, 0, 80, 79, 0, 67, 77,
+	0, 0, 0, 65, 68, 0, 0, 0, 0, 0,
+	0, 144, 73, 56, 57, 58, 59, 60, 61, 62,
+	63, 74, 0, 0, 73, 0, 0, 0, 0, 70,
+	69, 0, 0, 71, 0, 102, 0, 0, 0, 0,
+	0, 70, 69, 0, 0, 71, 0, 0, 0, 72,
+	0, 66, 0, 56, 57, 58, 59, 60, 61, 62,
+	63, 74, 67, 0, 0, 0, 0, 65, 68, 0,
+	0, 0, 0, 231, 0, 56, 57, 58, 59, 60,
+	61, 62, 63, 74, 0, 0, 0, 0, 73, 72,
+	0, 66, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 67, 0, 0, 70, 69, 65, 68, 71,
+	0, 72, 0, 66, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 67, 0, 0, 0, 73, 65,
+	68, 0, 0, 0, 0, 0, 0, 261, 0, 0,
+	0, 0, 0, 0, 0, 70, 69, 0, 0, 71,
+	73, 229, 0, 56, 57, 58, 59, 60, 61, 62,
+	63, 74, 0, 0, 0, 0, 0, 70, 69, 0,
+	0, 71, 0, 192, 0, 56, 57, 58, 59, 60,
+	61, 62, 63, 74, 0, 0, 0, 0, 0, 72,
+	0, 66, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 67, 0, 0, 0, 0, 65, 68, 0,
+	0, 72, 0, 66, 0, 56, 57, 58, 59, 60,
+	61, 62, 63, 74, 67, 0, 0, 0, 73, 65,
+	68, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 70, 69, 0, 0, 71,
+	73, 72, 0, 66, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 67, 0, 0, 70, 69, 65,
+	68, 71, 98, 95, 96, 97, 0, 0, 81, 82,
+	83, 84, 85, 86, 87, 88, 89, 0, 0, 0,
+	73, 0, 0, 78, 104, 80, 79, 0, 0, 77,
+	0, 0, 0, 0, 0, 99, 0, 70, 69, 0,
+	0, 71, 111, 112, 113, 114, 115, 116, 117, 118,
+	119, 120, 121, 122, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 102, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 218, 0, 0, 0,
+	0, 0, 0, 0, 0, 109, 111, 112, 113, 114,
+	115, 116, 117, 118, 119, 120, 121, 122, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	110, 0, 0, 0, 0, 0, 0, 0, 0, 109,
 }
-var DobyPact = []int{
 
-	306, -1000, 26, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, 771, 1078,
-	394, 394, -1000, -1000, 306, 394, 306, -3, 226, 97,
+var DobyPact = [...]int16{
+	514, -1000, 137, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, 449,
+	1825, 1698, 1698, 1698, -1000, -1000, 514, 1698, 514, 90,
+	357, 174, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
 	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, 394, 394,
-	394, 408, -9, 91, 306, 306, 116, 362, 394, 394,
-	394, 394, 394, 394, 394, 394, 394, 394, 394, 394,
-	394, 394, 394, 394, 394, 394, 394, 394, 394, -1000,
-	-1000, 385, 394, 394, 394, 394, 394, 394, 394, 394,
-	394, 394, 394, 394, -1000, 898, -15, 898, 54, 856,
-	-3, -1000, 39, -44, 394, 641, 1067, -1000, 813, -10,
-	982, -36, 394, 314, 394, 114, 5, -1000, -1000, -1000,
-	468, 394, -7, 107, 107, -10, -10, -10, 113, 411,
-	113, 1005, 1005, 1005, 1028, 1028, 136, 136, 136, 136,
-	982, 940, 898, 898, 394, -15, -15, -15, -15, -15,
-	-15, -15, -15, -15, -15, -15, -15, -1000, 306, -57,
-	-1000, -50, -1000, -1000, 394, -46, 394, 599, -1000, 282,
-	-1000, -1000, 109, -37, 394, 48, -1000, 515, -16, 112,
-	94, 86, -1000, 727, -1000, 898, 306, -1000, -1000, -41,
-	306, 557, 306, 394, -21, -1000, -1000, 87, 394, 80,
-	-1000, 394, 110, -5, -31, -19, -1000, 683, -1000, -1000,
-	-1000, 306, 23, 306, -5, 856, -1000, -25, -1000, -1000,
-	-1000, 394, 898, -1000, -1000, 102, -5, -1000, 23, -5,
-	-1000, -1000, -1000, -1000, 3, -1000, -1000, 94, -32, -5,
-	-1000,
+	-1000, -1000, -1000, -1000, 215, 1698, 1698, 1698, 1496, 1698,
+	1698, 166, 1698, 177, 1698, 514, 514, 226, 226, 1698,
+	1698, 1698, 1698, 1698, 1698, 1698, 1698, 1698, 1698, 1698,
+	1698, 1698, 1698, 1698, 1698, 1698, 1698, 1698, 1698, 1698,
+	1698, 1698, 1698, 1698, 1698, 1698, 1698, -1000, -1000, 1658,
+	1698, 1698, 1698, 1698, 1698, 1698, 1698, 1698, 1698, 1698,
+	1698, 1698, 1698, -1000, 1223, 70, 1223, 1223, 133, 585,
+	90, -1000, 52, -28, 1698, 129, 1781, -1000, 1698, -1000,
+	1165, -23, 1355, -4, 1698, 1107, 585, 1049, 1636, 1558,
+	1698, -23, 47, 96, 1223, -1000, -1000, -1000, -1000, -35,
+	991, 160, 150, 150, -23, -23, -23, -23, 1424, 362,
+	1424, 493, 493, 493, 1724, 1724, 71, 71, 71, 71,
+	71, 1355, 1318, 155, -12, -1000, 817, 933, 332, 1281,
+	1223, 1223, 1698, 70, 70, 70, 70, 70, 70, 70,
+	70, 70, 70, 70, 70, 70, -1000, 514, -61, -1000,
+	-24, -1000, -1000, 1698, -36, 1698, 875, -1000, 1536, 1223,
+	-1000, -1000, 205, -34, -1000, 1698, -63, -1000, -9, 1698,
+	154, 1698, 153, -66, 817, 139, 218, -1000, -1000, -1000,
+	1496, 89, 10, 1698, -1000, -1000, 1698, 1432, -1000, 1420,
+	-1000, 1698, 1698, 1223, 514, -1000, -1000, -45, 514, 759,
+	514, 1698, 7, -1000, -1000, -1000, -50, -1000, -40, -1000,
+	140, -1000, 209, -1000, -38, 10, 83, 45, 1698, 48,
+	-1000, -19, 1223, -1000, -1000, 1698, 1223, 1698, 1223, 1223,
+	-1000, 514, 1698, 59, 514, 83, 585, -1000, 1698, -1000,
+	1698, -2, -1000, 42, -1000, -1000, -1000, -1000, 188, 83,
+	-1000, 1698, -1000, 1223, 59, 701, 83, -1000, -1000, 643,
+	585, -1000, -1000, 95, -1000, -21, 514, -1000, 1698, -63,
+	10, -1000, 59, 1223, -1000, -5, 83, -1000,
 }
-var DobyPgo = []int{
 
-	0, 0, 24, 213, 211, 210, 209, 208, 207, 116,
-	205, 201, 196, 195, 192, 168, 4, 188, 3, 57,
-	183, 181, 172, 169, 158, 144, 143, 2, 138, 8,
-	5, 133, 132, 131, 127, 126, 7, 125, 120,
+var DobyPgo = [...]int16{
+	0, 0, 62, 330, 326, 325, 323, 320, 10, 319,
+	213, 318, 316, 6, 2, 315, 314, 313, 310, 4,
+	308, 240, 307, 3, 302, 20, 21, 300, 298, 296,
+	294, 291, 290, 289, 8, 12, 9, 50, 285, 283,
+	281, 277, 274, 270, 266, 265, 7, 260, 11, 13,
+	257, 256, 242, 241, 239, 5, 237, 234,
 }
-var DobyR1 = []int{
-
-	0, 2, 3, 3, 3, 3, 4, 5, 7, 7,
-	7, 6, 15, 15, 15, 15, 9, 10, 10, 11,
-	11, 11, 11, 11, 11, 11, 11, 11, 11, 11,
-	11, 11, 11, 11, 11, 11, 11, 11, 12, 12,
-	12, 14, 14, 14, 16, 17, 17, 17, 17, 17,
-	17, 17, 13, 18, 18, 18, 8, 8, 8, 1,
+
+var DobyR1 = [...]int8{
+	0, 2, 3, 3, 3, 3, 3, 3, 3, 22,
+	22, 17, 4, 5, 5, 8, 8, 7, 7, 6,
+	21, 21, 21, 21, 10, 11, 11, 12, 12, 12,
+	12, 12, 12, 12, 12, 12, 12, 12, 12, 12,
+	12, 12, 12, 12, 12, 12, 12, 12, 13, 13,
+	13, 15, 15, 15, 16, 16, 16, 25, 26, 26,
+	26, 26, 26, 26, 26, 14, 24, 24, 24, 20,
+	20, 35, 35, 36, 36, 27, 28, 34, 34, 34,
+	29, 30, 33, 32, 31, 18, 19, 19, 19, 23,
+	23, 23, 9, 9, 9, 1, 1, 1, 1, 1,
 	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
-	1, 1, 20, 21, 22, 22, 23, 23, 23, 23,
-	23, 23, 23, 23, 23, 23, 23, 23, 24, 25,
-	26, 26, 27, 28, 28, 29, 29, 37, 37, 37,
-	30, 31, 32, 33, 33, 33, 34, 35, 19, 19,
-	19, 19, 19, 19, 19, 19, 19, 19, 19, 19,
-	19, 19, 36, 36, 36, 36, 36, 38,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 38,
+	39, 40, 40, 41, 41, 41, 41, 41, 41, 41,
+	41, 41, 41, 41, 41, 41, 42, 43, 44, 45,
+	45, 46, 47, 47, 48, 48, 48, 56, 56, 56,
+	49, 50, 51, 52, 52, 52, 53, 54, 37, 37,
+	37, 37, 37, 37, 37, 37, 37, 37, 37, 37,
+	37, 37, 37, 55, 55, 55, 55, 55, 57,
 }
-var DobyR2 = []int{
 
-	0, 1, 1, 1, 1, 1, 3, 3, 6, 5,
-	5, 4, 0, 1, 3, 4, 4, 2, 2, 3,
+var DobyR2 = [...]int8{
+	0, 1, 1, 1, 1, 1, 1, 1, 1, 2,
+	3, 2, 3, 3, 3, 0, 1, 6, 8, 4,
+	0, 1, 3, 4, 4, 2, 2, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 5,
-	4, 4, 6, 5, 3, 0, 1, 3, 3, 4,
-	2, 3, 4, 0, 1, 3, 5, 6, 10, 1,
+	4, 4, 6, 5, 4, 6, 5, 3, 0, 1,
+	3, 3, 4, 2, 3, 4, 1, 3, 4, 4,
+	4, 4, 6, 1, 2, 4, 5, 0, 2, 5,
+	4, 5, 3, 3, 3, 2, 1, 1, 1, 0,
+	1, 3, 5, 6, 10, 1, 1, 1, 1, 1,
 	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
-	1, 1, 1, 3, 2, 2, 3, 3, 3, 3,
-	3, 3, 3, 3, 3, 3, 3, 3, 2, 2,
-	1, 1, 3, 3, 5, 4, 3, 1, 1, 2,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+	3, 2, 2, 3, 3, 3, 3, 3, 3, 3,
+	3, 3, 3, 3, 3, 3, 2, 2, 2, 1,
+	1, 3, 3, 5, 4, 6, 3, 1, 1, 2,
 	3, 3, 2, 7, 6, 3, 6, 2, 1, 1,
 	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
-	1, 1, 0, 1, 3, 3, 2, 2,
+	1, 1, 1, 0, 1, 3, 3, 2, 2,
 }
-var DobyChk = []int{
-
-	-1000, -38, -36, -19, -20, -21, -22, -23, -24, -25,
-	-26, -27, -28, -31, -32, -33, -34, -35, -1, -15,
-	70, 78, 59, 62, 51, 72, 81, 79, 68, 73,
-	-2, -3, -4, -5, -6, -7, -9, -10, -11, -12,
-	-13, -14, -8, 7, 8, 9, 10, 11, 49, 38,
-	48, 50, 85, 69, 5, 57, 53, 50, 49, 37,
-	38, 39, 40, 41, 42, 43, 44, 12, 13, 14,
-	45, 46, 32, 33, 34, 31, 26, 27, 28, 29,
-	30, 52, 47, 15, 16, 17, 18, 19, 20, 21,
-	22, 23, 24, 25, -9, -1, -15, -1, -36, -1,
-	-19, -30, 51, -19, 57, -1, -15, 10, -1, -1,
-	-1, -15, 5, 50, 51, 49, 7, -19, -19, -2,
-	-1, 58, -15, -1, -1, -1, -1, -1, -1, -1,
+
+var DobyChk = [...]int16{
+	-1000, -57, -55, -37, -38, -39, -40, -41, -42, -43,
+	-44, -45, -46, -47, -50, -51, -52, -53, -54, -1,
+	-21, 83, 92, 98, 72, 75, 63, 85, 95, 93,
+	81, 86, -2, -3, -17, -4, -5, -6, -7, -10,
+	-11, -12, -13, -27, -29, -30, -31, -33, -32, -28,
+	-18, -14, -20, -15, -16, -9, 7, 8, 9, 10,
+	11, 12, 13, 14, -22, 61, 45, 56, 62, 100,
+	99, 103, 43, 82, 15, 5, 69, 65, 59, 62,
+	61, 44, 45, 46, 47, 48, 49, 50, 51, 52,
+	18, 19, 20, 53, 54, 39, 40, 41, 38, 71,
+	33, 34, 101, 57, 60, 58, 35, 36, 37, 64,
+	55, 21, 22, 23, 24, 25, 26, 27, 28, 29,
+	30, 31, 32, -10, -1, -21, -1, -1, -55, -1,
+	-37, -49, 63, -37, 69, -1, -21, 11, 16, 17,
+	-1, -1, -1, -21, 5, -1, -1, -1, 62, 61,
+	63, -1, 61, 7, -1, -37, -37, -2, -2, -8,
+	-1, -21, -1, -1, -1, -1, -1, -1, -1, -1,
 	-1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
-	-1, -1, -1, -1, 5, -15, -15, -15, -15, -15,
-	-15, -15, -15, -15, -15, -15, -15, 56, 5, -27,
-	-30, -37, 5, -29, 60, 64, 57, -1, -27, 47,
-	54, 55, -15, -15, 5, -17, -16, -1, -18, 7,
-	49, 58, 55, -1, 54, -1, 66, -29, 56, -15,
-	58, -1, 57, 77, 5, 55, 55, -15, 5, 52,
-	56, 58, 52, 54, 7, -18, 7, -1, 55, 55,
-	-19, 58, -36, 57, -19, -1, 55, 5, 55, -16,
-	-16, 5, -1, 7, -27, 54, 54, 55, -36, -19,
-	-27, -27, 55, -16, 7, -27, -27, 49, -18, 54,
-	-27,
+	-1, -1, -1, -26, -24, -25, -1, -1, -1, -1,
+	-1, -1, 5, -21, -21, -21, -21, -21, -21, -21,
+	-21, -21, -21, -21, -21, -21, 68, 5, -46, -49,
+	-56, 5, -48, 73, 77, 69, -1, -46, 55, -1,
+	66, 67, -21, -36, -35, 81, -46, -49, -21, 5,
+	-21, 5, -26, -25, -1, -23, 7, -19, -13, -14,
+	62, 103, 61, 70, 67, 66, 5, 64, 68, 64,
+	68, 70, 70, -1, 79, -48, 68, -21, 70, -1,
+	69, 91, 5, 67, -35, 67, -21, -34, 79, 67,
+	-21, 66, -21, 68, -36, 64, 66, 7, 63, -23,
+	7, -8, -1, -25, -25, 5, -1, 5, -1, -1,
+	-37, 70, 85, -55, 69, -37, -1, 67, 87, -46,
+	85, 5, 67, 5, 66, 68, -19, -46, 66, 66,
+	67, 70, -25, -1, -55, -1, -37, -46, -46, -1,
+	-1, 67, 66, 7, -46, -8, 70, -46, 85, -46,
+	61, 67, -55, -1, -34, -23, 66, -46,
 }
-var DobyDef = []int{
-
-	-2, -2, 0, 123, 108, 109, 110, 111, 112, 113,
-	114, 115, 116, 117, 118, 119, 120, 121, -2, 0,
-	0, 12, 90, 91, -2, 0, 12, 0, 12, 0,
-	59, 60, 61, 62, 63, 64, 65, 66, 67, 68,
-	69, 70, 71, 1, 2, 3, 4, 5, 0, 0,
-	0, 12, 0, 0, -2, 12, 0, 0, 12, 0,
+
+var DobyDef = [...]int16{
+	-2, -2, 0, 174, 158, 159, 160, 161, 162, 163,
+	164, 165, 166, 167, 168, 169, 170, 171, 172, -2,
+	0, 0, 20, 0, 139, 140, -2, 0, 20, 0,
+	20, 0, 95, 96, 97, 98, 99, 100, 101, 102,
+	103, 104, 105, 106, 107, 108, 109, 110, 111, 112,
+	113, 114, 115, 116, 117, 118, 1, 2, 3, 4,
+	5, 6, 7, 8, 0, 0, 0, 0, 20, 0,
+	0, 0, 0, 0, 0, -2, 20, 0, 0, 15,
+	20, 0, 0, 0, 0, 0, 0, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 0, 0, 74,
-	75, 0, 12, 12, 12, 12, 12, 12, 12, 12,
-	12, 12, 12, 12, -2, 0, 89, 13, 0, 0,
-	0, 102, 0, 0, 0, -2, 0, 107, 0, 17,
-	18, 0, 12, 12, 45, 53, 0, 124, 125, 7,
-	0, 0, 0, 19, 20, 21, 22, 23, 24, 25,
-	26, 27, 28, 29, 30, 31, 32, 33, 34, 35,
-	36, 37, 73, 14, 0, 76, 77, 78, 79, 80,
-	81, 82, 83, 84, 85, 86, 87, 92, -2, 93,
-	101, 0, 97, 98, 12, 0, 0, 0, 105, 12,
-	6, 38, 0, 0, 12, 0, 46, 0, 0, 54,
-	53, 0, 11, 0, 16, 15, 12, 99, 100, 0,
-	-2, 0, 12, 0, 0, 40, 41, 0, 50, 0,
-	52, 0, 0, 0, 0, 0, 54, 0, 10, 9,
-	94, -2, 96, 12, 0, 0, 39, 0, 43, 47,
-	48, 51, 44, 55, 56, 0, 0, 8, 95, 0,
-	104, 106, 42, 49, 0, 57, 103, 53, 0, 0,
-	58,
+	0, 0, 58, 0, 0, 0, 0, 121, 122, 0,
+	20, 20, 20, 20, 20, 20, 20, 20, 20, 20,
+	20, 20, 20, -2, 0, 137, 21, 138, 0, 0,
+	0, 152, 0, 0, 0, -2, 0, 157, 0, 11,
+	0, 25, 26, 0, 20, 21, 0, 0, 20, 20,
+	58, 85, 89, 0, 9, 175, 176, 13, 14, 0,
+	16, 0, 27, 28, 29, 30, 31, 32, 33, 34,
+	35, 36, 37, 38, 39, 40, 41, 42, 43, 44,
+	45, 46, 47, 0, 0, 59, 66, 0, 82, 84,
+	120, 22, 0, 123, 124, 125, 126, 127, 128, 129,
+	130, 131, 132, 133, 134, 135, 141, -2, 142, 151,
+	0, 147, 148, 20, 0, 0, 0, 155, 20, 10,
+	12, 48, 0, 0, 73, 20, 77, 83, 0, 20,
+	0, 20, 0, 59, 0, 0, 86, 90, 87, 88,
+	20, 0, 89, 15, 19, 24, 63, 0, 69, 0,
+	70, 0, 0, 23, 20, 149, 150, 0, -2, 0,
+	20, 0, 0, 50, 74, 75, 0, 80, 0, 51,
+	0, 54, 0, 65, 0, 0, 0, 0, 58, 0,
+	86, 0, 16, 60, 61, 64, 67, 0, 57, 81,
+	143, -2, 0, 146, 20, 0, 0, 49, 0, 78,
+	0, 0, 53, 0, 56, 76, 91, 92, 0, 0,
+	17, 15, 62, 68, 144, 0, 0, 154, 156, 71,
+	0, 52, 55, 0, 93, 0, -2, 153, 0, 77,
+	89, 18, 145, 72, 79, 0, 0, 94,
 }
-var DobyTok1 = []int{
 
+var DobyTok1 = [...]int8{
 	1, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	3, 3, 3, 3, 3, 85,
+	3, 3, 3, 3, 3, 103,
 }
-var DobyTok2 = []int{
 
+var DobyTok2 = [...]int8{
 	2, 3, 4, 5, 6, 7, 8, 9, 10, 11,
 	12, 13, 14, 15, 16, 17, 18, 19, 20, 21,
 	22, 23, 24, 25, 26, 27, 28, 29, 30, 31,
@@ -535,30 +695,60 @@ var DobyTok2 = []int{
 	52, 53, 54, 55, 56, 57, 58, 59, 60, 61,
 	62, 63, 64, 65, 66, 67, 68, 69, 70, 71,
 	72, 73, 74, 75, 76, 77, 78, 79, 80, 81,
-	82, 83, 84,
+	82, 83, 84, 85, 86, 87, 88, 89, 90, 91,
+	92, 93, 94, 95, 96, 97, 98, 99, 100, 101,
+	102,
 }
-var DobyTok3 = []int{
+
+var DobyTok3 = [...]int8{
 	0,
 }
 
+var DobyErrorMessages = [...]struct {
+	state int
+	token int
+	msg   string
+}{}
+
 //line yaccpar:1
 
 /*	parser for yacc output	*/
 
-var DobyDebug = 0
+var (
+	DobyDebug        = 0
+	DobyErrorVerbose = false
+)
 
 type DobyLexer interface {
 	Lex(lval *DobySymType) int
 	Error(s string)
 }
 
+type DobyParser interface {
+	Parse(DobyLexer) int
+	Lookahead() int
+}
+
+type DobyParserImpl struct {
+	lval  DobySymType
+	stack [DobyInitialStackSize]DobySymType
+	char  int
+}
+
+func (p *DobyParserImpl) Lookahead() int {
+	return p.char
+}
+
+func DobyNewParser() DobyParser {
+	return &DobyParserImpl{}
+}
+
 const DobyFlag = -1000
 
 func DobyTokname(c int) string {
-	// 4 is TOKSTART above
-	if c >= 4 && c-4 < len(DobyToknames) {
-		if DobyToknames[c-4] != "" {
-			return DobyToknames[c-4]
+	if c >= 1 && c-1 < len(DobyToknames) {
+		if DobyToknames[c-1] != "" {
+			return DobyToknames[c-1]
 		}
 	}
 	return __yyfmt__.Sprintf("tok-%v", c)
@@ -573,51 +763,127 @@ func DobyStatname(s int) string {
 	return __yyfmt__.Sprintf("state-%v", s)
 }
 
-func Dobylex1(lex DobyLexer, lval *DobySymType) int {
-	c := 0
-	char := lex.Lex(lval)
+func DobyErrorMessage(state, lookAhead int) string {
+	const TOKSTART = 4
+
+	if !DobyErrorVerbose {
+		return "syntax error"
+	}
+
+	for _, e := range DobyErrorMessages {
+		if e.state == state && e.token == lookAhead {
+			return "syntax error: " + e.msg
+		}
+	}
+
+	res := "syntax error: unexpected " + DobyTokname(lookAhead)
+
+	// To match Bison, suggest at most four expected tokens.
+	expected := make([]int, 0, 4)
+
+	// Look for shiftable tokens.
+	base := int(DobyPact[state])
+	for tok := TOKSTART; tok-1 < len(DobyToknames); tok++ {
+		if n := base + tok; n >= 0 && n < DobyLast && int(DobyChk[int(DobyAct[n])]) == tok {
+			if len(expected) == cap(expected) {
+				return res
+			}
+			expected = append(expected, tok)
+		}
+	}
+
+	if DobyDef[state] == -2 {
+		i := 0
+		for DobyExca[i] != -1 || int(DobyExca[i+1]) != state {
+			i += 2
+		}
+
+		// Look for tokens that we accept or reduce.
+		for i += 2; DobyExca[i] >= 0; i += 2 {
+			tok := int(DobyExca[i])
+			if tok < TOKSTART || DobyExca[i+1] == 0 {
+				continue
+			}
+			if len(expected) == cap(expected) {
+				return res
+			}
+			expected = append(expected, tok)
+		}
+
+		// If the default action is to accept or reduce, give up.
+		if DobyExca[i+1] != 0 {
+			return res
+		}
+	}
+
+	for i, tok := range expected {
+		if i == 0 {
+			res += ", expecting "
+		} else {
+			res += " or "
+		}
+		res += DobyTokname(tok)
+	}
+	return res
+}
+
+func Dobylex1(lex DobyLexer, lval *DobySymType) (char, token int) {
+	token = 0
+	char = lex.Lex(lval)
 	if char <= 0 {
-		c = DobyTok1[0]
+		token = int(DobyTok1[0])
 		goto out
 	}
 	if char < len(DobyTok1) {
-		c = DobyTok1[char]
+		token = int(DobyTok1[char])
 		goto out
 	}
 	if char >= DobyPrivate {
 		if char < DobyPrivate+len(DobyTok2) {
-			c = DobyTok2[char-DobyPrivate]
+			token = int(DobyTok2[char-DobyPrivate])
 			goto out
 		}
 	}
 	for i := 0; i < len(DobyTok3); i += 2 {
-		c = DobyTok3[i+0]
-		if c == char {
-			c = DobyTok3[i+1]
+		token = int(DobyTok3[i+0])
+		if token == char {
+			token = int(DobyTok3[i+1])
 			goto out
 		}
 	}
 
 out:
-	if c == 0 {
-		c = DobyTok2[1] /* unknown char */
+	if token == 0 {
+		token = int(DobyTok2[1]) /* unknown char */
 	}
 	if DobyDebug >= 3 {
-		__yyfmt__.Printf("lex %s(%d)\n", DobyTokname(c), uint(char))
+		__yyfmt__.Printf("lex %s(%d)\n", DobyTokname(token), uint(char))
 	}
-	return c
+	return char, token
 }
 
 func DobyParse(Dobylex DobyLexer) int {
+	return DobyNewParser().Parse(Dobylex)
+}
+
+func (Dobyrcvr *DobyParserImpl) Parse(Dobylex DobyLexer) int {
 	var Dobyn int
-	var Dobylval DobySymType
 	var DobyVAL DobySymType
-	DobyS := make([]DobySymType, DobyMaxDepth)
+	var DobyDollar []DobySymType
+	_ = DobyDollar // silence set and not used
+	DobyS := Dobyrcvr.stack[:]
 
 	Nerrs := 0   /* number of errors */
 	Errflag := 0 /* error recovery flag */
 	Dobystate := 0
-	Dobychar := -1
+	Dobyrcvr.char = -1
+	Dobytoken := -1 // Dobyrcvr.char translated into internal numbering
+	defer func() {
+		// Make sure we report no lookahead when not parsing.
+		Dobystate = -1
+		Dobyrcvr.char = -1
+		Dobytoken = -1
+	}()
 	Dobyp := -1
 	goto Dobystack
 
@@ -630,7 +896,7 @@ ret1:
 Dobystack:
 	/* put a state and value onto the stack */
 	if DobyDebug >= 4 {
-		__yyfmt__.Printf("char %v in %v\n", DobyTokname(Dobychar), DobyStatname(Dobystate))
+		__yyfmt__.Printf("char %v in %v\n", DobyTokname(Dobytoken), DobyStatname(Dobystate))
 	}
 
 	Dobyp++
@@ -643,21 +909,22 @@ Dobystack:
 	DobyS[Dobyp].yys = Dobystate
 
 Dobynewstate:
-	Dobyn = DobyPact[Dobystate]
+	Dobyn = int(DobyPact[Dobystate])
 	if Dobyn <= DobyFlag {
 		goto Dobydefault /* simple state */
 	}
-	if Dobychar < 0 {
-		Dobychar = Dobylex1(Dobylex, &Dobylval)
+	if Dobyrcvr.char < 0 {
+		Dobyrcvr.char, Dobytoken = Dobylex1(Dobylex, &Dobyrcvr.lval)
 	}
-	Dobyn += Dobychar
+	Dobyn += Dobytoken
 	if Dobyn < 0 || Dobyn >= DobyLast {
 		goto Dobydefault
 	}
-	Dobyn = DobyAct[Dobyn]
-	if DobyChk[Dobyn] == Dobychar { /* valid shift */
-		Dobychar = -1
-		DobyVAL = Dobylval
+	Dobyn = int(DobyAct[Dobyn])
+	if int(DobyChk[Dobyn]) == Dobytoken { /* valid shift */
+		Dobyrcvr.char = -1
+		Dobytoken = -1
+		DobyVAL = Dobyrcvr.lval
 		Dobystate = Dobyn
 		if Errflag > 0 {
 			Errflag--
@@ -667,27 +934,27 @@ Dobynewstate:
 
 Dobydefault:
 	/* default state action */
-	Dobyn = DobyDef[Dobystate]
+	Dobyn = int(DobyDef[Dobystate])
 	if Dobyn == -2 {
-		if Dobychar < 0 {
-			Dobychar = Dobylex1(Dobylex, &Dobylval)
+		if Dobyrcvr.char < 0 {
+			Dobyrcvr.char, Dobytoken = Dobylex1(Dobylex, &Dobyrcvr.lval)
 		}
 
 		/* look through exception table */
 		xi := 0
 		for {
-			if DobyExca[xi+0] == -1 && DobyExca[xi+1] == Dobystate {
+			if DobyExca[xi+0] == -1 && int(DobyExca[xi+1]) == Dobystate {
 				break
 			}
 			xi += 2
 		}
 		for xi += 2; ; xi += 2 {
-			Dobyn = DobyExca[xi+0]
-			if Dobyn < 0 || Dobyn == Dobychar {
+			Dobyn = int(DobyExca[xi+0])
+			if Dobyn < 0 || Dobyn == Dobytoken {
 				break
 			}
 		}
-		Dobyn = DobyExca[xi+1]
+		Dobyn = int(DobyExca[xi+1])
 		if Dobyn < 0 {
 			goto ret0
 		}
@@ -696,11 +963,11 @@ Dobydefault:
 		/* error ... attempt to resume parsing */
 		switch Errflag {
 		case 0: /* brand new error */
-			Dobylex.Error("syntax error")
+			Dobylex.Error(DobyErrorMessage(Dobystate, Dobytoken))
 			Nerrs++
 			if DobyDebug >= 1 {
 				__yyfmt__.Printf("%s", DobyStatname(Dobystate))
-				__yyfmt__.Printf(" saw %s\n", DobyTokname(Dobychar))
+				__yyfmt__.Printf(" saw %s\n", DobyTokname(Dobytoken))
 			}
 			fallthrough
 
@@ -709,10 +976,10 @@ Dobydefault:
 
 			/* find a state where "error" is a legal shift action */
 			for Dobyp >= 0 {
-				Dobyn = DobyPact[DobyS[Dobyp].yys] + DobyErrCode
+				Dobyn = int(DobyPact[DobyS[Dobyp].yys]) + DobyErrCode
 				if Dobyn >= 0 && Dobyn < DobyLast {
-					Dobystate = DobyAct[Dobyn] /* simulate a shift of "error" */
-					if DobyChk[Dobystate] == DobyErrCode {
+					Dobystate = int(DobyAct[Dobyn]) /* simulate a shift of "error" */
+					if int(DobyChk[Dobystate]) == DobyErrCode {
 						goto Dobystack
 					}
 				}
@@ -728,12 +995,13 @@ Dobydefault:
 
 		case 3: /* no shift yet; clobber input char */
 			if DobyDebug >= 2 {
-				__yyfmt__.Printf("error recovery discards %s\n", DobyTokname(Dobychar))
+				__yyfmt__.Printf("error recovery discards %s\n", DobyTokname(Dobytoken))
 			}
-			if Dobychar == DobyEofCode {
+			if Dobytoken == DobyEofCode {
 				goto ret1
 			}
-			Dobychar = -1
+			Dobyrcvr.char = -1
+			Dobytoken = -1
 			goto Dobynewstate /* try again in the same state */
 		}
 	}
@@ -747,380 +1015,855 @@ Dobydefault:
 	Dobypt := Dobyp
 	_ = Dobypt // guard against "declared and not used"
 
-	Dobyp -= DobyR2[Dobyn]
+	Dobyp -= int(DobyR2[Dobyn])
+	// Dobyp is now the index of $0. Perform the default action. Iff the
+	// reduced production is ε, $1 is possibly out of range.
+	if Dobyp+1 >= len(DobyS) {
+		nyys := make([]DobySymType, len(DobyS)*2)
+		copy(nyys, DobyS)
+		DobyS = nyys
+	}
 	DobyVAL = DobyS[Dobyp+1]
 
 	/* consult goto table to find next state */
-	Dobyn = DobyR1[Dobyn]
-	Dobyg := DobyPgo[Dobyn]
+	Dobyn = int(DobyR1[Dobyn])
+	Dobyg := int(DobyPgo[Dobyn])
 	Dobyj := Dobyg + DobyS[Dobyp].yys + 1
 
 	if Dobyj >= DobyLast {
-		Dobystate = DobyAct[Dobyg]
+		Dobystate = int(DobyAct[Dobyg])
 	} else {
-		Dobystate = DobyAct[Dobyj]
-		if DobyChk[Dobystate] != -Dobyn {
-			Dobystate = DobyAct[Dobyg]
+		Dobystate = int(DobyAct[Dobyj])
+		if int(DobyChk[Dobystate]) != -Dobyn {
+			Dobystate = int(DobyAct[Dobyg])
 		}
 	}
 	// dummy call; replaced with literal code
 	switch Dobynt {
 
 	case 1:
-		//line grammar.y:90
-		{ DobyVAL.expr = &ast.Ident{DobyS[Dobypt-0].tok.Pos, DobyS[Dobypt-0].tok.Lit} }
+		DobyDollar = DobyS[Dobypt-1 : Dobypt+1]
+//line grammar.y:102
+		{
+			DobyVAL.expr = &ast.Ident{DobyDollar[1].tok.Pos, DobyDollar[1].tok.Lit}
+		}
 	case 2:
-		//line grammar.y:92
-		{ DobyVAL.expr = &ast.BasicLit{DobyS[Dobypt-0].tok.Pos, token.INT, DobyS[Dobypt-0].tok.Lit} }
+		DobyDollar = DobyS[Dobypt-1 : Dobypt+1]
+//line grammar.y:104
+		{
+			DobyVAL.expr = &ast.BasicLit{DobyDollar[1].tok.Pos, token.INT, DobyDollar[1].tok.Lit}
+		}
 	case 3:
-		//line grammar.y:93
-		{ DobyVAL.expr = &ast.BasicLit{DobyS[Dobypt-0].tok.Pos, token.FLOAT, DobyS[Dobypt-0].tok.Lit} }
+		DobyDollar = DobyS[Dobypt-1 : Dobypt+1]
+//line grammar.y:105
+		{
+			DobyVAL.expr = &ast.BasicLit{DobyDollar[1].tok.Pos, token.FLOAT, DobyDollar[1].tok.Lit}
+		}
 	case 4:
-		//line grammar.y:94
-		{ DobyVAL.expr = &ast.BasicLit{DobyS[Dobypt-0].tok.Pos, token.STRING, DobyS[Dobypt-0].tok.Lit} }
+		DobyDollar = DobyS[Dobypt-1 : Dobypt+1]
+//line grammar.y:106
+		{
+			DobyVAL.expr = &ast.BasicLit{DobyDollar[1].tok.Pos, token.IMAG, DobyDollar[1].tok.Lit}
+		}
 	case 5:
-		//line grammar.y:95
-		{ DobyVAL.expr = &ast.BasicLit{DobyS[Dobypt-0].tok.Pos, token.CHAR, DobyS[Dobypt-0].tok.Lit} }
+		DobyDollar = DobyS[Dobypt-1 : Dobypt+1]
+//line grammar.y:107
+		{
+			DobyVAL.expr = &ast.BasicLit{DobyDollar[1].tok.Pos, token.STRING, DobyDollar[1].tok.Lit}
+		}
 	case 6:
-		//line grammar.y:97
-		{ DobyVAL.expr = &ast.ParenExpr{DobyS[Dobypt-2].tok.Pos, DobyS[Dobypt-1].expr, DobyS[Dobypt-0].tok.Pos} }
+		DobyDollar = DobyS[Dobypt-1 : Dobypt+1]
+//line grammar.y:108
+		{
+			DobyVAL.expr = &ast.BasicLit{DobyDollar[1].tok.Pos, token.CHAR, DobyDollar[1].tok.Lit}
+		}
 	case 7:
-		//line grammar.y:99
-		{ DobyVAL.expr = &ast.SelectorExpr{DobyS[Dobypt-2].expr, DobyS[Dobypt-0].expr.(*ast.Ident)} }
+		DobyDollar = DobyS[Dobypt-1 : Dobypt+1]
+//line grammar.y:109
+		{
+			DobyVAL.expr = &ast.BasicLit{DobyDollar[1].tok.Pos, token.SYMBOL, DobyDollar[1].tok.Lit}
+		}
 	case 8:
-		//line grammar.y:102
-		{ DobyVAL.expr = &ast.SliceExpr{DobyS[Dobypt-5].expr, DobyS[Dobypt-4].tok.Pos, DobyS[Dobypt-3].expr, DobyS[Dobypt-1].expr, DobyS[Dobypt-0].tok.Pos} }
+		DobyDollar = DobyS[Dobypt-1 : Dobypt+1]
+//line grammar.y:110
+		{
+			DobyVAL.expr = &ast.BasicLit{DobyDollar[1].tok.Pos, token.REGEXP, DobyDollar[1].tok.Lit}
+		}
 	case 9:
-		//line grammar.y:104
-		{ DobyVAL.expr = &ast.SliceExpr{DobyS[Dobypt-4].expr, DobyS[Dobypt-3].tok.Pos, nil, DobyS[Dobypt-1].expr, DobyS[Dobypt-0].tok.Pos} }
+		DobyDollar = DobyS[Dobypt-2 : Dobypt+1]
+//line grammar.y:113
+		{
+			DobyVAL.expr_list = []ast.Expr{&ast.BasicLit{DobyDollar[1].tok.Pos, token.STRING, DobyDollar[1].tok.Lit}, DobyDollar[2].expr}
+		}
 	case 10:
-		//line grammar.y:106
-		{ DobyVAL.expr = &ast.SliceExpr{DobyS[Dobypt-4].expr, DobyS[Dobypt-3].tok.Pos, DobyS[Dobypt-2].expr, nil, DobyS[Dobypt-0].tok.Pos} }
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:115
+		{
+			DobyVAL.expr_list = append(DobyDollar[1].expr_list, &ast.BasicLit{DobyDollar[2].tok.Pos, token.STRING, DobyDollar[2].tok.Lit}, DobyDollar[3].expr)
+		}
 	case 11:
-		//line grammar.y:109
-		{ DobyVAL.expr = &ast.IndexExpr{DobyS[Dobypt-3].expr, DobyS[Dobypt-2].tok.Pos, DobyS[Dobypt-1].expr, DobyS[Dobypt-2].tok.Pos} }
+		DobyDollar = DobyS[Dobypt-2 : Dobypt+1]
+//line grammar.y:118
+		{
+			DobyVAL.expr = &ast.InterpExpr{DobyDollar[1].expr_list[0].(*ast.BasicLit).ValuePos, append(DobyDollar[1].expr_list, &ast.BasicLit{DobyDollar[2].tok.Pos, token.STRING, DobyDollar[2].tok.Lit})}
+		}
 	case 12:
-		//line grammar.y:111
-		{ DobyVAL.expr_list = []ast.Expr{} }
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:120
+		{
+			DobyVAL.expr = &ast.ParenExpr{DobyDollar[1].tok.Pos, DobyDollar[2].expr, DobyDollar[3].tok.Pos}
+		}
 	case 13:
-		//line grammar.y:112
-		{ DobyVAL.expr_list = []ast.Expr{DobyS[Dobypt-0].expr} }
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:122
+		{
+			DobyVAL.expr = &ast.SelectorExpr{DobyDollar[1].expr, DobyDollar[3].expr.(*ast.Ident), false}
+		}
 	case 14:
-		//line grammar.y:113
-		{ DobyVAL.expr_list = append(DobyS[Dobypt-2].expr_list, DobyS[Dobypt-0].expr) }
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:123
+		{
+			DobyVAL.expr = &ast.SelectorExpr{DobyDollar[1].expr, DobyDollar[3].expr.(*ast.Ident), true}
+		}
 	case 15:
-		//line grammar.y:114
-		{ DobyVAL.expr_list = append(DobyS[Dobypt-3].expr_list, DobyS[Dobypt-0].expr) }
+		DobyDollar = DobyS[Dobypt-0 : Dobypt+1]
+//line grammar.y:125
+		{
+			DobyVAL.expr = nil
+		}
 	case 16:
-		//line grammar.y:116
-		{ DobyVAL.expr = &ast.CallExpr{DobyS[Dobypt-3].expr, DobyS[Dobypt-2].tok.Pos, DobyS[Dobypt-1].expr_list, DobyS[Dobypt-0].tok.Pos} }
+		DobyDollar = DobyS[Dobypt-1 : Dobypt+1]
+//line grammar.y:126
+		{
+			DobyVAL.expr = DobyDollar[1].expr
+		}
 	case 17:
-		//line grammar.y:118
-		{ DobyVAL.expr = &ast.UnaryExpr{DobyS[Dobypt-1].tok.Pos, token.SUB, DobyS[Dobypt-0].expr } }
+		DobyDollar = DobyS[Dobypt-6 : Dobypt+1]
+//line grammar.y:129
+		{
+			DobyVAL.expr = &ast.SliceExpr{DobyDollar[1].expr, DobyDollar[2].tok.Pos, DobyDollar[3].expr, DobyDollar[5].expr, nil, DobyDollar[6].tok.Pos}
+		}
 	case 18:
-		//line grammar.y:119
-		{ DobyVAL.expr = &ast.UnaryExpr{DobyS[Dobypt-1].tok.Pos, token.NOT, DobyS[Dobypt-0].expr } }
+		DobyDollar = DobyS[Dobypt-8 : Dobypt+1]
+//line grammar.y:131
+		{
+			DobyVAL.expr = &ast.SliceExpr{DobyDollar[1].expr, DobyDollar[2].tok.Pos, DobyDollar[3].expr, DobyDollar[5].expr, DobyDollar[7].expr, DobyDollar[8].tok.Pos}
+		}
 	case 19:
-		//line grammar.y:121
-		{ DobyVAL.expr = &ast.BinaryExpr{DobyS[Dobypt-2].expr, DobyS[Dobypt-1].tok.Pos, token.ADD, DobyS[Dobypt-0].expr } }
+		DobyDollar = DobyS[Dobypt-4 : Dobypt+1]
+//line grammar.y:134
+		{
+			DobyVAL.expr = &ast.IndexExpr{DobyDollar[1].expr, DobyDollar[2].tok.Pos, DobyDollar[3].expr, DobyDollar[2].tok.Pos}
+		}
 	case 20:
-		//line grammar.y:122
-		{ DobyVAL.expr = &ast.BinaryExpr{DobyS[Dobypt-2].expr, DobyS[Dobypt-1].tok.Pos, token.SUB, DobyS[Dobypt-0].expr } }
+		DobyDollar = DobyS[Dobypt-0 : Dobypt+1]
+//line grammar.y:136
+		{
+			DobyVAL.expr_list = []ast.Expr{}
+		}
 	case 21:
-		//line grammar.y:123
-		{ DobyVAL.expr = &ast.BinaryExpr{DobyS[Dobypt-2].expr, DobyS[Dobypt-1].tok.Pos, token.MUL, DobyS[Dobypt-0].expr } }
+		DobyDollar = DobyS[Dobypt-1 : Dobypt+1]
+//line grammar.y:137
+		{
+			DobyVAL.expr_list = []ast.Expr{DobyDollar[1].expr}
+		}
 	case 22:
-		//line grammar.y:124
-		{ DobyVAL.expr = &ast.BinaryExpr{DobyS[Dobypt-2].expr, DobyS[Dobypt-1].tok.Pos, token.QUO, DobyS[Dobypt-0].expr } }
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:138
+		{
+			DobyVAL.expr_list = append(DobyDollar[1].expr_list, DobyDollar[3].expr)
+		}
 	case 23:
-		//line grammar.y:125
-		{ DobyVAL.expr = &ast.BinaryExpr{DobyS[Dobypt-2].expr, DobyS[Dobypt-1].tok.Pos, token.REM, DobyS[Dobypt-0].expr } }
+		DobyDollar = DobyS[Dobypt-4 : Dobypt+1]
+//line grammar.y:139
+		{
+			DobyVAL.expr_list = append(DobyDollar[1].expr_list, DobyDollar[4].expr)
+		}
 	case 24:
-		//line grammar.y:126
-		{ DobyVAL.expr = &ast.BinaryExpr{DobyS[Dobypt-2].expr, DobyS[Dobypt-1].tok.Pos, token.AND, DobyS[Dobypt-0].expr } }
+		DobyDollar = DobyS[Dobypt-4 : Dobypt+1]
+//line grammar.y:141
+		{
+			DobyVAL.expr = &ast.CallExpr{DobyDollar[1].expr, DobyDollar[2].tok.Pos, DobyDollar[3].expr_list, DobyDollar[4].tok.Pos}
+		}
 	case 25:
-		//line grammar.y:127
-		{ DobyVAL.expr = &ast.BinaryExpr{DobyS[Dobypt-2].expr, DobyS[Dobypt-1].tok.Pos, token.OR, DobyS[Dobypt-0].expr } }
+		DobyDollar = DobyS[Dobypt-2 : Dobypt+1]
+//line grammar.y:143
+		{
+			DobyVAL.expr = &ast.UnaryExpr{DobyDollar[1].tok.Pos, token.SUB, DobyDollar[2].expr}
+		}
 	case 26:
-		//line grammar.y:128
-		{ DobyVAL.expr = &ast.BinaryExpr{DobyS[Dobypt-2].expr, DobyS[Dobypt-1].tok.Pos, token.XOR, DobyS[Dobypt-0].expr } }
+		DobyDollar = DobyS[Dobypt-2 : Dobypt+1]
+//line grammar.y:144
+		{
+			DobyVAL.expr = &ast.UnaryExpr{DobyDollar[1].tok.Pos, token.NOT, DobyDollar[2].expr}
+		}
 	case 27:
-		//line grammar.y:129
-		{ DobyVAL.expr = &ast.BinaryExpr{DobyS[Dobypt-2].expr, DobyS[Dobypt-1].tok.Pos, token.SHL, DobyS[Dobypt-0].expr } }
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:146
+		{
+			DobyVAL.expr = &ast.BinaryExpr{DobyDollar[1].expr, DobyDollar[2].tok.Pos, token.ADD, DobyDollar[3].expr}
+		}
 	case 28:
-		//line grammar.y:130
-		{ DobyVAL.expr = &ast.BinaryExpr{DobyS[Dobypt-2].expr, DobyS[Dobypt-1].tok.Pos, token.SHR, DobyS[Dobypt-0].expr } }
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:147
+		{
+			DobyVAL.expr = &ast.BinaryExpr{DobyDollar[1].expr, DobyDollar[2].tok.Pos, token.SUB, DobyDollar[3].expr}
+		}
 	case 29:
-		//line grammar.y:131
-		{ DobyVAL.expr = &ast.BinaryExpr{DobyS[Dobypt-2].expr, DobyS[Dobypt-1].tok.Pos, token.AND_NOT, DobyS[Dobypt-0].expr } }
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:148
+		{
+			DobyVAL.expr = &ast.BinaryExpr{DobyDollar[1].expr, DobyDollar[2].tok.Pos, token.MUL, DobyDollar[3].expr}
+		}
 	case 30:
-		//line grammar.y:132
-		{ DobyVAL.expr = &ast.BinaryExpr{DobyS[Dobypt-2].expr, DobyS[Dobypt-1].tok.Pos, token.LSS, DobyS[Dobypt-0].expr } }
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:149
+		{
+			DobyVAL.expr = &ast.BinaryExpr{DobyDollar[1].expr, DobyDollar[2].tok.Pos, token.QUO, DobyDollar[3].expr}
+		}
 	case 31:
-		//line grammar.y:133
-		{ DobyVAL.expr = &ast.BinaryExpr{DobyS[Dobypt-2].expr, DobyS[Dobypt-1].tok.Pos, token.GTR, DobyS[Dobypt-0].expr } }
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:150
+		{
+			DobyVAL.expr = &ast.BinaryExpr{DobyDollar[1].expr, DobyDollar[2].tok.Pos, token.REM, DobyDollar[3].expr}
+		}
 	case 32:
-		//line grammar.y:134
-		{ DobyVAL.expr = &ast.BinaryExpr{DobyS[Dobypt-2].expr, DobyS[Dobypt-1].tok.Pos, token.NEQ, DobyS[Dobypt-0].expr } }
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:151
+		{
+			DobyVAL.expr = &ast.BinaryExpr{DobyDollar[1].expr, DobyDollar[2].tok.Pos, token.POW, DobyDollar[3].expr}
+		}
 	case 33:
-		//line grammar.y:135
-		{ DobyVAL.expr = &ast.BinaryExpr{DobyS[Dobypt-2].expr, DobyS[Dobypt-1].tok.Pos, token.LEQ, DobyS[Dobypt-0].expr } }
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:152
+		{
+			DobyVAL.expr = &ast.BinaryExpr{DobyDollar[1].expr, DobyDollar[2].tok.Pos, token.AND, DobyDollar[3].expr}
+		}
 	case 34:
-		//line grammar.y:136
-		{ DobyVAL.expr = &ast.BinaryExpr{DobyS[Dobypt-2].expr, DobyS[Dobypt-1].tok.Pos, token.GEQ, DobyS[Dobypt-0].expr } }
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:153
+		{
+			DobyVAL.expr = &ast.BinaryExpr{DobyDollar[1].expr, DobyDollar[2].tok.Pos, token.OR, DobyDollar[3].expr}
+		}
 	case 35:
-		//line grammar.y:137
-		{ DobyVAL.expr = &ast.BinaryExpr{DobyS[Dobypt-2].expr, DobyS[Dobypt-1].tok.Pos, token.EQL, DobyS[Dobypt-0].expr } }
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:154
+		{
+			DobyVAL.expr = &ast.BinaryExpr{DobyDollar[1].expr, DobyDollar[2].tok.Pos, token.XOR, DobyDollar[3].expr}
+		}
 	case 36:
-		//line grammar.y:139
-		{ DobyVAL.expr = &ast.BinaryExpr{DobyS[Dobypt-2].expr, DobyS[Dobypt-1].tok.Pos, token.LAND, DobyS[Dobypt-0].expr } }
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:155
+		{
+			DobyVAL.expr = &ast.BinaryExpr{DobyDollar[1].expr, DobyDollar[2].tok.Pos, token.SHL, DobyDollar[3].expr}
+		}
 	case 37:
-		//line grammar.y:140
-		{ DobyVAL.expr = &ast.BinaryExpr{DobyS[Dobypt-2].expr, DobyS[Dobypt-1].tok.Pos, token.LOR, DobyS[Dobypt-0].expr } }
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:156
+		{
+			DobyVAL.expr = &ast.BinaryExpr{DobyDollar[1].expr, DobyDollar[2].tok.Pos, token.SHR, DobyDollar[3].expr}
+		}
 	case 38:
-		//line grammar.y:143
-		{ DobyVAL.expr = &ast.ArrayExpr{DobyS[Dobypt-2].tok.Pos, DobyS[Dobypt-1].expr_list, DobyS[Dobypt-0].tok.Pos} }
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:157
+		{
+			DobyVAL.expr = &ast.BinaryExpr{DobyDollar[1].expr, DobyDollar[2].tok.Pos, token.AND_NOT, DobyDollar[3].expr}
+		}
 	case 39:
-		//line grammar.y:145
-		{ DobyVAL.expr = &ast.ArrayExpr{DobyS[Dobypt-4].tok.Pos, DobyS[Dobypt-2].expr_list, DobyS[Dobypt-1].tok.Pos} }
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:158
+		{
+			DobyVAL.expr = &ast.BinaryExpr{DobyDollar[1].expr, DobyDollar[2].tok.Pos, token.LSS, DobyDollar[3].expr}
+		}
 	case 40:
-		//line grammar.y:147
-		{ DobyVAL.expr = &ast.ArrayExpr{DobyS[Dobypt-3].tok.Pos, DobyS[Dobypt-1].expr_list, DobyS[Dobypt-0].tok.Pos} }
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:159
+		{
+			DobyVAL.expr = &ast.BinaryExpr{DobyDollar[1].expr, DobyDollar[2].tok.Pos, token.GTR, DobyDollar[3].expr}
+		}
 	case 41:
-		//line grammar.y:150
-		{ DobyVAL.expr = &ast.SetExpr{DobyS[Dobypt-2].tok.Pos, DobyS[Dobypt-1].expr_list, DobyS[Dobypt-0].tok.Pos} }
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:160
+		{
+			DobyVAL.expr = &ast.BinaryExpr{DobyDollar[1].expr, DobyDollar[2].tok.Pos, token.NEQ, DobyDollar[3].expr}
+		}
 	case 42:
-		//line grammar.y:152
-		{ DobyVAL.expr = &ast.SetExpr{DobyS[Dobypt-4].tok.Pos, DobyS[Dobypt-2].expr_list, DobyS[Dobypt-0].tok.Pos} }
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:161
+		{
+			DobyVAL.expr = &ast.BinaryExpr{DobyDollar[1].expr, DobyDollar[2].tok.Pos, token.LEQ, DobyDollar[3].expr}
+		}
 	case 43:
-		//line grammar.y:154
-		{ DobyVAL.expr = &ast.SetExpr{DobyS[Dobypt-3].tok.Pos, DobyS[Dobypt-1].expr_list, DobyS[Dobypt-0].tok.Pos} }
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:162
+		{
+			DobyVAL.expr = &ast.BinaryExpr{DobyDollar[1].expr, DobyDollar[2].tok.Pos, token.GEQ, DobyDollar[3].expr}
+		}
 	case 44:
-		//line grammar.y:157
-		{ DobyVAL.field = &ast.Field{DobyS[Dobypt-2].expr, DobyS[Dobypt-1].tok.Pos, DobyS[Dobypt-0].expr} }
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:163
+		{
+			DobyVAL.expr = &ast.BinaryExpr{DobyDollar[1].expr, DobyDollar[2].tok.Pos, token.EQL, DobyDollar[3].expr}
+		}
 	case 45:
-		//line grammar.y:159
-		{ DobyVAL.field_list = []*ast.Field{} }
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:164
+		{
+			DobyVAL.expr = &ast.BinaryExpr{DobyDollar[1].expr, DobyDollar[2].tok.Pos, token.MATCH_OP, DobyDollar[3].expr}
+		}
 	case 46:
-		//line grammar.y:160
-		{ DobyVAL.field_list = []*ast.Field{DobyS[Dobypt-0].field} }
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:166
+		{
+			DobyVAL.expr = &ast.BinaryExpr{DobyDollar[1].expr, DobyDollar[2].tok.Pos, token.LAND, DobyDollar[3].expr}
+		}
 	case 47:
-		//line grammar.y:161
-		{ DobyVAL.field_list = append(DobyS[Dobypt-2].field_list, DobyS[Dobypt-0].field) }
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:167
+		{
+			DobyVAL.expr = &ast.BinaryExpr{DobyDollar[1].expr, DobyDollar[2].tok.Pos, token.LOR, DobyDollar[3].expr}
+		}
 	case 48:
-		//line grammar.y:162
-		{ DobyVAL.field_list = append(DobyS[Dobypt-2].field_list, DobyS[Dobypt-0].field) }
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:170
+		{
+			DobyVAL.expr = &ast.ArrayExpr{DobyDollar[1].tok.Pos, DobyDollar[2].expr_list, DobyDollar[3].tok.Pos}
+		}
 	case 49:
-		//line grammar.y:163
-		{ DobyVAL.field_list = append(DobyS[Dobypt-3].field_list, DobyS[Dobypt-0].field) }
+		DobyDollar = DobyS[Dobypt-5 : Dobypt+1]
+//line grammar.y:172
+		{
+			DobyVAL.expr = &ast.ArrayExpr{DobyDollar[1].tok.Pos, DobyDollar[3].expr_list, DobyDollar[4].tok.Pos}
+		}
 	case 50:
-		//line grammar.y:164
-		{ DobyVAL.field_list = DobyS[Dobypt-1].field_list }
+		DobyDollar = DobyS[Dobypt-4 : Dobypt+1]
+//line grammar.y:174
+		{
+			DobyVAL.expr = &ast.ArrayExpr{DobyDollar[1].tok.Pos, DobyDollar[3].expr_list, DobyDollar[4].tok.Pos}
+		}
 	case 51:
-		//line grammar.y:165
-		{ DobyVAL.field_list = DobyS[Dobypt-2].field_list }
+		DobyDollar = DobyS[Dobypt-4 : Dobypt+1]
+//line grammar.y:177
+		{
+			DobyVAL.expr = &ast.SetExpr{DobyDollar[2].tok.Pos, DobyDollar[3].expr_list, DobyDollar[4].tok.Pos}
+		}
 	case 52:
-		//line grammar.y:168
-		{ DobyVAL.expr = &ast.DictExpr{DobyS[Dobypt-2].tok.Pos, DobyS[Dobypt-1].field_list, DobyS[Dobypt-0].tok.Pos} }
+		DobyDollar = DobyS[Dobypt-6 : Dobypt+1]
+//line grammar.y:179
+		{
+			DobyVAL.expr = &ast.SetExpr{DobyDollar[2].tok.Pos, DobyDollar[4].expr_list, DobyDollar[6].tok.Pos}
+		}
 	case 53:
-		//line grammar.y:171
-		{ DobyVAL.ident_list = []*ast.Ident{} }
+		DobyDollar = DobyS[Dobypt-5 : Dobypt+1]
+//line grammar.y:181
+		{
+			DobyVAL.expr = &ast.SetExpr{DobyDollar[2].tok.Pos, DobyDollar[4].expr_list, DobyDollar[5].tok.Pos}
+		}
 	case 54:
-		//line grammar.y:173
-		{ DobyVAL.ident_list = []*ast.Ident{&ast.Ident{DobyS[Dobypt-0].tok.Pos, DobyS[Dobypt-0].tok.Lit}} }
+		DobyDollar = DobyS[Dobypt-4 : Dobypt+1]
+//line grammar.y:184
+		{
+			DobyVAL.expr = &ast.TupleExpr{DobyDollar[2].tok.Pos, DobyDollar[3].expr_list, DobyDollar[4].tok.Pos}
+		}
 	case 55:
-		//line grammar.y:175
-		{ DobyVAL.ident_list = append(DobyS[Dobypt-2].ident_list, &ast.Ident{DobyS[Dobypt-0].tok.Pos, DobyS[Dobypt-0].tok.Lit}) }
+		DobyDollar = DobyS[Dobypt-6 : Dobypt+1]
+//line grammar.y:186
+		{
+			DobyVAL.expr = &ast.TupleExpr{DobyDollar[2].tok.Pos, DobyDollar[4].expr_list, DobyDollar[6].tok.Pos}
+		}
 	case 56:
-		//line grammar.y:178
-		{ DobyVAL.expr = &ast.FuncDeclExpr{DobyS[Dobypt-4].tok.Pos, nil, nil, nil, DobyS[Dobypt-2].ident_list, DobyS[Dobypt-0].stmt.(*ast.BlockStmt), []string{}} }
+		DobyDollar = DobyS[Dobypt-5 : Dobypt+1]
+//line grammar.y:188
+		{
+			DobyVAL.expr = &ast.TupleExpr{DobyDollar[2].tok.Pos, DobyDollar[4].expr_list, DobyDollar[5].tok.Pos}
+		}
 	case 57:
-		//line grammar.y:180
-		{ DobyVAL.expr = &ast.FuncDeclExpr{DobyS[Dobypt-5].tok.Pos, nil, nil, &ast.Ident{DobyS[Dobypt-4].tok.Pos, DobyS[Dobypt-4].tok.Lit}, DobyS[Dobypt-2].ident_list, DobyS[Dobypt-0].stmt.(*ast.BlockStmt), []string{}} }
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:191
+		{
+			DobyVAL.field = &ast.Field{DobyDollar[1].expr, DobyDollar[2].tok.Pos, DobyDollar[3].expr}
+		}
 	case 58:
-		//line grammar.y:182
-		{ DobyVAL.expr = &ast.FuncDeclExpr{DobyS[Dobypt-9].tok.Pos, &ast.Ident{DobyS[Dobypt-7].tok.Pos, DobyS[Dobypt-7].tok.Lit}, &ast.Ident{DobyS[Dobypt-6].tok.Pos, DobyS[Dobypt-6].tok.Lit},
-	                                          &ast.Ident{DobyS[Dobypt-4].tok.Pos, DobyS[Dobypt-4].tok.Lit}, DobyS[Dobypt-2].ident_list, DobyS[Dobypt-0].stmt.(*ast.BlockStmt), []string{}} }
+		DobyDollar = DobyS[Dobypt-0 : Dobypt+1]
+//line grammar.y:193
+		{
+			DobyVAL.field_list = []*ast.Field{}
+		}
 	case 59:
-		DobyVAL.expr = DobyS[Dobypt-0].expr
+		DobyDollar = DobyS[Dobypt-1 : Dobypt+1]
+//line grammar.y:194
+		{
+			DobyVAL.field_list = []*ast.Field{DobyDollar[1].field}
+		}
 	case 60:
-		DobyVAL.expr = DobyS[Dobypt-0].expr
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:195
+		{
+			DobyVAL.field_list = append(DobyDollar[1].field_list, DobyDollar[3].field)
+		}
 	case 61:
-		DobyVAL.expr = DobyS[Dobypt-0].expr
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:196
+		{
+			DobyVAL.field_list = append(DobyDollar[1].field_list, DobyDollar[3].field)
+		}
 	case 62:
-		DobyVAL.expr = DobyS[Dobypt-0].expr
+		DobyDollar = DobyS[Dobypt-4 : Dobypt+1]
+//line grammar.y:197
+		{
+			DobyVAL.field_list = append(DobyDollar[1].field_list, DobyDollar[4].field)
+		}
 	case 63:
-		DobyVAL.expr = DobyS[Dobypt-0].expr
+		DobyDollar = DobyS[Dobypt-2 : Dobypt+1]
+//line grammar.y:198
+		{
+			DobyVAL.field_list = DobyDollar[1].field_list
+		}
 	case 64:
-		DobyVAL.expr = DobyS[Dobypt-0].expr
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:199
+		{
+			DobyVAL.field_list = DobyDollar[1].field_list
+		}
 	case 65:
-		DobyVAL.expr = DobyS[Dobypt-0].expr
+		DobyDollar = DobyS[Dobypt-4 : Dobypt+1]
+//line grammar.y:202
+		{
+			DobyVAL.expr = &ast.DictExpr{DobyDollar[2].tok.Pos, DobyDollar[3].field_list, DobyDollar[4].tok.Pos}
+		}
 	case 66:
-		DobyVAL.expr = DobyS[Dobypt-0].expr
+		DobyDollar = DobyS[Dobypt-1 : Dobypt+1]
+//line grammar.y:204
+		{
+			DobyVAL.expr_list = []ast.Expr{DobyDollar[1].expr}
+		}
 	case 67:
-		DobyVAL.expr = DobyS[Dobypt-0].expr
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:205
+		{
+			DobyVAL.expr_list = append(DobyDollar[1].expr_list, DobyDollar[3].expr)
+		}
 	case 68:
-		DobyVAL.expr = DobyS[Dobypt-0].expr
+		DobyDollar = DobyS[Dobypt-4 : Dobypt+1]
+//line grammar.y:206
+		{
+			DobyVAL.expr_list = append(DobyDollar[1].expr_list, DobyDollar[4].expr)
+		}
 	case 69:
-		DobyVAL.expr = DobyS[Dobypt-0].expr
+		DobyDollar = DobyS[Dobypt-4 : Dobypt+1]
+//line grammar.y:209
+		{
+			DobyVAL.expr = &ast.CompositeLit{DobyDollar[1].expr, DobyDollar[2].tok.Pos, DobyDollar[3].field_list, nil, DobyDollar[4].tok.Pos}
+		}
 	case 70:
-		DobyVAL.expr = DobyS[Dobypt-0].expr
+		DobyDollar = DobyS[Dobypt-4 : Dobypt+1]
+//line grammar.y:211
+		{
+			DobyVAL.expr = &ast.CompositeLit{DobyDollar[1].expr, DobyDollar[2].tok.Pos, nil, DobyDollar[3].expr_list, DobyDollar[4].tok.Pos}
+		}
 	case 71:
-		DobyVAL.expr = DobyS[Dobypt-0].expr
+		DobyDollar = DobyS[Dobypt-4 : Dobypt+1]
+//line grammar.y:214
+		{
+			DobyVAL.clause = &ast.CompClause{DobyDollar[1].tok.Pos, DobyDollar[2].expr_list, DobyDollar[4].expr, nil}
+		}
 	case 72:
-		//line grammar.y:201
-		{ DobyVAL.stmt = &ast.ExprStmt{DobyS[Dobypt-0].expr} }
+		DobyDollar = DobyS[Dobypt-6 : Dobypt+1]
+//line grammar.y:216
+		{
+			DobyVAL.clause = &ast.CompClause{DobyDollar[1].tok.Pos, DobyDollar[2].expr_list, DobyDollar[4].expr, DobyDollar[6].expr}
+		}
 	case 73:
-		//line grammar.y:203
-		{ DobyVAL.stmt = &ast.SendStmt{DobyS[Dobypt-2].expr, DobyS[Dobypt-1].tok.Pos, DobyS[Dobypt-0].expr} }
+		DobyDollar = DobyS[Dobypt-1 : Dobypt+1]
+//line grammar.y:218
+		{
+			DobyVAL.clause_list = []*ast.CompClause{DobyDollar[1].clause}
+		}
 	case 74:
-		//line grammar.y:205
-		{ DobyVAL.stmt = &ast.IncDecStmt{DobyS[Dobypt-1].expr, DobyS[Dobypt-0].tok.Pos, token.INC} }
+		DobyDollar = DobyS[Dobypt-2 : Dobypt+1]
+//line grammar.y:219
+		{
+			DobyVAL.clause_list = append(DobyDollar[1].clause_list, DobyDollar[2].clause)
+		}
 	case 75:
-		//line grammar.y:206
-		{ DobyVAL.stmt = &ast.IncDecStmt{DobyS[Dobypt-1].expr, DobyS[Dobypt-0].tok.Pos, token.DEC} }
+		DobyDollar = DobyS[Dobypt-4 : Dobypt+1]
+//line grammar.y:222
+		{
+			DobyVAL.expr = &ast.ArrayCompExpr{DobyDollar[1].tok.Pos, DobyDollar[2].expr, DobyDollar[3].clause_list, DobyDollar[4].tok.Pos}
+		}
 	case 76:
-		//line grammar.y:208
-		{ DobyVAL.stmt = &ast.AssignStmt{DobyS[Dobypt-2].expr_list, DobyS[Dobypt-1].tok.Pos, token.ASSIGN, DobyS[Dobypt-0].expr_list} }
+		DobyDollar = DobyS[Dobypt-5 : Dobypt+1]
+//line grammar.y:225
+		{
+			DobyVAL.expr = &ast.DictCompExpr{DobyDollar[2].tok.Pos, DobyDollar[3].field, DobyDollar[4].clause_list, DobyDollar[5].tok.Pos}
+		}
 	case 77:
-		//line grammar.y:209
-		{ DobyVAL.stmt = &ast.AssignStmt{DobyS[Dobypt-2].expr_list, DobyS[Dobypt-1].tok.Pos, token.ADD_ASSIGN, DobyS[Dobypt-0].expr_list} }
+		DobyDollar = DobyS[Dobypt-0 : Dobypt+1]
+//line grammar.y:227
+		{
+			DobyVAL.stmt = nil
+		}
 	case 78:
-		//line grammar.y:210
-		{ DobyVAL.stmt = &ast.AssignStmt{DobyS[Dobypt-2].expr_list, DobyS[Dobypt-1].tok.Pos, token.SUB_ASSIGN, DobyS[Dobypt-0].expr_list} }
+		DobyDollar = DobyS[Dobypt-2 : Dobypt+1]
+//line grammar.y:228
+		{
+			DobyVAL.stmt = DobyDollar[2].stmt
+		}
 	case 79:
-		//line grammar.y:211
-		{ DobyVAL.stmt = &ast.AssignStmt{DobyS[Dobypt-2].expr_list, DobyS[Dobypt-1].tok.Pos, token.MUL_ASSIGN, DobyS[Dobypt-0].expr_list} }
+		DobyDollar = DobyS[Dobypt-5 : Dobypt+1]
+//line grammar.y:230
+		{
+			DobyVAL.stmt = &ast.BlockStmt{DobyDollar[2].tok.Pos, []ast.Stmt{&ast.ExprStmt{&ast.IfExpr{DobyDollar[2].tok.Pos, DobyDollar[3].expr, DobyDollar[4].stmt.(*ast.BlockStmt), DobyDollar[5].stmt}}}, DobyDollar[2].tok.Pos}
+		}
 	case 80:
-		//line grammar.y:212
-		{ DobyVAL.stmt = &ast.AssignStmt{DobyS[Dobypt-2].expr_list, DobyS[Dobypt-1].tok.Pos, token.QUO_ASSIGN, DobyS[Dobypt-0].expr_list} }
+		DobyDollar = DobyS[Dobypt-4 : Dobypt+1]
+//line grammar.y:233
+		{
+			DobyVAL.expr = &ast.IfExpr{DobyDollar[1].tok.Pos, DobyDollar[2].expr, DobyDollar[3].stmt.(*ast.BlockStmt), DobyDollar[4].stmt}
+		}
 	case 81:
-		//line grammar.y:213
-		{ DobyVAL.stmt = &ast.AssignStmt{DobyS[Dobypt-2].expr_list, DobyS[Dobypt-1].tok.Pos, token.REM_ASSIGN, DobyS[Dobypt-0].expr_list} }
+		DobyDollar = DobyS[Dobypt-5 : Dobypt+1]
+//line grammar.y:236
+		{
+			DobyVAL.expr = &ast.CondExpr{DobyDollar[1].expr, DobyDollar[2].tok.Pos, DobyDollar[3].expr, DobyDollar[4].tok.Pos, DobyDollar[5].expr}
+		}
 	case 82:
-		//line grammar.y:214
-		{ DobyVAL.stmt = &ast.AssignStmt{DobyS[Dobypt-2].expr_list, DobyS[Dobypt-1].tok.Pos, token.AND_ASSIGN, DobyS[Dobypt-0].expr_list} }
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:239
+		{
+			DobyVAL.expr = &ast.RangeExpr{DobyDollar[1].expr, DobyDollar[2].tok.Pos, DobyDollar[3].expr}
+		}
 	case 83:
-		//line grammar.y:215
-		{ DobyVAL.stmt = &ast.AssignStmt{DobyS[Dobypt-2].expr_list, DobyS[Dobypt-1].tok.Pos, token.OR_ASSIGN, DobyS[Dobypt-0].expr_list} }
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:242
+		{
+			DobyVAL.expr = &ast.MatchExpr{DobyDollar[1].tok.Pos, DobyDollar[2].expr, DobyDollar[3].stmt.(*ast.BlockStmt)}
+		}
 	case 84:
-		//line grammar.y:216
-		{ DobyVAL.stmt = &ast.AssignStmt{DobyS[Dobypt-2].expr_list, DobyS[Dobypt-1].tok.Pos, token.XOR_ASSIGN, DobyS[Dobypt-0].expr_list} }
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:245
+		{
+			DobyVAL.expr = &ast.CoalesceExpr{DobyDollar[1].expr, DobyDollar[2].tok.Pos, DobyDollar[3].expr}
+		}
 	case 85:
-		//line grammar.y:217
-		{ DobyVAL.stmt = &ast.AssignStmt{DobyS[Dobypt-2].expr_list, DobyS[Dobypt-1].tok.Pos, token.SHL_ASSIGN, DobyS[Dobypt-0].expr_list} }
+		DobyDollar = DobyS[Dobypt-2 : Dobypt+1]
+//line grammar.y:248
+		{
+			DobyVAL.expr = &ast.SpreadExpr{DobyDollar[1].tok.Pos, DobyDollar[2].expr}
+		}
 	case 86:
-		//line grammar.y:218
-		{ DobyVAL.stmt = &ast.AssignStmt{DobyS[Dobypt-2].expr_list, DobyS[Dobypt-1].tok.Pos, token.SHR_ASSIGN, DobyS[Dobypt-0].expr_list} }
-	case 87:
-		//line grammar.y:219
-		{ DobyVAL.stmt = &ast.AssignStmt{DobyS[Dobypt-2].expr_list, DobyS[Dobypt-1].tok.Pos, token.AND_NOT_ASSIGN, DobyS[Dobypt-0].expr_list} }
-	case 88:
-		//line grammar.y:222
-		{ DobyVAL.stmt = &ast.GoStmt{DobyS[Dobypt-1].tok.Pos, DobyS[Dobypt-0].expr.(*ast.CallExpr)} }
+		DobyDollar = DobyS[Dobypt-1 : Dobypt+1]
+//line grammar.y:250
+		{
+			DobyVAL.expr = &ast.Ident{DobyDollar[1].tok.Pos, DobyDollar[1].tok.Lit}
+		}
 	case 89:
-		//line grammar.y:225
-		{ DobyVAL.stmt = &ast.ReturnStmt{DobyS[Dobypt-1].tok.Pos, DobyS[Dobypt-0].expr_list} }
+		DobyDollar = DobyS[Dobypt-0 : Dobypt+1]
+//line grammar.y:255
+		{
+			DobyVAL.expr_list = []ast.Expr{}
+		}
 	case 90:
-		//line grammar.y:227
-		{ DobyVAL.stmt = &ast.BranchStmt{DobyS[Dobypt-0].tok.Pos, token.BREAK} }
+		DobyDollar = DobyS[Dobypt-1 : Dobypt+1]
+//line grammar.y:257
+		{
+			DobyVAL.expr_list = []ast.Expr{DobyDollar[1].expr}
+		}
 	case 91:
-		//line grammar.y:228
-		{ DobyVAL.stmt = &ast.BranchStmt{DobyS[Dobypt-0].tok.Pos, token.CONTINUE } }
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:259
+		{
+			DobyVAL.expr_list = append(DobyDollar[1].expr_list, DobyDollar[3].expr)
+		}
 	case 92:
-		//line grammar.y:230
-		{ DobyVAL.stmt = &ast.BlockStmt{DobyS[Dobypt-2].tok.Pos, DobyS[Dobypt-1].stmt_list ,DobyS[Dobypt-0].tok.Pos} }
+		DobyDollar = DobyS[Dobypt-5 : Dobypt+1]
+//line grammar.y:262
+		{
+			DobyVAL.expr = &ast.FuncDeclExpr{DobyDollar[1].tok.Pos, nil, nil, nil, DobyDollar[3].expr_list, DobyDollar[5].stmt.(*ast.BlockStmt), []string{}}
+		}
 	case 93:
-		//line grammar.y:232
-		{ DobyVAL.stmt = &ast.IfStmt{DobyS[Dobypt-2].tok.Pos, DobyS[Dobypt-1].expr, DobyS[Dobypt-0].stmt.(*ast.BlockStmt), nil} }
+		DobyDollar = DobyS[Dobypt-6 : Dobypt+1]
+//line grammar.y:264
+		{
+			DobyVAL.expr = &ast.FuncDeclExpr{DobyDollar[1].tok.Pos, nil, nil, &ast.Ident{DobyDollar[2].tok.Pos, DobyDollar[2].tok.Lit}, DobyDollar[4].expr_list, DobyDollar[6].stmt.(*ast.BlockStmt), []string{}}
+		}
 	case 94:
-		//line grammar.y:233
-		{ DobyVAL.stmt = &ast.IfStmt{DobyS[Dobypt-4].tok.Pos, DobyS[Dobypt-3].expr, DobyS[Dobypt-2].stmt.(*ast.BlockStmt), DobyS[Dobypt-0].stmt} }
-	case 95:
-		//line grammar.y:235
-		{ DobyVAL.stmt = &ast.CaseClause{DobyS[Dobypt-3].tok.Pos, DobyS[Dobypt-2].expr_list, DobyS[Dobypt-1].tok.Pos, DobyS[Dobypt-0].stmt_list} }
-	case 96:
-		//line grammar.y:236
-		{ DobyVAL.stmt = &ast.CaseClause{DobyS[Dobypt-2].tok.Pos, nil, DobyS[Dobypt-1].tok.Pos, DobyS[Dobypt-0].stmt_list} }
-	case 97:
-		//line grammar.y:238
-		{ DobyVAL.stmt_list = []ast.Stmt{} }
-	case 98:
-		//line grammar.y:239
-		{ DobyVAL.stmt_list = []ast.Stmt{DobyS[Dobypt-0].stmt} }
-	case 99:
-		//line grammar.y:240
-		{ DobyVAL.stmt_list = append(DobyS[Dobypt-1].stmt_list, DobyS[Dobypt-0].stmt) }
-	case 100:
-		//line grammar.y:242
-		{ DobyVAL.stmt = &ast.BlockStmt{DobyS[Dobypt-2].tok.Pos, DobyS[Dobypt-1].stmt_list, DobyS[Dobypt-0].tok.Pos} }
-	case 101:
-		//line grammar.y:244
-		{ DobyVAL.stmt = &ast.SwitchStmt{DobyS[Dobypt-2].tok.Pos, DobyS[Dobypt-1].stmt, DobyS[Dobypt-0].stmt.(*ast.BlockStmt)} }
-	case 102:
-		//line grammar.y:246
-		{ DobyVAL.stmt = &ast.SelectStmt{DobyS[Dobypt-1].tok.Pos, DobyS[Dobypt-0].stmt.(*ast.BlockStmt)} }
-	case 103:
-		//line grammar.y:249
-		{ DobyVAL.stmt = &ast.ForStmt{DobyS[Dobypt-6].tok.Pos, DobyS[Dobypt-5].stmt, DobyS[Dobypt-3].expr, DobyS[Dobypt-1].stmt, DobyS[Dobypt-0].stmt.(*ast.BlockStmt)} }
-	case 104:
-		//line grammar.y:251
-		{ DobyVAL.stmt = &ast.ForStmt{DobyS[Dobypt-5].tok.Pos, nil, DobyS[Dobypt-3].expr, DobyS[Dobypt-1].stmt, DobyS[Dobypt-0].stmt.(*ast.BlockStmt)} }
-	case 105:
-		//line grammar.y:253
-		{ DobyVAL.stmt = &ast.ForStmt{DobyS[Dobypt-2].tok.Pos, nil, DobyS[Dobypt-1].expr, nil, DobyS[Dobypt-0].stmt.(*ast.BlockStmt)} }
-	case 106:
-		//line grammar.y:256
-		{ DobyVAL.stmt = &ast.RangeStmt{DobyS[Dobypt-5].tok.Pos, DobyS[Dobypt-4].expr_list, DobyS[Dobypt-1].expr, DobyS[Dobypt-0].stmt.(*ast.BlockStmt)} }
-	case 107:
-		//line grammar.y:259
-		{ DobyVAL.stmt = &ast.ImportStmt{DobyS[Dobypt-1].tok.Pos, []string{DobyS[Dobypt-0].tok.Lit}} }
-	case 108:
-		DobyVAL.stmt = DobyS[Dobypt-0].stmt
-	case 109:
-		DobyVAL.stmt = DobyS[Dobypt-0].stmt
-	case 110:
-		DobyVAL.stmt = DobyS[Dobypt-0].stmt
-	case 111:
-		DobyVAL.stmt = DobyS[Dobypt-0].stmt
-	case 112:
-		DobyVAL.stmt = DobyS[Dobypt-0].stmt
-	case 113:
-		DobyVAL.stmt = DobyS[Dobypt-0].stmt
-	case 114:
-		DobyVAL.stmt = DobyS[Dobypt-0].stmt
-	case 115:
-		DobyVAL.stmt = DobyS[Dobypt-0].stmt
-	case 116:
-		DobyVAL.stmt = DobyS[Dobypt-0].stmt
-	case 117:
-		DobyVAL.stmt = DobyS[Dobypt-0].stmt
-	case 118:
-		DobyVAL.stmt = DobyS[Dobypt-0].stmt
+		DobyDollar = DobyS[Dobypt-10 : Dobypt+1]
+//line grammar.y:266
+		{
+			DobyVAL.expr = &ast.FuncDeclExpr{DobyDollar[1].tok.Pos, &ast.Ident{DobyDollar[3].tok.Pos, DobyDollar[3].tok.Lit}, &ast.Ident{DobyDollar[4].tok.Pos, DobyDollar[4].tok.Lit},
+				&ast.Ident{DobyDollar[6].tok.Pos, DobyDollar[6].tok.Lit}, DobyDollar[8].expr_list, DobyDollar[10].stmt.(*ast.BlockStmt), []string{}}
+		}
 	case 119:
-		DobyVAL.stmt = DobyS[Dobypt-0].stmt
+		DobyDollar = DobyS[Dobypt-1 : Dobypt+1]
+//line grammar.y:296
+		{
+			DobyVAL.stmt = &ast.ExprStmt{DobyDollar[1].expr}
+		}
 	case 120:
-		DobyVAL.stmt = DobyS[Dobypt-0].stmt
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:298
+		{
+			DobyVAL.stmt = &ast.SendStmt{DobyDollar[1].expr, DobyDollar[2].tok.Pos, DobyDollar[3].expr}
+		}
 	case 121:
-		DobyVAL.stmt = DobyS[Dobypt-0].stmt
+		DobyDollar = DobyS[Dobypt-2 : Dobypt+1]
+//line grammar.y:300
+		{
+			DobyVAL.stmt = &ast.IncDecStmt{DobyDollar[1].expr, DobyDollar[2].tok.Pos, token.INC}
+		}
 	case 122:
-		//line grammar.y:276
-		{ DobyVAL.stmt_list = []ast.Stmt{} }
+		DobyDollar = DobyS[Dobypt-2 : Dobypt+1]
+//line grammar.y:301
+		{
+			DobyVAL.stmt = &ast.IncDecStmt{DobyDollar[1].expr, DobyDollar[2].tok.Pos, token.DEC}
+		}
 	case 123:
-		//line grammar.y:277
-		{ DobyVAL.stmt_list = []ast.Stmt{DobyS[Dobypt-0].stmt} }
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:303
+		{
+			DobyVAL.stmt = &ast.AssignStmt{DobyDollar[1].expr_list, DobyDollar[2].tok.Pos, token.ASSIGN, DobyDollar[3].expr_list}
+		}
 	case 124:
-		//line grammar.y:278
-		{ DobyVAL.stmt_list = append(DobyS[Dobypt-2].stmt_list, DobyS[Dobypt-0].stmt) }
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:304
+		{
+			DobyVAL.stmt = &ast.AssignStmt{DobyDollar[1].expr_list, DobyDollar[2].tok.Pos, token.ADD_ASSIGN, DobyDollar[3].expr_list}
+		}
 	case 125:
-		//line grammar.y:279
-		{ DobyVAL.stmt_list = append(DobyS[Dobypt-2].stmt_list, DobyS[Dobypt-0].stmt) }
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:305
+		{
+			DobyVAL.stmt = &ast.AssignStmt{DobyDollar[1].expr_list, DobyDollar[2].tok.Pos, token.SUB_ASSIGN, DobyDollar[3].expr_list}
+		}
 	case 126:
-		//line grammar.y:280
-		{ DobyVAL.stmt_list = DobyS[Dobypt-1].stmt_list }
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:306
+		{
+			DobyVAL.stmt = &ast.AssignStmt{DobyDollar[1].expr_list, DobyDollar[2].tok.Pos, token.MUL_ASSIGN, DobyDollar[3].expr_list}
+		}
 	case 127:
-		//line grammar.y:285
-		{ ProgramAst = DobyS[Dobypt-1].stmt_list }
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:307
+		{
+			DobyVAL.stmt = &ast.AssignStmt{DobyDollar[1].expr_list, DobyDollar[2].tok.Pos, token.QUO_ASSIGN, DobyDollar[3].expr_list}
+		}
+	case 128:
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:308
+		{
+			DobyVAL.stmt = &ast.AssignStmt{DobyDollar[1].expr_list, DobyDollar[2].tok.Pos, token.REM_ASSIGN, DobyDollar[3].expr_list}
+		}
+	case 129:
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:309
+		{
+			DobyVAL.stmt = &ast.AssignStmt{DobyDollar[1].expr_list, DobyDollar[2].tok.Pos, token.POW_ASSIGN, DobyDollar[3].expr_list}
+		}
+	case 130:
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:310
+		{
+			DobyVAL.stmt = &ast.AssignStmt{DobyDollar[1].expr_list, DobyDollar[2].tok.Pos, token.AND_ASSIGN, DobyDollar[3].expr_list}
+		}
+	case 131:
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:311
+		{
+			DobyVAL.stmt = &ast.AssignStmt{DobyDollar[1].expr_list, DobyDollar[2].tok.Pos, token.OR_ASSIGN, DobyDollar[3].expr_list}
+		}
+	case 132:
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:312
+		{
+			DobyVAL.stmt = &ast.AssignStmt{DobyDollar[1].expr_list, DobyDollar[2].tok.Pos, token.XOR_ASSIGN, DobyDollar[3].expr_list}
+		}
+	case 133:
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:313
+		{
+			DobyVAL.stmt = &ast.AssignStmt{DobyDollar[1].expr_list, DobyDollar[2].tok.Pos, token.SHL_ASSIGN, DobyDollar[3].expr_list}
+		}
+	case 134:
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:314
+		{
+			DobyVAL.stmt = &ast.AssignStmt{DobyDollar[1].expr_list, DobyDollar[2].tok.Pos, token.SHR_ASSIGN, DobyDollar[3].expr_list}
+		}
+	case 135:
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:315
+		{
+			DobyVAL.stmt = &ast.AssignStmt{DobyDollar[1].expr_list, DobyDollar[2].tok.Pos, token.AND_NOT_ASSIGN, DobyDollar[3].expr_list}
+		}
+	case 136:
+		DobyDollar = DobyS[Dobypt-2 : Dobypt+1]
+//line grammar.y:318
+		{
+			DobyVAL.stmt = &ast.GoStmt{DobyDollar[1].tok.Pos, DobyDollar[2].expr.(*ast.CallExpr)}
+		}
+	case 137:
+		DobyDollar = DobyS[Dobypt-2 : Dobypt+1]
+//line grammar.y:321
+		{
+			DobyVAL.stmt = &ast.ReturnStmt{DobyDollar[1].tok.Pos, DobyDollar[2].expr_list}
+		}
+	case 138:
+		DobyDollar = DobyS[Dobypt-2 : Dobypt+1]
+//line grammar.y:324
+		{
+			DobyVAL.stmt = &ast.YieldStmt{DobyDollar[1].tok.Pos, DobyDollar[2].expr}
+		}
+	case 139:
+		DobyDollar = DobyS[Dobypt-1 : Dobypt+1]
+//line grammar.y:326
+		{
+			DobyVAL.stmt = &ast.BranchStmt{DobyDollar[1].tok.Pos, token.BREAK}
+		}
+	case 140:
+		DobyDollar = DobyS[Dobypt-1 : Dobypt+1]
+//line grammar.y:327
+		{
+			DobyVAL.stmt = &ast.BranchStmt{DobyDollar[1].tok.Pos, token.CONTINUE}
+		}
+	case 141:
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:329
+		{
+			DobyVAL.stmt = &ast.BlockStmt{DobyDollar[1].tok.Pos, DobyDollar[2].stmt_list, DobyDollar[3].tok.Pos}
+		}
+	case 142:
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:331
+		{
+			DobyVAL.stmt = &ast.IfStmt{DobyDollar[1].tok.Pos, DobyDollar[2].expr, DobyDollar[3].stmt.(*ast.BlockStmt), nil}
+		}
+	case 143:
+		DobyDollar = DobyS[Dobypt-5 : Dobypt+1]
+//line grammar.y:332
+		{
+			DobyVAL.stmt = &ast.IfStmt{DobyDollar[1].tok.Pos, DobyDollar[2].expr, DobyDollar[3].stmt.(*ast.BlockStmt), DobyDollar[5].stmt}
+		}
+	case 144:
+		DobyDollar = DobyS[Dobypt-4 : Dobypt+1]
+//line grammar.y:334
+		{
+			DobyVAL.stmt = &ast.CaseClause{DobyDollar[1].tok.Pos, DobyDollar[2].expr_list, nil, DobyDollar[3].tok.Pos, DobyDollar[4].stmt_list}
+		}
+	case 145:
+		DobyDollar = DobyS[Dobypt-6 : Dobypt+1]
+//line grammar.y:336
+		{
+			DobyVAL.stmt = &ast.CaseClause{DobyDollar[1].tok.Pos, DobyDollar[2].expr_list, DobyDollar[4].expr, DobyDollar[5].tok.Pos, DobyDollar[6].stmt_list}
+		}
+	case 146:
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:337
+		{
+			DobyVAL.stmt = &ast.CaseClause{DobyDollar[1].tok.Pos, nil, nil, DobyDollar[2].tok.Pos, DobyDollar[3].stmt_list}
+		}
+	case 147:
+		DobyDollar = DobyS[Dobypt-1 : Dobypt+1]
+//line grammar.y:339
+		{
+			DobyVAL.stmt_list = []ast.Stmt{}
+		}
+	case 148:
+		DobyDollar = DobyS[Dobypt-1 : Dobypt+1]
+//line grammar.y:340
+		{
+			DobyVAL.stmt_list = []ast.Stmt{DobyDollar[1].stmt}
+		}
+	case 149:
+		DobyDollar = DobyS[Dobypt-2 : Dobypt+1]
+//line grammar.y:341
+		{
+			DobyVAL.stmt_list = append(DobyDollar[1].stmt_list, DobyDollar[2].stmt)
+		}
+	case 150:
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:343
+		{
+			DobyVAL.stmt = &ast.BlockStmt{DobyDollar[1].tok.Pos, DobyDollar[2].stmt_list, DobyDollar[3].tok.Pos}
+		}
+	case 151:
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:345
+		{
+			DobyVAL.stmt = &ast.SwitchStmt{DobyDollar[1].tok.Pos, DobyDollar[2].stmt, DobyDollar[3].stmt.(*ast.BlockStmt)}
+		}
+	case 152:
+		DobyDollar = DobyS[Dobypt-2 : Dobypt+1]
+//line grammar.y:347
+		{
+			DobyVAL.stmt = &ast.SelectStmt{DobyDollar[1].tok.Pos, DobyDollar[2].stmt.(*ast.BlockStmt)}
+		}
+	case 153:
+		DobyDollar = DobyS[Dobypt-7 : Dobypt+1]
+//line grammar.y:350
+		{
+			DobyVAL.stmt = &ast.ForStmt{DobyDollar[1].tok.Pos, DobyDollar[2].stmt, DobyDollar[4].expr, DobyDollar[6].stmt, DobyDollar[7].stmt.(*ast.BlockStmt)}
+		}
+	case 154:
+		DobyDollar = DobyS[Dobypt-6 : Dobypt+1]
+//line grammar.y:352
+		{
+			DobyVAL.stmt = &ast.ForStmt{DobyDollar[1].tok.Pos, nil, DobyDollar[3].expr, DobyDollar[5].stmt, DobyDollar[6].stmt.(*ast.BlockStmt)}
+		}
+	case 155:
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:354
+		{
+			DobyVAL.stmt = &ast.ForStmt{DobyDollar[1].tok.Pos, nil, DobyDollar[2].expr, nil, DobyDollar[3].stmt.(*ast.BlockStmt)}
+		}
+	case 156:
+		DobyDollar = DobyS[Dobypt-6 : Dobypt+1]
+//line grammar.y:357
+		{
+			DobyVAL.stmt = &ast.RangeStmt{DobyDollar[1].tok.Pos, DobyDollar[2].expr_list, DobyDollar[5].expr, DobyDollar[6].stmt.(*ast.BlockStmt)}
+		}
+	case 157:
+		DobyDollar = DobyS[Dobypt-2 : Dobypt+1]
+//line grammar.y:360
+		{
+			DobyVAL.stmt = &ast.ImportStmt{DobyDollar[1].tok.Pos, []string{DobyDollar[2].tok.Lit}}
+		}
+	case 173:
+		DobyDollar = DobyS[Dobypt-0 : Dobypt+1]
+//line grammar.y:378
+		{
+			DobyVAL.stmt_list = []ast.Stmt{}
+		}
+	case 174:
+		DobyDollar = DobyS[Dobypt-1 : Dobypt+1]
+//line grammar.y:379
+		{
+			DobyVAL.stmt_list = []ast.Stmt{DobyDollar[1].stmt}
+		}
+	case 175:
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:380
+		{
+			DobyVAL.stmt_list = append(DobyDollar[1].stmt_list, DobyDollar[3].stmt)
+		}
+	case 176:
+		DobyDollar = DobyS[Dobypt-3 : Dobypt+1]
+//line grammar.y:381
+		{
+			DobyVAL.stmt_list = append(DobyDollar[1].stmt_list, DobyDollar[3].stmt)
+		}
+	case 177:
+		DobyDollar = DobyS[Dobypt-2 : Dobypt+1]
+//line grammar.y:382
+		{
+			DobyVAL.stmt_list = DobyDollar[1].stmt_list
+		}
+	case 178:
+		DobyDollar = DobyS[Dobypt-2 : Dobypt+1]
+//line grammar.y:387
+		{
+			ProgramAst = DobyDollar[1].stmt_list
+		}
 	}
 	goto Dobystack /* stack new state and value */
 }
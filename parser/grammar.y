@@ -32,56 +32,68 @@ func (t Tok) String() string {
     stmt_list []ast.Stmt
     field *ast.Field
     field_list []*ast.Field
-    ident_list []*ast.Ident
+    clause *ast.CompClause
+    clause_list []*ast.CompClause
     tok Tok
 }
 
 %type <expr> expr ident basiclit
-%type <expr> paren_expr selector_expr index_expr slice_expr func_decl_expr
-%type <expr> call_expr unary_expr binary_expr array_expr dict_expr set_expr
-%type <expr_list> expr_list
+%type <expr> paren_expr selector_expr index_expr slice_expr slice_bound func_decl_expr
+%type <expr> call_expr unary_expr binary_expr array_expr dict_expr set_expr tuple_expr interp_expr
+%type <expr> spread_expr param composite_expr
+%type <expr_list> expr_list interp_parts param_list elem_list
 %type <field> field_pair
 %type <field_list> field_list
-%type <ident_list> ident_list
+%type <expr> array_comp_expr dict_comp_expr
+%type <expr> if_expr cond_expr coalesce_expr match_expr range_expr
+%type <stmt> else_expr
+%type <clause> comp_clause
+%type <clause_list> comp_clause_list
 
 %type <stmt> stmt expr_stmt send_stmt incdec_stmt assign_stmt go_stmt
-%type <stmt> return_stmt branch_stmt block_stmt if_stmt 
+%type <stmt> return_stmt yield_stmt branch_stmt block_stmt if_stmt 
 %type <stmt> case_clause case_block switch_stmt select_stmt for_stmt range_stmt import_stmt
 %type <stmt_list> stmt_list case_clause_list prog 
 
 %token <tok> EOF EOL COMMENT
-%token <tok> IDENT INT FLOAT STRING CHAR 
+%token <tok> IDENT INT FLOAT IMAG STRING CHAR SYMBOL REGEXP STRING_HEAD STRING_MID STRING_TAIL
 %token <tok> SHL SHR AND_NOT 
-%token <tok> ADD_ASSIGN SUB_ASSIGN MUL_ASSIGN QUO_ASSIGN REM_ASSIGN
+%token <tok> ADD_ASSIGN SUB_ASSIGN MUL_ASSIGN QUO_ASSIGN REM_ASSIGN POW_ASSIGN
 %token <tok> AND_ASSIGN OR_ASSIGN XOR_ASSIGN SHL_ASSIGN SHR_ASSIGN AND_NOT_ASSIGN
 %token <tok> LAND LOR ARROW INC DEC EQL
-%token <tok> NEQ LEQ GEQ DEFINE ELLIPSIS ADD SUB MUL QUO REM AND OR XOR
-%token <tok> LSS GTR ASSIGN NOT 
+%token <tok> NEQ LEQ GEQ DEFINE ELLIPSIS ADD SUB MUL QUO REM POW AND OR XOR
+%token <tok> LSS GTR ASSIGN NOT QUESTION QUES_QUES QUES_PERIOD DOTDOT
 %token <tok> LPAREN LBRACK LBRACE COMMA PERIOD RPAREN RBRACK RBRACE
-%token <tok> SEMICOLON COLON
+%token <tok> SEMICOLON COLON MATCH_OP
 
 %token <tok> BREAK CASE CHAN CONTINUE CONST
 %token <tok> DEFAULT DEFER ELSE FALLTHROUGH FOR
-%token <tok> FUNC GO GOTO IF IMPORT INTERFACE MAP PACKAGE RANGE RETURN 
-%token <tok> SELECT STRUCT SWITCH TYPE VAR 
+%token <tok> FUNC GO GOTO IF IMPORT IN INTERFACE MAP PACKAGE RANGE RETURN 
+%token <tok> SELECT STRUCT SWITCH TYPE VAR YIELD MATCH
+%token <tok> IF_EXPR /* if where an operand is expected, see Lexer.Lex */
+%token <tok> LIT_LBRACE /* { right after a name, starts a composite literal */
 
+%right QUESTION
+%left QUES_QUES
 %left LOR ARROW
 %left LAND 
 %left NOT 
 %left SHL SHR AND_NOT 
 %left LSS GTR
-%left NEQ LEQ GEQ EQL
+%left NEQ LEQ GEQ EQL MATCH_OP
+%left DOTDOT
 %left OR
 %left AND XOR
 %left ADD SUB
 %left MUL QUO REM
 %left INC DEC
 %left UMINUS
+%right POW
 %left LPAREN
 %left LBRACK
-%left PERIOD
+%left PERIOD QUES_PERIOD LIT_LBRACE
 
-%right ASSIGN ADD_ASSIGN SUB_ASSIGN MUL_ASSIGN QUO_ASSIGN REM_ASSIGN AND_ASSIGN OR_ASSIGN XOR_ASSIGN SHL_ASSIGN SHR_ASSIGN AND_NOT_ASSIGN DEFINE
+%right ASSIGN ADD_ASSIGN SUB_ASSIGN MUL_ASSIGN QUO_ASSIGN REM_ASSIGN POW_ASSIGN AND_ASSIGN OR_ASSIGN XOR_ASSIGN SHL_ASSIGN SHR_ASSIGN AND_NOT_ASSIGN DEFINE
 
 %start prog
 
@@ -91,19 +103,32 @@ ident : IDENT				{ $$ = &ast.Ident{$1.Pos, $1.Lit} }
 
 basiclit : INT				{ $$ = &ast.BasicLit{$1.Pos, token.INT, $1.Lit} }
 	 | FLOAT			{ $$ = &ast.BasicLit{$1.Pos, token.FLOAT, $1.Lit} }
+	 | IMAG				{ $$ = &ast.BasicLit{$1.Pos, token.IMAG, $1.Lit} }
 	 | STRING 			{ $$ = &ast.BasicLit{$1.Pos, token.STRING, $1.Lit} }
 	 | CHAR				{ $$ = &ast.BasicLit{$1.Pos, token.CHAR, $1.Lit} }
+	 | SYMBOL			{ $$ = &ast.BasicLit{$1.Pos, token.SYMBOL, $1.Lit} }
+	 | REGEXP			{ $$ = &ast.BasicLit{$1.Pos, token.REGEXP, $1.Lit} }
+
+interp_parts : STRING_HEAD expr
+	       { $$ = []ast.Expr{&ast.BasicLit{$1.Pos, token.STRING, $1.Lit}, $2} }
+	     | interp_parts STRING_MID expr
+	       { $$ = append($1, &ast.BasicLit{$2.Pos, token.STRING, $2.Lit}, $3) }
+
+interp_expr : interp_parts STRING_TAIL
+	      { $$ = &ast.InterpExpr{$1[0].(*ast.BasicLit).ValuePos, append($1, &ast.BasicLit{$2.Pos, token.STRING, $2.Lit})} }
 
 paren_expr : LPAREN expr RPAREN		{ $$ = &ast.ParenExpr{$1.Pos, $2, $3.Pos} }
 
-selector_expr : expr PERIOD ident      	{ $$ = &ast.SelectorExpr{$1, $3.(*ast.Ident)} }
+selector_expr : expr PERIOD ident      	{ $$ = &ast.SelectorExpr{$1, $3.(*ast.Ident), false} }
+	      | expr QUES_PERIOD ident		{ $$ = &ast.SelectorExpr{$1, $3.(*ast.Ident), true} }
+
+slice_bound : /* empty */		{ $$ = nil }
+	    | expr			{ $$ = $1 }
 
-slice_expr : expr LBRACK expr COLON expr RBRACK	
-	     { $$ = &ast.SliceExpr{$1, $2.Pos, $3, $5, $6.Pos} }
-           | expr LBRACK COLON expr RBRACK	
-	     { $$ = &ast.SliceExpr{$1, $2.Pos, nil, $4, $5.Pos} }
-           | expr LBRACK expr COLON RBRACK	
-	     { $$ = &ast.SliceExpr{$1, $2.Pos, $3, nil, $5.Pos} }
+slice_expr : expr LBRACK slice_bound COLON slice_bound RBRACK
+	     { $$ = &ast.SliceExpr{$1, $2.Pos, $3, $5, nil, $6.Pos} }
+           | expr LBRACK slice_bound COLON slice_bound COLON slice_bound RBRACK
+	     { $$ = &ast.SliceExpr{$1, $2.Pos, $3, $5, $7, $8.Pos} }
 
 index_expr : expr LBRACK expr RBRACK    
 	     { $$ = &ast.IndexExpr{$1, $2.Pos, $3, $2.Pos} }
@@ -123,6 +148,7 @@ binary_expr : expr ADD expr 		  { $$ = &ast.BinaryExpr{$1, $2.Pos, token.ADD, $3
             | expr MUL expr		  { $$ = &ast.BinaryExpr{$1, $2.Pos, token.MUL, $3 } }
             | expr QUO expr		  { $$ = &ast.BinaryExpr{$1, $2.Pos, token.QUO, $3 } }
             | expr REM expr		  { $$ = &ast.BinaryExpr{$1, $2.Pos, token.REM, $3 } }
+            | expr POW expr		  { $$ = &ast.BinaryExpr{$1, $2.Pos, token.POW, $3 } }
             | expr AND expr		  { $$ = &ast.BinaryExpr{$1, $2.Pos, token.AND, $3 } }
             | expr OR expr		  { $$ = &ast.BinaryExpr{$1, $2.Pos, token.OR, $3 } }
             | expr XOR expr		  { $$ = &ast.BinaryExpr{$1, $2.Pos, token.XOR, $3 } }
@@ -135,6 +161,7 @@ binary_expr : expr ADD expr 		  { $$ = &ast.BinaryExpr{$1, $2.Pos, token.ADD, $3
             | expr LEQ expr		  { $$ = &ast.BinaryExpr{$1, $2.Pos, token.LEQ, $3 } }
             | expr GEQ expr		  { $$ = &ast.BinaryExpr{$1, $2.Pos, token.GEQ, $3 } }
             | expr EQL expr		  { $$ = &ast.BinaryExpr{$1, $2.Pos, token.EQL, $3 } }
+            | expr MATCH_OP expr	  { $$ = &ast.BinaryExpr{$1, $2.Pos, token.MATCH_OP, $3 } }
 
             | expr LAND expr		  { $$ = &ast.BinaryExpr{$1, $2.Pos, token.LAND, $3 } }
             | expr LOR expr		  { $$ = &ast.BinaryExpr{$1, $2.Pos, token.LOR, $3 } }
@@ -153,6 +180,13 @@ set_expr : '#' LBRACK expr_list RBRACK
 	 | '#' LBRACK EOL expr_list RBRACK
 	   { $$ = &ast.SetExpr{$2.Pos, $4, $5.Pos} }
 
+tuple_expr : '#' LPAREN expr_list RPAREN
+	     { $$ = &ast.TupleExpr{$2.Pos, $3, $4.Pos} }
+	   | '#' LPAREN EOL expr_list EOL RPAREN
+	     { $$ = &ast.TupleExpr{$2.Pos, $4, $6.Pos} }
+	   | '#' LPAREN EOL expr_list RPAREN
+	     { $$ = &ast.TupleExpr{$2.Pos, $4, $5.Pos} }
+
 field_pair : expr COLON expr
 	     { $$ = &ast.Field{$1, $2.Pos, $3} }
 
@@ -167,23 +201,74 @@ field_list : /* empty */			    { $$ = []*ast.Field{} }
 dict_expr : '#' LBRACE field_list RBRACE
 	    { $$ = &ast.DictExpr{$2.Pos, $3, $4.Pos} }
 
-ident_list : /* empty */
-   	     { $$ = []*ast.Ident{} }
-	   | IDENT
-	     { $$ = []*ast.Ident{&ast.Ident{$1.Pos, $1.Lit}} }
-	   | ident_list COMMA IDENT
-	     { $$ = append($1, &ast.Ident{$3.Pos, $3.Lit}) }
+elem_list : expr			{ $$ = []ast.Expr{$1} }
+	  | elem_list COMMA expr	{ $$ = append($1, $3) }
+	  | elem_list COMMA EOL expr	{ $$ = append($1, $4) }
+
+composite_expr : expr LIT_LBRACE field_list RBRACE
+		 { $$ = &ast.CompositeLit{$1, $2.Pos, $3, nil, $4.Pos} }
+	       | expr LIT_LBRACE elem_list RBRACE
+		 { $$ = &ast.CompositeLit{$1, $2.Pos, nil, $3, $4.Pos} }
+
+comp_clause : FOR expr_list IN expr
+	      { $$ = &ast.CompClause{$1.Pos, $2, $4, nil} }
+	    | FOR expr_list IN expr IF expr
+	      { $$ = &ast.CompClause{$1.Pos, $2, $4, $6} }
+
+comp_clause_list : comp_clause				{ $$ = []*ast.CompClause{$1} }
+		 | comp_clause_list comp_clause		{ $$ = append($1, $2) }
+
+array_comp_expr : LBRACK expr comp_clause_list RBRACK
+		  { $$ = &ast.ArrayCompExpr{$1.Pos, $2, $3, $4.Pos} }
+
+dict_comp_expr : '#' LBRACE field_pair comp_clause_list RBRACE
+		 { $$ = &ast.DictCompExpr{$2.Pos, $3, $4, $5.Pos} }
+
+else_expr : /* empty */			{ $$ = nil }
+	  | ELSE block_stmt		{ $$ = $2 }
+	  | ELSE IF expr block_stmt else_expr
+	    { $$ = &ast.BlockStmt{$2.Pos, []ast.Stmt{&ast.ExprStmt{&ast.IfExpr{$2.Pos, $3, $4.(*ast.BlockStmt), $5}}}, $2.Pos} }
 
-func_decl_expr : FUNC LPAREN ident_list RPAREN block_stmt
+if_expr : IF_EXPR expr block_stmt else_expr
+	  { $$ = &ast.IfExpr{$1.Pos, $2, $3.(*ast.BlockStmt), $4} }
+
+cond_expr : expr QUESTION expr COLON expr %prec QUESTION
+	    { $$ = &ast.CondExpr{$1, $2.Pos, $3, $4.Pos, $5} }
+
+range_expr : expr DOTDOT expr
+	     { $$ = &ast.RangeExpr{$1, $2.Pos, $3} }
+
+match_expr : MATCH expr case_block
+	     { $$ = &ast.MatchExpr{$1.Pos, $2, $3.(*ast.BlockStmt)} }
+
+coalesce_expr : expr QUES_QUES expr
+		{ $$ = &ast.CoalesceExpr{$1, $2.Pos, $3} }
+
+spread_expr : ELLIPSIS expr %prec UMINUS
+	      { $$ = &ast.SpreadExpr{$1.Pos, $2} }
+
+param : IDENT	     	{ $$ = &ast.Ident{$1.Pos, $1.Lit} }
+      | array_expr
+      | dict_expr
+
+param_list : /* empty */
+   	     { $$ = []ast.Expr{} }
+	   | param
+	     { $$ = []ast.Expr{$1} }
+	   | param_list COMMA param
+	     { $$ = append($1, $3) }
+
+func_decl_expr : FUNC LPAREN param_list RPAREN block_stmt
                  { $$ = &ast.FuncDeclExpr{$1.Pos, nil, nil, nil, $3, $5.(*ast.BlockStmt), []string{}} }
-	       | FUNC IDENT LPAREN ident_list RPAREN block_stmt
+	       | FUNC IDENT LPAREN param_list RPAREN block_stmt
                  { $$ = &ast.FuncDeclExpr{$1.Pos, nil, nil, &ast.Ident{$2.Pos, $2.Lit}, $4, $6.(*ast.BlockStmt), []string{}} }
-	       | FUNC LPAREN IDENT IDENT RPAREN IDENT LPAREN ident_list RPAREN block_stmt
+	       | FUNC LPAREN IDENT IDENT RPAREN IDENT LPAREN param_list RPAREN block_stmt
 	       	 { $$ = &ast.FuncDeclExpr{$1.Pos, &ast.Ident{$3.Pos, $3.Lit}, &ast.Ident{$4.Pos, $4.Lit},
                                           &ast.Ident{$6.Pos, $6.Lit}, $8, $10.(*ast.BlockStmt), []string{}} }
 
 expr : ident
      | basiclit
+     | interp_expr
      | paren_expr
      | selector_expr
      | index_expr
@@ -192,8 +277,18 @@ expr : ident
      | unary_expr
      | binary_expr
      | array_expr
+     | array_comp_expr
+     | if_expr
+     | cond_expr
+     | coalesce_expr
+     | range_expr
+     | match_expr
+     | dict_comp_expr
+     | spread_expr
      | dict_expr
+     | composite_expr
      | set_expr
+     | tuple_expr
      | func_decl_expr
 
 /// stmts
@@ -211,6 +306,7 @@ assign_stmt : expr_list ASSIGN expr_list       		{ $$ = &ast.AssignStmt{$1, $2.P
 	    | expr_list MUL_ASSIGN expr_list		{ $$ = &ast.AssignStmt{$1, $2.Pos, token.MUL_ASSIGN, $3} }
 	    | expr_list QUO_ASSIGN expr_list		{ $$ = &ast.AssignStmt{$1, $2.Pos, token.QUO_ASSIGN, $3} }
 	    | expr_list REM_ASSIGN expr_list		{ $$ = &ast.AssignStmt{$1, $2.Pos, token.REM_ASSIGN, $3} }
+	    | expr_list POW_ASSIGN expr_list		{ $$ = &ast.AssignStmt{$1, $2.Pos, token.POW_ASSIGN, $3} }
 	    | expr_list AND_ASSIGN expr_list		{ $$ = &ast.AssignStmt{$1, $2.Pos, token.AND_ASSIGN, $3} }
 	    | expr_list OR_ASSIGN expr_list		{ $$ = &ast.AssignStmt{$1, $2.Pos, token.OR_ASSIGN, $3} }
 	    | expr_list XOR_ASSIGN expr_list		{ $$ = &ast.AssignStmt{$1, $2.Pos, token.XOR_ASSIGN, $3} }
@@ -224,6 +320,9 @@ go_stmt : GO call_expr
 return_stmt : RETURN expr_list
 	      { $$ = &ast.ReturnStmt{$1.Pos, $2} }
 
+yield_stmt : YIELD expr
+	     { $$ = &ast.YieldStmt{$1.Pos, $2} }
+
 branch_stmt : BREAK				{ $$ = &ast.BranchStmt{$1.Pos, token.BREAK} }
 	     | CONTINUE				{ $$ = &ast.BranchStmt{$1.Pos, token.CONTINUE } }
 
@@ -232,8 +331,10 @@ block_stmt : LBRACE stmt_list RBRACE		{ $$ = &ast.BlockStmt{$1.Pos, $2 ,$3.Pos}
 if_stmt : IF expr block_stmt  			{ $$ = &ast.IfStmt{$1.Pos, $2, $3.(*ast.BlockStmt), nil} }
 	| IF expr block_stmt ELSE stmt		{ $$ = &ast.IfStmt{$1.Pos, $2, $3.(*ast.BlockStmt), $5} }
 
-case_clause : CASE expr_list COLON stmt_list	{ $$ = &ast.CaseClause{$1.Pos, $2, $3.Pos, $4} }
-            | DEFAULT COLON stmt_list           { $$ = &ast.CaseClause{$1.Pos, nil, $2.Pos, $3} }
+case_clause : CASE expr_list COLON stmt_list	{ $$ = &ast.CaseClause{$1.Pos, $2, nil, $3.Pos, $4} }
+            | CASE expr_list IF expr COLON stmt_list
+	      { $$ = &ast.CaseClause{$1.Pos, $2, $4, $5.Pos, $6} }
+            | DEFAULT COLON stmt_list           { $$ = &ast.CaseClause{$1.Pos, nil, nil, $2.Pos, $3} }
 
 case_clause_list : EOL	     	   		{ $$ = []ast.Stmt{} }
 		 | case_clause	   		{ $$ = []ast.Stmt{$1} }
@@ -264,6 +365,7 @@ stmt : expr_stmt
      | assign_stmt
      | go_stmt
      | return_stmt
+     | yield_stmt
      | branch_stmt
      | block_stmt
      | if_stmt
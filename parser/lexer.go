@@ -1,9 +1,12 @@
 package parser
 
 import (
+	"bytes"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/jxwr/doby/token"
 )
@@ -15,9 +18,30 @@ type Lexer struct {
 	Line     int
 	Col      int
 	LastTok  *DobySymType
+	lastTyp  int
+	prevTyp  int
+	spaced   bool  // blanks before the current token
+	depth    int   // open parens, brackets and braces
+	headers  []int // depths of the if, for, switch and match headers being lexed
 
 	SavedToks []*Tok
 	Lines     []string
+
+	Errors []string // the syntax errors reported
+	Quiet  bool     // keep syntax errors in Errors without printing them
+
+	// string literals waiting for the end of a #{} interpolation
+	interps []*strLit
+}
+
+// a string literal is lexed in pieces when it contains #{} interpolations,
+// the state is kept until the closing quote or heredoc terminator is reached
+type strLit struct {
+	heredoc bool
+	end     int // heredoc: start of the terminator line
+	resume  int // heredoc: end of the terminator line
+	indent  int // heredoc: indentation stripped from every line
+	depth   int // braces opened inside the current #{}
 }
 
 func NewLexer(filename, src string) *Lexer {
@@ -27,12 +51,16 @@ func NewLexer(filename, src string) *Lexer {
 }
 
 var (
-	floatRe       = regexp.MustCompile("^[0-9]+\\.[0-9]+")
-	intRe         = regexp.MustCompile("^[0-9]+")
-	stringRe      = regexp.MustCompile("^\"[^\"]*\"")
-	charRe        = regexp.MustCompile("^'.'")
+	// go number literals, "1." is not a float so that 10.Times() still works,
+	// "1.e2" is one like in go
+	numberRe = regexp.MustCompile("^(0[xX][0-9a-fA-F_]+((\\.[0-9a-fA-F_]*)?[pP][+-]?[0-9_]+)?" +
+		"|0[bBoO][0-9_]+" +
+		"|[0-9][0-9_]*\\.[eE][+-]?[0-9][0-9_]*" +
+		"|[0-9][0-9_]*(\\.[0-9][0-9_]*)?([eE][+-]?[0-9][0-9_]*)?" +
+		"|\\.[0-9][0-9_]*([eE][+-]?[0-9][0-9_]*)?)i?")
+	heredocRe     = regexp.MustCompile("^<<~([a-zA-Z_][a-zA-Z0-9_]*)[ \t\r]*\n")
+	charRe        = regexp.MustCompile(`^'(\\(x[0-9a-fA-F]{2}|u[0-9a-fA-F]{4}|U[0-9a-fA-F]{8}|[0-7]{3}|.)|[^'\\\n])'`)
 	identRe       = regexp.MustCompile("^[a-zA-Z_][a-zA-Z0-9_]*")
-	escapeRe      = regexp.MustCompile("\\\\.")
 	lineCommentRe = regexp.MustCompile("^//.*")
 
 	SpecTokens = map[int]string{
@@ -42,16 +70,23 @@ var (
 	}
 
 	AtomTokenMap = map[int]string{
-		IDENT:  "IDENT",
-		INT:    "INT",
-		FLOAT:  "FLOAT",
-		CHAR:   "CHAR",
-		STRING: "STRING",
+		IDENT:       "IDENT",
+		INT:         "INT",
+		FLOAT:       "FLOAT",
+		IMAG:        "IMAG",
+		CHAR:        "CHAR",
+		STRING:      "STRING",
+		SYMBOL:      "SYMBOL",
+		REGEXP:      "REGEXP",
+		STRING_HEAD: "STRING_HEAD",
+		STRING_MID:  "STRING_MID",
+		STRING_TAIL: "STRING_TAIL",
 	}
 
 	OpTokens = [...]int{
 		ADD_ASSIGN, // "+=",
 		SUB_ASSIGN, // "-=",
+		POW_ASSIGN, // "**=",
 		MUL_ASSIGN, // "*=",
 		QUO_ASSIGN, // "/=",
 		REM_ASSIGN, // "%=",
@@ -66,6 +101,7 @@ var (
 		SHL,     // "<<",
 		SHR,     // ">>",
 		AND_NOT, // "&^",
+		POW,     // "**",
 
 		LAND,  // "&&",
 		LOR,   // "||",
@@ -74,11 +110,16 @@ var (
 		DEC,   // "--",
 		EQL,   // "==",
 
-		NEQ,      // "!=",
-		LEQ,      // "<=",
-		GEQ,      // ">=",
-		DEFINE,   // ":=",
-		ELLIPSIS, // "...",
+		MATCH_OP, // "=~",
+
+		NEQ,         // "!=",
+		LEQ,         // "<=",
+		GEQ,         // ">=",
+		DEFINE,      // ":=",
+		ELLIPSIS,    // "...",
+		DOTDOT,      // "..",
+		QUES_QUES,   // "??",
+		QUES_PERIOD, // "?.",
 
 		ADD, // "+",
 		SUB, // "-",
@@ -90,10 +131,11 @@ var (
 		OR,  // "|",
 		XOR, // "^",
 
-		LSS,    // "<",
-		GTR,    // ">",
-		ASSIGN, // "=",
-		NOT,    // "!",
+		LSS,      // "<",
+		GTR,      // ">",
+		ASSIGN,   // "=",
+		NOT,      // "!",
+		QUESTION, // "?",
 
 		LPAREN, // "(",
 		LBRACK, // "[",
@@ -111,6 +153,7 @@ var (
 	OpTokenMap = map[int]string{
 		ADD_ASSIGN: "+=",
 		SUB_ASSIGN: "-=",
+		POW_ASSIGN: "**=",
 		MUL_ASSIGN: "*=",
 		QUO_ASSIGN: "/=",
 		REM_ASSIGN: "%=",
@@ -125,6 +168,7 @@ var (
 		SHL:     "<<",
 		SHR:     ">>",
 		AND_NOT: "&^",
+		POW:     "**",
 
 		LAND:  "&&",
 		LOR:   "||",
@@ -133,11 +177,16 @@ var (
 		DEC:   "--",
 		EQL:   "==",
 
-		NEQ:      "!=",
-		LEQ:      "<=",
-		GEQ:      ">=",
-		DEFINE:   ":=",
-		ELLIPSIS: "...",
+		MATCH_OP: "=~",
+
+		NEQ:         "!=",
+		LEQ:         "<=",
+		GEQ:         ">=",
+		DEFINE:      ":=",
+		ELLIPSIS:    "...",
+		DOTDOT:      "..",
+		QUES_QUES:   "??",
+		QUES_PERIOD: "?.",
 
 		ADD: "+",
 		SUB: "-",
@@ -149,10 +198,11 @@ var (
 		OR:  "|",
 		XOR: "^",
 
-		LSS:    "<",
-		GTR:    ">",
-		ASSIGN: "=",
-		NOT:    "!",
+		LSS:      "<",
+		GTR:      ">",
+		ASSIGN:   "=",
+		NOT:      "!",
+		QUESTION: "?",
 
 		LPAREN: "(",
 		LBRACK: "[",
@@ -185,9 +235,11 @@ var (
 		GOTO:   "goto",
 		IF:     "if",
 		IMPORT: "import",
+		IN:     "in",
 
 		INTERFACE: "interface",
 		MAP:       "map",
+		MATCH:     "match",
 		PACKAGE:   "package",
 		RANGE:     "range",
 		RETURN:    "return",
@@ -197,6 +249,7 @@ var (
 		SWITCH: "switch",
 		TYPE:   "type",
 		VAR:    "var",
+		YIELD:  "yield",
 	}
 )
 
@@ -209,7 +262,84 @@ func (l *Lexer) MkTok(lit string) Tok {
 	return t
 }
 
+// tokens after which an operand is expected, an if there starts an
+// if-expression rather than an if statement
+var operandPrefix = map[int]bool{
+	ASSIGN: true, DEFINE: true, ADD_ASSIGN: true, SUB_ASSIGN: true, MUL_ASSIGN: true,
+	QUO_ASSIGN: true, REM_ASSIGN: true, POW_ASSIGN: true, AND_ASSIGN: true, OR_ASSIGN: true, XOR_ASSIGN: true,
+	SHL_ASSIGN: true, SHR_ASSIGN: true, AND_NOT_ASSIGN: true,
+	LPAREN: true, LBRACK: true, COMMA: true, RETURN: true, YIELD: true, ARROW: true,
+	ADD: true, SUB: true, MUL: true, QUO: true, REM: true, POW: true, AND: true, OR: true, XOR: true,
+	SHL: true, SHR: true, AND_NOT: true, LAND: true, LOR: true, NOT: true,
+	EQL: true, NEQ: true, LSS: true, GTR: true, LEQ: true, GEQ: true, MATCH_OP: true,
+	QUESTION: true, QUES_QUES: true, IN: true, ELLIPSIS: true, DOTDOT: true,
+}
+
+// tokens ending an operand, a colon after them is never a symbol and a
+// slash is a division rather than a regexp literal
+var operandEnd = map[int]bool{
+	IDENT: true, INT: true, FLOAT: true, IMAG: true, CHAR: true, STRING: true,
+	STRING_TAIL: true, SYMBOL: true, REGEXP: true, RPAREN: true, RBRACK: true, RBRACE: true,
+}
+
 func (l *Lexer) Lex(lval *DobySymType) int {
+	tok := l.lex(lval)
+	if tok == IF && operandPrefix[l.lastTyp] {
+		tok = IF_EXPR
+	}
+	// like gofmt writes them, T{...} is a composite literal and if x {
+	// a block. as in go, a literal in a header must be parenthesized
+	block := l.header(tok)
+	if tok == LBRACE && l.lastTyp == IDENT && !l.spaced && !block {
+		tok = LIT_LBRACE
+	}
+	switch tok {
+	case LPAREN, LBRACK, LBRACE, LIT_LBRACE:
+		l.depth++
+	case RPAREN, RBRACK, RBRACE:
+		l.depth--
+	}
+	l.prevTyp, l.lastTyp = l.lastTyp, tok
+	return tok
+}
+
+// an if, for, switch or match starting a statement or an expression opens a
+// header, the first { at its depth is its block. after an operand they are
+// comprehension clauses or case guards, which have no block
+func (l *Lexer) header(tok int) bool {
+	switch tok {
+	case IF, IF_EXPR, FOR, SWITCH, MATCH:
+		if !operandEnd[l.lastTyp] {
+			l.headers = append(l.headers, l.depth)
+		}
+		return false
+	}
+	for n := len(l.headers); n > 0; n-- {
+		top := l.headers[n-1]
+		switch {
+		case tok == LBRACE && l.depth == top:
+			l.headers = l.headers[:n-1]
+			return true
+		case tok == EOL && l.depth == top:
+		case (tok == RPAREN || tok == RBRACK || tok == RBRACE) && l.depth <= top:
+		default:
+			return false
+		}
+		l.headers = l.headers[:n-1]
+	}
+	return false
+}
+
+// :name is a symbol unless the colon belongs to a dict field, case clause,
+// ?: or slice, the [ of an index like a[:n] follows an operand
+func (l *Lexer) symbolAllowed() bool {
+	if operandEnd[l.lastTyp] || l.lastTyp == DEFAULT {
+		return false
+	}
+	return l.lastTyp != LBRACK || !operandEnd[l.prevTyp]
+}
+
+func (l *Lexer) lex(lval *DobySymType) int {
 	if l.Pos >= len(l.Src) {
 		return 0
 	}
@@ -217,6 +347,7 @@ func (l *Lexer) Lex(lval *DobySymType) int {
 	src := l.Src[l.Pos:]
 	cur := strings.TrimLeft(src, " \t\r")
 	l.Pos += len(src) - len(cur)
+	l.spaced = len(src) != len(cur)
 
 	l.LastTok = lval
 
@@ -236,20 +367,42 @@ func (l *Lexer) Lex(lval *DobySymType) int {
 		return EOL
 	}
 
-	m = floatRe.FindString(cur)
-	if m != "" {
-		lval.tok = l.MkTok(m)
-		l.Col += len(m)
-		l.Pos += len(m)
-		return FLOAT
+	// the '}' closing an interpolation continues the string
+	if n := len(l.interps); n > 0 && cur[0] == '}' && l.interps[n-1].depth == 0 {
+		return l.lexString(lval, l.interps[n-1], l.Pos+1, false)
 	}
 
-	m = intRe.FindString(cur)
-	if m != "" {
+	if cur[0] == '"' {
+		return l.lexString(lval, &strLit{}, l.Pos+1, true)
+	}
+
+	if cur[0] == '`' {
+		return l.lexRawString(lval)
+	}
+
+	if sm := heredocRe.FindStringSubmatch(cur); sm != nil {
+		return l.lexHeredoc(lval, sm[1], l.Pos+len(sm[0]))
+	}
+
+	m = numberRe.FindString(cur)
+	if m != "" && !isIdentChar(cur, len(m)) {
 		lval.tok = l.MkTok(m)
 		l.Col += len(m)
 		l.Pos += len(m)
-		return INT
+		return numberKind(m)
+	}
+
+	if cur[0] == ':' && l.symbolAllowed() {
+		if m = identRe.FindString(cur[1:]); m != "" {
+			lval.tok = l.MkTok(m)
+			l.Col += len(m) + 1
+			l.Pos += len(m) + 1
+			return SYMBOL
+		}
+	}
+
+	if cur[0] == '/' && !operandEnd[l.lastTyp] {
+		return l.lexRegexp(lval)
 	}
 
 	for _, tok := range OpTokens {
@@ -259,12 +412,19 @@ func (l *Lexer) Lex(lval *DobySymType) int {
 			lval.tok = l.MkTok(op)
 			l.Col += len(op)
 			l.Pos += len(op)
+			if n := len(l.interps); n > 0 {
+				if tok == LBRACE {
+					l.interps[n-1].depth++
+				} else if tok == RBRACE {
+					l.interps[n-1].depth--
+				}
+			}
 			return tok
 		}
 	}
 
 	for tok, kw := range KeywordTokenMap {
-		if strings.HasPrefix(cur, kw) {
+		if strings.HasPrefix(cur, kw) && !isIdentChar(cur, len(kw)) {
 			lval.tok = l.MkTok(kw)
 			l.Col += len(kw)
 			l.Pos += len(kw)
@@ -272,29 +432,6 @@ func (l *Lexer) Lex(lval *DobySymType) int {
 		}
 	}
 
-	m = stringRe.FindString(cur)
-	if m != "" {
-		n := escapeRe.ReplaceAllStringFunc(m, func(s string) string {
-			esc := strings.TrimPrefix(s, "\\")
-			switch esc {
-			case "n":
-				return "\n"
-			case "t":
-				return "\t"
-			case "r":
-				return "\r"
-			case "\"":
-				return "\""
-			default:
-				return esc
-			}
-		})
-		lval.tok = l.MkTok(n)
-		l.Col += len(m)
-		l.Pos += len(m)
-		return STRING
-	}
-
 	m = charRe.FindString(cur)
 	if m != "" {
 		lval.tok = l.MkTok(m)
@@ -321,8 +458,217 @@ func (l *Lexer) Lex(lval *DobySymType) int {
 	return 0
 }
 
+// move to pos, keeping Line and Col right across newlines
+func (l *Lexer) advanceTo(pos int) {
+	seg := l.Src[l.Pos:pos]
+	if n := strings.Count(seg, "\n"); n > 0 {
+		l.Line += n
+		l.Col = len(seg) - strings.LastIndex(seg, "\n") - 1
+	} else {
+		l.Col += len(seg)
+	}
+	l.Pos = pos
+}
+
+// scan the body of a string literal from pos, up to the end of the literal
+// or the next #{, escape sequences are the ones of go plus \#
+func (l *Lexer) scanString(st *strLit, pos int) (text string, next int, interp bool, ok bool) {
+	buf := []byte{}
+	i := pos
+	lineStart := st.heredoc && l.Src[i-1] == '\n'
+	for {
+		if st.heredoc {
+			if i >= st.end {
+				return string(buf), st.resume, false, true
+			}
+			if lineStart {
+				for j := 0; j < st.indent && (l.Src[i] == ' ' || l.Src[i] == '\t'); j++ {
+					i++
+				}
+				lineStart = false
+				continue
+			}
+		} else {
+			if i >= len(l.Src) {
+				return "", i, false, false
+			}
+			if l.Src[i] == '"' {
+				return string(buf), i + 1, false, true
+			}
+		}
+
+		c := l.Src[i]
+		switch {
+		case c == '#' && i+1 < len(l.Src) && l.Src[i+1] == '{':
+			return string(buf), i + 2, true, true
+		case c == '\\' && i+1 < len(l.Src) && (l.Src[i+1] == '#' || l.Src[i+1] == '\''):
+			buf = append(buf, l.Src[i+1])
+			i += 2
+		case c == '\\':
+			val, multibyte, tail, err := strconv.UnquoteChar(l.Src[i:], '"')
+			if err != nil {
+				return "", i, false, false
+			}
+			if multibyte || val < utf8.RuneSelf {
+				buf = append(buf, string(val)...)
+			} else {
+				buf = append(buf, byte(val))
+			}
+			i = len(l.Src) - len(tail)
+		case c == '\n':
+			buf = append(buf, c)
+			i++
+			lineStart = st.heredoc
+		default:
+			buf = append(buf, c)
+			i++
+		}
+	}
+}
+
+// lex the next piece of a string literal, a literal without interpolation
+// is a single STRING, otherwise STRING_HEAD expr (STRING_MID expr)* STRING_TAIL
+func (l *Lexer) lexString(lval *DobySymType, st *strLit, pos int, first bool) int {
+	text, next, interp, ok := l.scanString(st, pos)
+	if !ok {
+		l.Error("bad escape or unterminated string literal")
+		l.Pos = len(l.Src)
+		return 0
+	}
+	lval.tok = l.MkTok(text)
+	l.advanceTo(next)
+
+	switch {
+	case first && !interp:
+		return STRING
+	case first:
+		l.interps = append(l.interps, st)
+		return STRING_HEAD
+	case interp:
+		return STRING_MID
+	default:
+		l.interps = l.interps[:len(l.interps)-1]
+		return STRING_TAIL
+	}
+}
+
+// `raw string`, no escapes and may span lines
+func (l *Lexer) lexRawString(lval *DobySymType) int {
+	end := strings.IndexByte(l.Src[l.Pos+1:], '`')
+	if end < 0 {
+		l.Error("unterminated raw string literal")
+		l.Pos = len(l.Src)
+		return 0
+	}
+	end += l.Pos + 1
+	lval.tok = l.MkTok(strings.Replace(l.Src[l.Pos+1:end], "\r", "", -1))
+	l.advanceTo(end + 1)
+	return STRING
+}
+
+// /pattern/flags, the literal of the token is pattern/flags. \/ stands for
+// a slash, other escapes are left to the regexp and a slash inside [] does
+// not end the pattern
+func (l *Lexer) lexRegexp(lval *DobySymType) int {
+	buf := []byte{}
+	class := false
+	i := l.Pos + 1
+	for ; i < len(l.Src) && (l.Src[i] != '/' || class); i++ {
+		c := l.Src[i]
+		switch {
+		case c == '\n':
+			i = len(l.Src)
+		case c == '\\' && i+1 < len(l.Src) && l.Src[i+1] == '/':
+			buf = append(buf, '/')
+			i++
+		case c == '\\' && i+1 < len(l.Src) && l.Src[i+1] != '\n':
+			buf = append(buf, c, l.Src[i+1])
+			i++
+		case c == '[':
+			class = true
+			buf = append(buf, c)
+		case c == ']':
+			class = false
+			buf = append(buf, c)
+		default:
+			buf = append(buf, c)
+		}
+	}
+	if i >= len(l.Src) {
+		l.Error("unterminated regexp literal")
+		l.Pos = len(l.Src)
+		return 0
+	}
+	end := i + 1
+	for end < len(l.Src) && isIdentChar(l.Src, end) {
+		end++
+	}
+	lval.tok = l.MkTok(string(buf) + "/" + l.Src[i+1:end])
+	l.advanceTo(end)
+	return REGEXP
+}
+
+// <<~TAG heredoc, the body starts on the next line and ends at a line holding
+// only TAG, the common indentation of the body lines is removed
+func (l *Lexer) lexHeredoc(lval *DobySymType, tag string, body int) int {
+	st := &strLit{heredoc: true, indent: -1}
+	pos := body
+	for {
+		if pos >= len(l.Src) {
+			l.Error("unterminated heredoc " + tag)
+			l.Pos = len(l.Src)
+			return 0
+		}
+		end := strings.IndexByte(l.Src[pos:], '\n')
+		if end < 0 {
+			end = len(l.Src)
+		} else {
+			end += pos
+		}
+		line := l.Src[pos:end]
+		if strings.TrimSpace(line) == tag {
+			st.end = pos
+			st.resume = end
+			break
+		}
+		if strings.TrimSpace(line) != "" {
+			ws := len(line) - len(strings.TrimLeft(line, " \t"))
+			if st.indent < 0 || ws < st.indent {
+				st.indent = ws
+			}
+		}
+		pos = end + 1
+	}
+	if st.indent < 0 {
+		st.indent = 0
+	}
+	return l.lexString(lval, st, body, true)
+}
+
+func numberKind(lit string) int {
+	if strings.HasSuffix(lit, "i") {
+		return IMAG
+	}
+	hex := len(lit) > 1 && (lit[1] == 'x' || lit[1] == 'X')
+	if strings.ContainsAny(lit, ".pP") || (!hex && strings.ContainsAny(lit, "eE")) {
+		return FLOAT
+	}
+	return INT
+}
+
+// a keyword only matches a whole word, "format" is not FOR + "mat"
+func isIdentChar(s string, i int) bool {
+	if i >= len(s) {
+		return false
+	}
+	c := s[i]
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// Error reports a syntax error, it is printed unless the lexer is quiet
 func (l *Lexer) Error(s string) {
-	fmt.Printf("Syntax Error: Line %d, Col %d:\n", l.Line, l.Col)
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "Syntax Error: %s, Line %d, Col %d:\n", s, l.Line, l.Col)
 
 	line := l.Line - 5
 	if line < 0 {
@@ -331,15 +677,26 @@ func (l *Lexer) Error(s string) {
 
 	for line < l.Line+5 && line < len(l.Lines) {
 		if line == l.Line-1 {
-			fmt.Printf("*%3d) %s\n", line+1, l.Lines[line])
+			fmt.Fprintf(&buf, "*%3d) %s\n", line+1, l.Lines[line])
 		} else {
-			fmt.Printf(" %3d) %s\n", line+1, l.Lines[line])
+			fmt.Fprintf(&buf, " %3d) %s\n", line+1, l.Lines[line])
 		}
 		line++
 	}
+
+	l.Errors = append(l.Errors, buf.String())
+	if !l.Quiet {
+		fmt.Print(buf.String())
+	}
 }
 
 func (l *Lexer) PrintPosInfo(pos int) {
+	fmt.Print(l.PosInfo(pos))
+}
+
+// PosInfo shows the lines around pos with a caret under it
+func (l *Lexer) PosInfo(pos int) string {
+	var buf bytes.Buffer
 	lineNum := 1
 	col := 0
 
@@ -353,9 +710,9 @@ func (l *Lexer) PrintPosInfo(pos int) {
 	}
 
 	if l.FileName != "" {
-		fmt.Printf("\nFile: \"%s\", Line %d, Col %d\n", l.FileName, lineNum, col)
+		fmt.Fprintf(&buf, "\nFile: \"%s\", Line %d, Col %d\n", l.FileName, lineNum, col)
 	} else {
-		fmt.Printf("\nLine %d, Col %d\n", lineNum, col)
+		fmt.Fprintf(&buf, "\nLine %d, Col %d\n", lineNum, col)
 	}
 
 	ln := lineNum - 5
@@ -365,19 +722,20 @@ func (l *Lexer) PrintPosInfo(pos int) {
 
 	for ln < lineNum+4 && ln < len(l.Lines) {
 		if ln == lineNum-1 {
-			fmt.Printf("*%3d) %s\n", ln+1, l.Lines[ln])
-			fmt.Print("      ")
+			fmt.Fprintf(&buf, "*%3d) %s\n", ln+1, l.Lines[ln])
+			buf.WriteString("      ")
 			for i := 0; i < col-1; i++ {
 				if l.Lines[ln][i] == '\t' {
-					fmt.Print("\t")
+					buf.WriteString("\t")
 				} else {
-					fmt.Print(" ")
+					buf.WriteString(" ")
 				}
 			}
-			fmt.Println("^")
+			buf.WriteString("^\n")
 		} else {
-			fmt.Printf(" %3d) %s\n", ln+1, l.Lines[ln])
+			fmt.Fprintf(&buf, " %3d) %s\n", ln+1, l.Lines[ln])
 		}
 		ln++
 	}
+	return buf.String()
 }
@@ -8,6 +8,7 @@ import (
 
 type ArrayObject struct {
 	Property
+	Enumerable
 
 	Vals []Object
 }
@@ -37,7 +38,24 @@ func (self *ArrayObject) ToString(rt *Runtime, args ...Object) []Object {
 	return []Object{rt.NewStringObject(self.String())}
 }
 
+func (self *ArrayObject) Freeze(rt *Runtime, args ...Object) (results []Object) {
+	if !self.frozen {
+		self.frozen = true
+		for _, val := range self.Vals {
+			val.Freeze(rt)
+		}
+	}
+	results = append(results, self)
+	return
+}
+
+func (self *ArrayObject) ToTuple(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewTupleObject(append([]Object{}, self.Vals...)))
+	return
+}
+
 func (self *ArrayObject) Push(rt *Runtime, args ...Object) (results []Object) {
+	rt.checkFrozen(self)
 	for _, arg := range args {
 		self.Vals = append(self.Vals, arg)
 	}
@@ -46,6 +64,7 @@ func (self *ArrayObject) Push(rt *Runtime, args ...Object) (results []Object) {
 }
 
 func (self *ArrayObject) Pop(rt *Runtime, args ...Object) (results []Object) {
+	rt.checkFrozen(self)
 	n := 1
 	if len(args) == 1 {
 		n = args[0].(*IntegerObject).Val
@@ -68,11 +87,12 @@ func (self *ArrayObject) Take(rt *Runtime, args ...Object) (results []Object) {
 	} else {
 		rt.Fatalf("array::Take need one integer argumenet, %d given", len(args))
 	}
-	obj := rt.NewArrayObject(self.Vals[:n])
+	obj := rt.NewArrayObject(append([]Object{}, self.Vals[:n]...))
 	results = append(results, obj)
 	return
 }
 
+// the elements after the first n, the receiver is left alone
 func (self *ArrayObject) Drop(rt *Runtime, args ...Object) (results []Object) {
 	var n int
 	if len(args) == 1 {
@@ -83,8 +103,7 @@ func (self *ArrayObject) Drop(rt *Runtime, args ...Object) (results []Object) {
 	} else {
 		rt.Fatalf("array::Drop need one integer argumenet, %d given", len(args))
 	}
-	obj := rt.NewArrayObject(self.Vals[:n])
-	self.Vals = self.Vals[n:]
+	obj := rt.NewArrayObject(append([]Object{}, self.Vals[n:]...))
 	results = append(results, obj)
 	return
 }
@@ -102,19 +121,16 @@ func (self *ArrayObject) Size(rt *Runtime, args ...Object) (results []Object) {
 }
 
 func (self *ArrayObject) Each(rt *Runtime, args ...Object) (results []Object) {
-	fnobj := args[0].(*ClosureObject)
 	for i := 0; i < len(self.Vals); i++ {
-		rt.CallFuncObj(fnobj, rt.NewIntegerObject(i), self.Vals[i])
+		rt.Call(args[0], rt.NewIntegerObject(i), self.Vals[i])
 	}
 	return
 }
 
 func (self *ArrayObject) Map(rt *Runtime, args ...Object) (results []Object) {
-	fnobj := args[0].(*ClosureObject)
 	arr := []Object{}
 	for i := 0; i < len(self.Vals); i++ {
-		rt.CallFuncObj(fnobj, self.Vals[i])
-		arr = append(arr, rt.Pop())
+		arr = append(arr, rt.Call(args[0], self.Vals[i]))
 	}
 	obj := rt.NewArrayObject(arr)
 	results = append(results, obj)
@@ -122,11 +138,9 @@ func (self *ArrayObject) Map(rt *Runtime, args ...Object) (results []Object) {
 }
 
 func (self *ArrayObject) Select(rt *Runtime, args ...Object) (results []Object) {
-	fnobj := args[0].(*ClosureObject)
 	arr := []Object{}
 	for i := 0; i < len(self.Vals); i++ {
-		rt.CallFuncObj(fnobj, self.Vals[i])
-		if rt.Pop().(*BoolObject).Val {
+		if Truthy(rt.Call(args[0], self.Vals[i])) {
 			arr = append(arr, self.Vals[i])
 		}
 	}
@@ -135,6 +149,20 @@ func (self *ArrayObject) Select(rt *Runtime, args ...Object) (results []Object)
 	return
 }
 
+// Sort() sorts in place by Compare, Sort(fn) by the key function fn(elem)
+// or the comparator fn(a, b). The sort is stable
+func (self *ArrayObject) Sort(rt *Runtime, args ...Object) (results []Object) {
+	rt.checkFrozen(self)
+	rt.sortObjects(self.Vals, sortFunc(args))
+	results = append(results, self)
+	return
+}
+
+func (self *ArrayObject) Lazy(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewLazyObject(self))
+	return
+}
+
 func (self *ArrayObject) OP__iter__(rt *Runtime, args ...Object) (results []Object) {
 	idx := args[0].(*IntegerObject)
 	if idx.Val < len(self.Vals) {
@@ -147,49 +175,73 @@ func (self *ArrayObject) OP__iter__(rt *Runtime, args ...Object) (results []Obje
 }
 
 func (self *ArrayObject) OP__add__(rt *Runtime, args ...Object) (results []Object) {
-	vals := append(self.Vals[:], args[0].(*ArrayObject).Vals...)
+	vals := append(append([]Object{}, self.Vals...), args[0].(*ArrayObject).Vals...)
 	ret := rt.NewArrayObject(vals)
 	results = append(results, ret)
 	return
 }
 
-func (self *ArrayObject) OP__add_assign__(rt *Runtime, args ...Object) (results []Object) {
-	self.Vals = append(self.Vals, args[0].(*ArrayObject).Vals...)
-	return
-}
-
 func (self *ArrayObject) OP__get_index__(rt *Runtime, args ...Object) (results []Object) {
-	idx := args[0].(*IntegerObject)
-	obj := self.Vals[idx.Val]
+	obj := self.Vals[rt.index(args[0], len(self.Vals))]
 	results = append(results, obj)
 	return
 }
 
 func (self *ArrayObject) OP__set_index__(rt *Runtime, args ...Object) (results []Object) {
-	idx := args[0].(*IntegerObject)
+	rt.checkFrozen(self)
 	val := args[1]
-	self.Vals[idx.Val] = val
+	self.Vals[rt.index(args[0], len(self.Vals))] = val
 	return
 }
 
 func (self *ArrayObject) OP__slice__(rt *Runtime, args ...Object) (results []Object) {
-	low := 0
-	high := len(self.Vals)
-	lo := args[0]
-	ho := args[1]
+	lo, hi, step := rt.sliceIndices(args, len(self.Vals))
+	vals := make([]Object, 0, sliceLen(lo, hi, step))
+	for i := lo; len(vals) < cap(vals); i += step {
+		vals = append(vals, self.Vals[i])
+	}
+	ret := rt.NewArrayObject(vals)
+	results = append(results, ret)
+	return
+}
+
+// a[lo:hi] = vals replaces the range, which may change the length of the
+// array. with a step the number of values must match the slice
+func (self *ArrayObject) OP__set_slice__(rt *Runtime, args ...Object) (results []Object) {
+	rt.checkFrozen(self)
+	arr, ok := args[3].(*ArrayObject)
+	if !ok {
+		rt.Fatalf("can only assign an array to a slice, %s given", args[3].Name())
+	}
+	vals := append([]Object{}, arr.Vals...)
 
-	v, ok := lo.(*IntegerObject)
-	if ok {
-		low = v.Val
+	lo, hi, step := rt.sliceIndices(args[:3], len(self.Vals))
+	if step == 1 {
+		if hi < lo {
+			hi = lo
+		}
+		tail := append(vals, self.Vals[hi:]...)
+		self.Vals = append(self.Vals[:lo], tail...)
+		return
 	}
 
-	v, ok = ho.(*IntegerObject)
-	if ok {
-		high = ho.(*IntegerObject).Val
+	n := sliceLen(lo, hi, step)
+	if n != len(vals) {
+		rt.Fatalf("cannot assign %d values to a slice of %d elements", len(vals), n)
+	}
+	for i, j := lo, 0; j < n; i, j = i+step, j+1 {
+		self.Vals[i] = vals[j]
 	}
+	return
+}
 
-	vals := self.Vals[low:high]
-	ret := rt.NewArrayObject(vals)
-	results = append(results, ret)
+func (self *ArrayObject) DeleteAt(rt *Runtime, args ...Object) (results []Object) {
+	rt.checkFrozen(self)
+	if len(args) != 1 {
+		rt.Fatalf("array::DeleteAt need one integer argument, %d given", len(args))
+	}
+	i := rt.index(args[0], len(self.Vals))
+	self.Vals = append(self.Vals[:i], self.Vals[i+1:]...)
+	results = append(results, self)
 	return
 }
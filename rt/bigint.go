@@ -0,0 +1,274 @@
+package rt
+
+import (
+	"math"
+	"math/big"
+)
+
+/// bigint
+
+// integers that overflow int64 are promoted to a BigIntObject, results
+// that fit back into int64 are demoted to a plain IntegerObject
+type BigIntObject struct {
+	Property
+
+	Val *big.Int
+}
+
+func (self *BigIntObject) Name() string {
+	return "bigint"
+}
+
+func (self *BigIntObject) HashCode() string {
+	return self.String()
+}
+
+func (self *BigIntObject) String() string {
+	return self.Val.String()
+}
+
+func (self *BigIntObject) ToString(rt *Runtime, args ...Object) []Object {
+	return []Object{rt.NewStringObject(self.String())}
+}
+
+func (self *BigIntObject) Abs(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewInteger(new(big.Int).Abs(self.Val)))
+	return
+}
+
+func (self *BigIntObject) BitLen(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewIntegerObject(self.Val.BitLen()))
+	return
+}
+
+func (self *BigIntObject) Pow(rt *Runtime, args ...Object) (results []Object) {
+	return self.OP__pow__(rt, args...)
+}
+
+func (self *BigIntObject) DivMod(rt *Runtime, args ...Object) (results []Object) {
+	return rt.divMod(self.Val, args[0])
+}
+
+func (self *BigIntObject) Gcd(rt *Runtime, args ...Object) (results []Object) {
+	return rt.gcd(self.Val, args[0])
+}
+
+func (self *BigIntObject) Round(rt *Runtime, args ...Object) (results []Object) {
+	return rt.roundInteger("bigint::Round", self.Val, "round", args)
+}
+
+func (self *BigIntObject) Floor(rt *Runtime, args ...Object) (results []Object) {
+	return rt.roundInteger("bigint::Floor", self.Val, "floor", args)
+}
+
+func (self *BigIntObject) Ceil(rt *Runtime, args ...Object) (results []Object) {
+	return rt.roundInteger("bigint::Ceil", self.Val, "ceil", args)
+}
+
+func (self *BigIntObject) ToF(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewFloatObject(bigToFloat(self.Val)))
+	return
+}
+
+func (self *BigIntObject) ToI(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, self)
+	return
+}
+
+func (self *BigIntObject) OP__minus__(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewInteger(new(big.Int).Neg(self.Val)))
+	return
+}
+
+// +
+func (self *BigIntObject) OP__add__(rt *Runtime, args ...Object) (results []Object) {
+	results = bigBinary(rt, "__add__", self.Val, args[0])
+	return
+}
+
+// -
+func (self *BigIntObject) OP__sub__(rt *Runtime, args ...Object) (results []Object) {
+	results = bigBinary(rt, "__sub__", self.Val, args[0])
+	return
+}
+
+// *
+func (self *BigIntObject) OP__mul__(rt *Runtime, args ...Object) (results []Object) {
+	results = bigBinary(rt, "__mul__", self.Val, args[0])
+	return
+}
+
+// /
+func (self *BigIntObject) OP__quo__(rt *Runtime, args ...Object) (results []Object) {
+	results = bigBinary(rt, "__quo__", self.Val, args[0])
+	return
+}
+
+// %
+func (self *BigIntObject) OP__rem__(rt *Runtime, args ...Object) (results []Object) {
+	results = bigBinary(rt, "__rem__", self.Val, args[0])
+	return
+}
+
+// **
+func (self *BigIntObject) OP__pow__(rt *Runtime, args ...Object) (results []Object) {
+	results = bigBinary(rt, "__pow__", self.Val, args[0])
+	return
+}
+
+// &
+func (self *BigIntObject) OP__and__(rt *Runtime, args ...Object) (results []Object) {
+	results = bigBinary(rt, "__and__", self.Val, args[0])
+	return
+}
+
+// |
+func (self *BigIntObject) OP__or__(rt *Runtime, args ...Object) (results []Object) {
+	results = bigBinary(rt, "__or__", self.Val, args[0])
+	return
+}
+
+// ^
+func (self *BigIntObject) OP__xor__(rt *Runtime, args ...Object) (results []Object) {
+	results = bigBinary(rt, "__xor__", self.Val, args[0])
+	return
+}
+
+// <<
+func (self *BigIntObject) OP__shl__(rt *Runtime, args ...Object) (results []Object) {
+	results = bigBinary(rt, "__shl__", self.Val, args[0])
+	return
+}
+
+// >>
+func (self *BigIntObject) OP__shr__(rt *Runtime, args ...Object) (results []Object) {
+	results = bigBinary(rt, "__shr__", self.Val, args[0])
+	return
+}
+
+// &^
+func (self *BigIntObject) OP__and_not__(rt *Runtime, args ...Object) (results []Object) {
+	results = bigBinary(rt, "__and_not__", self.Val, args[0])
+	return
+}
+
+// ==
+func (self *BigIntObject) OP__eql__(rt *Runtime, args ...Object) (results []Object) {
+	results = bigBinary(rt, "__eql__", self.Val, args[0])
+	return
+}
+
+// !=
+func (self *BigIntObject) OP__neq__(rt *Runtime, args ...Object) (results []Object) {
+	results = bigBinary(rt, "__neq__", self.Val, args[0])
+	return
+}
+
+// <
+func (self *BigIntObject) OP__lss__(rt *Runtime, args ...Object) (results []Object) {
+	results = bigBinary(rt, "__lss__", self.Val, args[0])
+	return
+}
+
+// >
+func (self *BigIntObject) OP__gtr__(rt *Runtime, args ...Object) (results []Object) {
+	results = bigBinary(rt, "__gtr__", self.Val, args[0])
+	return
+}
+
+// <=
+func (self *BigIntObject) OP__leq__(rt *Runtime, args ...Object) (results []Object) {
+	results = bigBinary(rt, "__leq__", self.Val, args[0])
+	return
+}
+
+// >=
+func (self *BigIntObject) OP__geq__(rt *Runtime, args ...Object) (results []Object) {
+	results = bigBinary(rt, "__geq__", self.Val, args[0])
+	return
+}
+
+func bigToFloat(x *big.Int) float64 {
+	f, _ := new(big.Float).SetInt(x).Float64()
+	return f
+}
+
+// bigBinary computes x op obj exactly, float and complex operands fall back
+// to float and complex arithmetic
+func bigBinary(rt *Runtime, method string, x *big.Int, obj Object) (results []Object) {
+	var y *big.Int
+
+	switch arg := obj.(type) {
+	case *IntegerObject:
+		y = big.NewInt(int64(arg.Val))
+	case *BigIntObject:
+		y = arg.Val
+	case *FloatObject:
+		return rt.NewFloatObject(bigToFloat(x)).binary(rt, method, obj)
+	case *ComplexObject:
+		return complexBinary(rt, method, complex(bigToFloat(x), 0), obj)
+	default:
+		switch method {
+		case "__eql__":
+			return append(results, rt.False)
+		case "__neq__":
+			return append(results, rt.True)
+		}
+		rt.Fatalf("integer::%s unsupported operand %s", method, obj.Name())
+		return
+	}
+
+	z := new(big.Int)
+	switch method {
+	case "__add__":
+		z.Add(x, y)
+	case "__sub__":
+		z.Sub(x, y)
+	case "__mul__":
+		z.Mul(x, y)
+	case "__quo__":
+		if y.Sign() == 0 {
+			rt.Fatalf("integer divide by zero")
+		}
+		z.Quo(x, y)
+	case "__rem__":
+		if y.Sign() == 0 {
+			rt.Fatalf("integer divide by zero")
+		}
+		z.Rem(x, y)
+	case "__pow__":
+		return append(results, bigPow(rt, x, y))
+	case "__and__":
+		z.And(x, y)
+	case "__or__":
+		z.Or(x, y)
+	case "__xor__":
+		z.Xor(x, y)
+	case "__and_not__":
+		z.AndNot(x, y)
+	case "__shl__", "__shr__":
+		if y.Sign() < 0 || !y.IsInt64() || y.Int64() > math.MaxInt32 {
+			rt.Fatalf("invalid shift count %s", y)
+		}
+		if method == "__shl__" {
+			z.Lsh(x, uint(y.Int64()))
+		} else {
+			z.Rsh(x, uint(y.Int64()))
+		}
+	case "__eql__":
+		return append(results, rt.NewBoolObject(x.Cmp(y) == 0))
+	case "__neq__":
+		return append(results, rt.NewBoolObject(x.Cmp(y) != 0))
+	case "__lss__":
+		return append(results, rt.NewBoolObject(x.Cmp(y) < 0))
+	case "__gtr__":
+		return append(results, rt.NewBoolObject(x.Cmp(y) > 0))
+	case "__leq__":
+		return append(results, rt.NewBoolObject(x.Cmp(y) <= 0))
+	case "__geq__":
+		return append(results, rt.NewBoolObject(x.Cmp(y) >= 0))
+	}
+
+	results = append(results, rt.NewInteger(z))
+	return
+}
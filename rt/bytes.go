@@ -0,0 +1,122 @@
+package rt
+
+import (
+	"bytes"
+	"fmt"
+)
+
+/// bytes
+
+type BytesObject struct {
+	Property
+
+	Val []byte
+}
+
+func (self *BytesObject) Name() string {
+	return "bytes"
+}
+
+func (self *BytesObject) HashCode() string {
+	return fmt.Sprintf("%p", self)
+}
+
+func (self *BytesObject) String() string {
+	return fmt.Sprint(self.Val)
+}
+
+func (self *BytesObject) ToString(rt *Runtime, args ...Object) []Object {
+	return []Object{rt.NewStringObject(self.String())}
+}
+
+/// methods
+
+func (self *BytesObject) Length(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewIntegerObject(len(self.Val)))
+	return
+}
+
+func (self *BytesObject) Size(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewIntegerObject(len(self.Val)))
+	return
+}
+
+// interpret the bytes as utf-8 text
+func (self *BytesObject) Decode(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewStringObject(string(self.Val)))
+	return
+}
+
+func (self *BytesObject) ToArray(rt *Runtime, args ...Object) (results []Object) {
+	vals := []Object{}
+	for _, b := range self.Val {
+		vals = append(vals, rt.NewIntegerObject(int(b)))
+	}
+	results = append(results, rt.NewArrayObject(vals))
+	return
+}
+
+/// operators
+
+func (self *BytesObject) OP__add__(rt *Runtime, args ...Object) (results []Object) {
+	arg, ok := args[0].(*BytesObject)
+	if !ok {
+		rt.Fatalf("bytes::+ unsupported operand %s", args[0].Name())
+	}
+	val := append(append([]byte{}, self.Val...), arg.Val...)
+	results = append(results, rt.NewBytesObject(val))
+	return
+}
+
+func (self *BytesObject) OP__eql__(rt *Runtime, args ...Object) (results []Object) {
+	arg, ok := args[0].(*BytesObject)
+	cmp := ok && bytes.Equal(self.Val, arg.Val)
+	results = append(results, rt.NewBoolObject(cmp))
+	return
+}
+
+func (self *BytesObject) OP__neq__(rt *Runtime, args ...Object) (results []Object) {
+	arg, ok := args[0].(*BytesObject)
+	cmp := !ok || !bytes.Equal(self.Val, arg.Val)
+	results = append(results, rt.NewBoolObject(cmp))
+	return
+}
+
+func (self *BytesObject) OP__get_index__(rt *Runtime, args ...Object) (results []Object) {
+	b := self.Val[rt.index(args[0], len(self.Val))]
+	results = append(results, rt.NewIntegerObject(int(b)))
+	return
+}
+
+func (self *BytesObject) OP__set_index__(rt *Runtime, args ...Object) (results []Object) {
+	rt.checkFrozen(self)
+	val := args[1].(*IntegerObject)
+	self.Val[rt.index(args[0], len(self.Val))] = byte(val.Val)
+	return
+}
+
+func (self *BytesObject) OP__slice__(rt *Runtime, args ...Object) (results []Object) {
+	lo, hi, step := rt.sliceIndices(args, len(self.Val))
+
+	if step == 1 {
+		results = append(results, rt.NewBytesObject(self.Val[lo:hi]))
+		return
+	}
+	sub := make([]byte, 0, sliceLen(lo, hi, step))
+	for i := lo; len(sub) < cap(sub); i += step {
+		sub = append(sub, self.Val[i])
+	}
+	results = append(results, rt.NewBytesObject(sub))
+	return
+}
+
+func (self *BytesObject) OP__iter__(rt *Runtime, args ...Object) (results []Object) {
+	idx := args[0].(*IntegerObject)
+	if idx.Val < len(self.Val) {
+		b := rt.NewIntegerObject(int(self.Val[idx.Val]))
+		results = append(results, args[0], b, rt.True)
+	} else {
+		results = append(results, rt.False)
+	}
+	return
+}
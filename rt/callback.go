@@ -0,0 +1,148 @@
+package rt
+
+import (
+	"reflect"
+)
+
+/// go callbacks
+
+// The runtime has a single operand stack, so only one goroutine may run doby
+// code at a time. The vm holds the lock while it runs and releases it around
+// calls into go, a callback takes it before it enters the vm. Go may call a
+// callback from any goroutine, e.g. an http handler, it waits for its turn.
+func (self *Runtime) Lock() {
+	self.lock.Lock()
+}
+
+func (self *Runtime) Unlock() {
+	self.lock.Unlock()
+}
+
+// closures, go functions and builtin methods can be passed to go as funcs
+func callable(obj Object) bool {
+	switch obj.(type) {
+	case *ClosureObject, *GoFuncObject, *FuncObject, *GoTypeObject:
+		return true
+	}
+	return false
+}
+
+// CallAll calls a closure, go function or builtin method and returns all of
+// its results
+func (self *Runtime) CallAll(fn Object, args ...Object) []Object {
+	switch fn := fn.(type) {
+	case *ClosureObject:
+		base := self.Stack.Len()
+		self.CallFuncObj(fn, args...)
+		rets := make([]Object, self.Stack.Len()-base)
+		for i := len(rets) - 1; i >= 0; i-- {
+			rets[i] = self.Pop()
+		}
+		return rets
+	case *GoFuncObject:
+		return fn.CallGoFunc(self, args...)
+	case *FuncObject, *GoTypeObject:
+		return Invoke(self, fn, "__call__", args...)
+	}
+	self.Fatalf("%s is not callable", fn.Name())
+	return nil
+}
+
+// a go func of type typ calling fn, go functions of a convertible type are
+// passed as they are
+func (self *Runtime) goFunc(fn Object, typ reflect.Type) reflect.Value {
+	if gofn, ok := fn.(*GoFuncObject); ok && gofn.typ.ConvertibleTo(typ) {
+		return reflect.ValueOf(gofn.fn).Convert(typ)
+	}
+
+	return reflect.MakeFunc(typ, func(in []reflect.Value) []reflect.Value {
+		self.Lock()
+		pos := self.Pos
+		args := make([]Object, len(in))
+		for i, v := range in {
+			if v.Kind() == reflect.Int32 {
+				// rune and int32 are the same type, go passes runes
+				args[i] = self.NewRuneObject(rune(v.Int()))
+			} else {
+				args[i] = self.GoValueToObject(v.Interface())
+			}
+		}
+		rets := self.CallAll(fn, args...)
+		self.Pos = pos
+
+		outs := make([]reflect.Value, typ.NumOut())
+		for i := range outs {
+			var ret Object = self.Nil
+			if i < len(rets) {
+				ret = rets[i]
+			}
+			outs[i] = self.goValue(ret, typ.Out(i))
+		}
+		self.Unlock()
+		return outs
+	})
+}
+
+// an argument of a go method, callables become funcs and arrays and dicts
+// go values where go wants one
+func (self *Runtime) goArg(obj Object, typ reflect.Type) reflect.Value {
+	switch o := obj.(type) {
+	case *BigIntObject:
+		if v, ok := bigIntToValue(o, typ); ok {
+			return v
+		}
+		self.Fatalf("%s overflows %s", o.Val, typ)
+	case *ClosureObject, *GoFuncObject, *FuncObject, *GoTypeObject, *ArrayObject, *DictObject:
+		if typ != nil && typ.Kind() != reflect.Interface {
+			return self.goValue(obj, typ)
+		}
+	}
+	return ObjectToValue(obj, typ)
+}
+
+// obj as a go value of type typ, nil is the zero value. arrays and dicts
+// make slices, maps and structs, see compose
+func (self *Runtime) goValue(obj Object, typ reflect.Type) reflect.Value {
+	var v reflect.Value
+	switch o := obj.(type) {
+	case *NilObject:
+		return reflect.Zero(typ)
+	case *GoObject:
+		if o.obj == nil {
+			return reflect.Zero(typ)
+		}
+		v = o.valueFor(typ)
+	case *BigIntObject:
+		if v, ok := bigIntToValue(o, typ); ok {
+			return v
+		}
+		v = ObjectToValue(obj, nil)
+	case *SymbolObject:
+		v = reflect.ValueOf(o.Val)
+	case *ArrayObject, *DictObject:
+		switch typ.Kind() {
+		case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+			return self.compose(typ, obj)
+		case reflect.Ptr:
+			if typ.Elem().Kind() == reflect.Struct {
+				return self.compose(typ.Elem(), obj).Addr()
+			}
+		}
+		v = ObjectToValue(obj, nil)
+	default:
+		if typ.Kind() == reflect.Func && callable(obj) {
+			return self.goFunc(obj, typ)
+		}
+		v = ObjectToValue(obj, nil)
+	}
+
+	if v.Type().AssignableTo(typ) {
+		return v
+	}
+	// go converts integers to strings of one rune, doby does not
+	if v.Type().ConvertibleTo(typ) && (typ.Kind() != reflect.String || v.Kind() == reflect.String) {
+		return v.Convert(typ)
+	}
+	self.Fatalf("cannot use %s as go %s", obj.Name(), typ)
+	return reflect.Value{}
+}
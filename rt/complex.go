@@ -0,0 +1,144 @@
+package rt
+
+import (
+	"fmt"
+	"math/cmplx"
+)
+
+/// complex
+
+type ComplexObject struct {
+	Property
+
+	Val complex128
+}
+
+func (self *ComplexObject) HashCode() string {
+	return self.String()
+}
+
+func (self *ComplexObject) Name() string {
+	return "complex"
+}
+
+func (self *ComplexObject) String() string {
+	return fmt.Sprint(self.Val)
+}
+
+func (self *ComplexObject) ToString(rt *Runtime, args ...Object) []Object {
+	return []Object{rt.NewStringObject(self.String())}
+}
+
+func (self *ComplexObject) Real(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewFloatObject(real(self.Val)))
+	return
+}
+
+func (self *ComplexObject) Imag(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewFloatObject(imag(self.Val)))
+	return
+}
+
+func (self *ComplexObject) Abs(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewFloatObject(cmplx.Abs(self.Val)))
+	return
+}
+
+func (self *ComplexObject) Conj(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewComplexObject(cmplx.Conj(self.Val)))
+	return
+}
+
+func (self *ComplexObject) Pow(rt *Runtime, args ...Object) (results []Object) {
+	return self.OP__pow__(rt, args...)
+}
+
+func (self *ComplexObject) OP__minus__(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewComplexObject(-self.Val))
+	return
+}
+
+// +
+func (self *ComplexObject) OP__add__(rt *Runtime, args ...Object) (results []Object) {
+	results = complexBinary(rt, "__add__", self.Val, args[0])
+	return
+}
+
+// -
+func (self *ComplexObject) OP__sub__(rt *Runtime, args ...Object) (results []Object) {
+	results = complexBinary(rt, "__sub__", self.Val, args[0])
+	return
+}
+
+// *
+func (self *ComplexObject) OP__mul__(rt *Runtime, args ...Object) (results []Object) {
+	results = complexBinary(rt, "__mul__", self.Val, args[0])
+	return
+}
+
+// /
+func (self *ComplexObject) OP__quo__(rt *Runtime, args ...Object) (results []Object) {
+	results = complexBinary(rt, "__quo__", self.Val, args[0])
+	return
+}
+
+// **
+func (self *ComplexObject) OP__pow__(rt *Runtime, args ...Object) (results []Object) {
+	results = complexBinary(rt, "__pow__", self.Val, args[0])
+	return
+}
+
+// ==
+func (self *ComplexObject) OP__eql__(rt *Runtime, args ...Object) (results []Object) {
+	results = complexBinary(rt, "__eql__", self.Val, args[0])
+	return
+}
+
+// !=
+func (self *ComplexObject) OP__neq__(rt *Runtime, args ...Object) (results []Object) {
+	results = complexBinary(rt, "__neq__", self.Val, args[0])
+	return
+}
+
+func toComplex(obj Object) (val complex128, ok bool) {
+	switch arg := obj.(type) {
+	case *IntegerObject:
+		return complex(float64(arg.Val), 0), true
+	case *FloatObject:
+		return complex(arg.Val, 0), true
+	case *BigIntObject:
+		return complex(bigToFloat(arg.Val), 0), true
+	case *ComplexObject:
+		return arg.Val, true
+	}
+	return 0, false
+}
+
+// complexBinary is shared with integer and float operands promoted to complex
+func complexBinary(rt *Runtime, method string, x complex128, obj Object) (results []Object) {
+	y, ok := toComplex(obj)
+	if !ok {
+		rt.Fatalf("complex::%s unsupported operand %s", method, obj.Name())
+		return
+	}
+
+	switch method {
+	case "__add__":
+		results = append(results, rt.NewComplexObject(x+y))
+	case "__sub__":
+		results = append(results, rt.NewComplexObject(x-y))
+	case "__mul__":
+		results = append(results, rt.NewComplexObject(x*y))
+	case "__quo__":
+		results = append(results, rt.NewComplexObject(x/y))
+	case "__pow__":
+		results = append(results, rt.NewComplexObject(cmplx.Pow(x, y)))
+	case "__eql__":
+		results = append(results, rt.NewBoolObject(x == y))
+	case "__neq__":
+		results = append(results, rt.NewBoolObject(x != y))
+	default:
+		rt.Fatalf("complex::%s not supported", method)
+	}
+	return
+}
@@ -2,14 +2,139 @@ package rt
 
 import (
 	"fmt"
-	"sort"
 )
 
 /// dict
 
+// entries keep the insertion order, index maps the typed key of a key, see
+// setKey, to its position in entries. keys are stored apart from the
+// property chain, so a key never shadows a dict method
 type DictObject struct {
 	Property
-	sortedkeys []string
+	Enumerable
+
+	entries []Slot
+	index   map[string]int
+	module  bool
+	// entries removed but not yet dropped from entries
+	holes int
+}
+
+func (self *DictObject) get(key Object) (Object, bool) {
+	i, ok := self.index[setKey(key)]
+	if !ok {
+		return nil, false
+	}
+	return self.entries[i].Val, true
+}
+
+func (self *DictObject) put(key, val Object) {
+	if self.index == nil {
+		self.index = map[string]int{}
+	}
+	k := setKey(key)
+	if i, ok := self.index[k]; ok {
+		self.entries[i].Val = val
+		return
+	}
+	self.index[k] = len(self.entries)
+	self.entries = append(self.entries, Slot{key, val})
+}
+
+// a removed entry leaves a hole, the holes are closed all at once the next
+// time the entries are read, so deleting many keys stays linear
+func (self *DictObject) remove(key Object) {
+	k := setKey(key)
+	i, ok := self.index[k]
+	if !ok {
+		return
+	}
+	delete(self.index, k)
+	self.entries[i] = Slot{}
+	self.holes++
+}
+
+// the entries in insertion order, every read of them goes through here
+func (self *DictObject) slots() []Slot {
+	if self.holes == 0 {
+		return self.entries
+	}
+	entries := self.entries[:0]
+	for _, slot := range self.entries {
+		if slot.Key != nil {
+			self.index[setKey(slot.Key)] = len(entries)
+			entries = append(entries, slot)
+		}
+	}
+	for i := len(entries); i < len(self.entries); i++ {
+		self.entries[i] = Slot{}
+	}
+	self.entries, self.holes = entries, 0
+	return entries
+}
+
+// walks a snapshot of the entries, so the dict may change on the way. an
+// entry deleted before it is reached is skipped and a key gives its value
+// at the time it is reached
+func (self *DictObject) walk() func() (Slot, bool) {
+	slots := append([]Slot(nil), self.slots()...)
+	i := 0
+	return func() (Slot, bool) {
+		for i < len(slots) {
+			key := slots[i].Key
+			i++
+			if val, ok := self.get(key); ok {
+				return Slot{key, val}, true
+			}
+		}
+		return Slot{}, false
+	}
+}
+
+// a dict keyed by strings, from before there were symbols or read from a
+// file, still answers d.name with its "name" entry when it has no :name
+func (self *DictObject) stringKey(key Object) (int, bool) {
+	sym, ok := key.(*SymbolObject)
+	if !ok {
+		return 0, false
+	}
+	if _, ok := self.index[setKey(sym)]; ok {
+		return 0, false
+	}
+	// the setKey of the string sym.Val
+	i, ok := self.index["string\x00"+sym.Val]
+	return i, ok
+}
+
+// d.name is the method name when the dict has one and the key :name
+// otherwise, or the key "name", module dicts look at their keys first
+func (self *DictObject) GetProp(key Object) Object {
+	if self.module {
+		if val, ok := self.get(key); ok {
+			return val
+		}
+	}
+	if val, ok := self.Property.lookup(key); ok {
+		return val
+	}
+	if val, ok := self.get(key); ok {
+		return val
+	}
+	if i, ok := self.stringKey(key); ok {
+		return self.entries[i].Val
+	}
+	panic(fmt.Sprintf("Error: no property %v\n", key))
+}
+
+func (self *DictObject) SetProp(key Object, val Object) {
+	if self.frozen {
+		panic(fmt.Sprintf("Error: cannot set property %v of a frozen object\n", key))
+	}
+	if i, ok := self.stringKey(key); ok {
+		self.entries[i].Val = val
+		return
+	}
+	self.put(key, val)
 }
 
 func (self *DictObject) Name() string {
@@ -22,17 +147,14 @@ func (self *DictObject) HashCode() string {
 
 func (self *DictObject) String() string {
 	s := "#{"
-
-	ln := len(self.Property.Slots)
-	idx := 0
-	for _, slot := range self.Property.Slots {
+	ln := len(self.slots())
+	for i, slot := range self.slots() {
 		s += slot.Key.String()
 		s += ":"
 		s += slot.Val.String()
-		if idx < ln-1 {
+		if i < ln-1 {
 			s += ","
 		}
-		idx++
 	}
 	s += "}"
 	return s
@@ -42,23 +164,131 @@ func (self *DictObject) ToString(rt *Runtime, args ...Object) []Object {
 	return []Object{rt.NewStringObject(self.String())}
 }
 
-func (self *DictObject) OP__iter__(rt *Runtime, args ...Object) (results []Object) {
-	idx := args[0].(*IntegerObject)
-	ln := len(self.Property.Slots)
+func (self *DictObject) Freeze(rt *Runtime, args ...Object) (results []Object) {
+	if !self.frozen {
+		self.frozen = true
+		for _, slot := range self.slots() {
+			slot.Val.Freeze(rt)
+		}
+	}
+	results = append(results, self)
+	return
+}
 
-	if idx.Val == 0 {
-		hashes := make([]string, ln)
-		i := 0
-		for hash, _ := range self.Property.Slots {
-			hashes[i] = hash
-			i++
+func (self *DictObject) Keys(rt *Runtime, args ...Object) (results []Object) {
+	keys := make([]Object, len(self.slots()))
+	for i, slot := range self.slots() {
+		keys[i] = slot.Key
+	}
+	results = append(results, rt.NewArrayObject(keys))
+	return
+}
+
+func (self *DictObject) Values(rt *Runtime, args ...Object) (results []Object) {
+	vals := make([]Object, len(self.slots()))
+	for i, slot := range self.slots() {
+		vals[i] = slot.Val
+	}
+	results = append(results, rt.NewArrayObject(vals))
+	return
+}
+
+func (self *DictObject) Has(rt *Runtime, args ...Object) (results []Object) {
+	_, ok := self.get(args[0])
+	results = append(results, rt.NewBoolObject(ok))
+	return
+}
+
+func (self *DictObject) Delete(rt *Runtime, args ...Object) (results []Object) {
+	rt.checkFrozen(self)
+	for _, arg := range args {
+		self.remove(arg)
+	}
+	results = append(results, self)
+	return
+}
+
+// a new dict with the entries of both, the argument wins on equal keys
+func (self *DictObject) Merge(rt *Runtime, args ...Object) (results []Object) {
+	other, ok := args[0].(*DictObject)
+	if !ok {
+		rt.Fatalf("dict::Merge needs a dict, %s given", args[0].Name())
+	}
+	slots := append(append([]Slot{}, self.slots()...), other.slots()...)
+	results = append(results, rt.NewDictObject(slots))
+	return
+}
+
+// the value of a key, the default when it is missing or an error without one
+func (self *DictObject) Fetch(rt *Runtime, args ...Object) (results []Object) {
+	val, ok := self.get(args[0])
+	if !ok {
+		if len(args) < 2 {
+			rt.Fatalf("key %s not found in %s", args[0], self)
+		}
+		val = args[1]
+	}
+	results = append(results, val)
+	return
+}
+
+func (self *DictObject) Each(rt *Runtime, args ...Object) (results []Object) {
+	next := self.walk()
+	for slot, ok := next(); ok; slot, ok = next() {
+		rt.Call(args[0], slot.Key, slot.Val)
+	}
+	return
+}
+
+func (self *DictObject) Map(rt *Runtime, args ...Object) (results []Object) {
+	arr := []Object{}
+	next := self.walk()
+	for slot, ok := next(); ok; slot, ok = next() {
+		arr = append(arr, rt.Call(args[0], slot.Key, slot.Val))
+	}
+	results = append(results, rt.NewArrayObject(arr))
+	return
+}
+
+func (self *DictObject) Select(rt *Runtime, args ...Object) (results []Object) {
+	slots := []Slot{}
+	next := self.walk()
+	for slot, ok := next(); ok; slot, ok = next() {
+		if Truthy(rt.Call(args[0], slot.Key, slot.Val)) {
+			slots = append(slots, slot)
 		}
-		self.sortedkeys = hashes
-		sort.Strings(self.sortedkeys)
 	}
-	if idx.Val < ln {
-		hash := self.sortedkeys[idx.Val]
-		slot := self.Property.Slots[hash]
+	results = append(results, rt.NewDictObject(slots))
+	return
+}
+
+func (self *DictObject) Size(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewIntegerObject(len(self.slots())))
+	return
+}
+
+func (self *DictObject) Length(rt *Runtime, args ...Object) (results []Object) {
+	return self.Size(rt, args...)
+}
+
+// [[key, value], ...] in insertion order
+func (self *DictObject) ToArray(rt *Runtime, args ...Object) (results []Object) {
+	pairs := make([]Object, len(self.slots()))
+	for i, slot := range self.slots() {
+		pairs[i] = rt.NewArrayObject([]Object{slot.Key, slot.Val})
+	}
+	results = append(results, rt.NewArrayObject(pairs))
+	return
+}
+
+/// operators
+
+// a loop over a dict walks a dictIter, see Runtime.OpenIter, this is for
+// the values that are iterated by index
+func (self *DictObject) OP__iter__(rt *Runtime, args ...Object) (results []Object) {
+	idx := args[0].(*IntegerObject)
+	if idx.Val < len(self.slots()) {
+		slot := self.slots()[idx.Val]
 		results = append(results, slot.Key, slot.Val, rt.True)
 	} else {
 		results = append(results, rt.False)
@@ -67,14 +297,56 @@ func (self *DictObject) OP__iter__(rt *Runtime, args ...Object) (results []Objec
 }
 
 func (self *DictObject) OP__get_index__(rt *Runtime, args ...Object) (results []Object) {
-	idx := args[0]
-	results = append(results, self.GetProp(idx))
+	val, ok := self.get(args[0])
+	if !ok {
+		rt.Fatalf("key %s not found in %s", args[0], self)
+	}
+	results = append(results, val)
 	return
 }
 
 func (self *DictObject) OP__set_index__(rt *Runtime, args ...Object) (results []Object) {
-	idx := args[0]
-	val := args[1]
-	self.SetProp(idx, val)
+	rt.checkFrozen(self)
+	self.put(args[0], args[1])
+	return
+}
+
+/// dict iterator
+
+// what a range loop or a comprehension walks instead of a dict
+type dictIter struct {
+	Property
+
+	dict *DictObject
+	next func() (Slot, bool)
+}
+
+func (self *Runtime) newDictIter(dict *DictObject) *dictIter {
+	return &dictIter{MakeProperty(nil, &self.dictIterProperties), dict, dict.walk()}
+}
+
+func (self *dictIter) Name() string {
+	return "dict"
+}
+
+func (self *dictIter) HashCode() string {
+	return fmt.Sprintf("%p", self)
+}
+
+func (self *dictIter) String() string {
+	return self.dict.String()
+}
+
+func (self *dictIter) ToString(rt *Runtime, args ...Object) []Object {
+	return []Object{rt.NewStringObject(self.String())}
+}
+
+func (self *dictIter) OP__iter__(rt *Runtime, args ...Object) (results []Object) {
+	slot, ok := self.next()
+	if ok {
+		results = append(results, slot.Key, slot.Val, rt.True)
+	} else {
+		results = append(results, rt.False)
+	}
 	return
 }
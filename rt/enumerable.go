@@ -0,0 +1,451 @@
+package rt
+
+import (
+	"strings"
+)
+
+/// enumerable
+
+// Enumerable is mixed into the collection objects, the embedding object is
+// its owner. Its elements are the values of arrays, sets and tuples and
+// [key, value] pairs for dicts. Methods taking a function accept closures,
+// go functions and builtin methods.
+type Enumerable struct {
+	owner Object
+}
+
+// all the elements at once, for the methods whose result holds them all
+// anyway. the others walk them with each
+func (self *Enumerable) elems(rt *Runtime) []Object {
+	switch obj := self.owner.(type) {
+	case *ArrayObject:
+		return obj.Vals
+	case *SetObject:
+		return obj.Vals
+	case *TupleObject:
+		return obj.Vals
+	case *RangeObject:
+		return obj.vals(rt)
+	case *DictObject:
+		pairs := make([]Object, len(obj.slots()))
+		for i, slot := range obj.slots() {
+			pairs[i] = rt.NewArrayObject([]Object{slot.Key, slot.Val})
+		}
+		return pairs
+	}
+	rt.Fatalf("%s is not enumerable", self.owner.Name())
+	return nil
+}
+
+// calls fn with the elements in order until it returns false. a range gives
+// its integers one at a time, it is never built as a whole
+func (self *Enumerable) each(rt *Runtime, fn func(i int, elem Object) bool) {
+	if r, ok := self.owner.(*RangeObject); ok {
+		for i := 0; i < r.size(); i++ {
+			if !fn(i, rt.NewIntegerObject(r.Lo+i)) {
+				return
+			}
+		}
+		return
+	}
+	for i, elem := range self.elems(rt) {
+		if !fn(i, elem) {
+			return
+		}
+	}
+}
+
+func (self *Enumerable) size(rt *Runtime) int {
+	if r, ok := self.owner.(*RangeObject); ok {
+		return r.size()
+	}
+	return len(self.elems(rt))
+}
+
+// the elements of an argument, which may be any enumerable
+func (self *Runtime) enumArg(method string, arg Object) []Object {
+	enum, ok := arg.(interface {
+		elems(*Runtime) []Object
+	})
+	if !ok {
+		self.Fatalf("enumerable::%s needs an enumerable, %s given", method, arg.Name())
+	}
+	return enum.elems(self)
+}
+
+// Call calls a closure, go function or builtin method and returns its
+// first result, nil when there is none
+func (self *Runtime) Call(fn Object, args ...Object) Object {
+	rets := self.CallAll(fn, args...)
+	if len(rets) == 0 {
+		return self.Nil
+	}
+	return rets[0]
+}
+
+// only nil and false are false, conditions in the vm use it too
+func Truthy(obj Object) bool {
+	switch obj := obj.(type) {
+	case *NilObject:
+		return false
+	case *BoolObject:
+		return obj.Val
+	}
+	return true
+}
+
+// whether the optional predicate holds for an element, or it is truthy
+func holds(rt *Runtime, args []Object, elem Object) bool {
+	if len(args) > 0 {
+		return Truthy(rt.Call(args[0], elem))
+	}
+	return Truthy(elem)
+}
+
+// the elements for which the optional predicate holds, or which are truthy,
+// and the others
+func (self *Enumerable) split(rt *Runtime, args []Object) (yes, no []Object) {
+	yes, no = []Object{}, []Object{}
+	self.each(rt, func(i int, elem Object) bool {
+		if holds(rt, args, elem) {
+			yes = append(yes, elem)
+		} else {
+			no = append(no, elem)
+		}
+		return true
+	})
+	return
+}
+
+// the first element for which holds gives want, Any, All and None stop there
+func (self *Enumerable) seek(rt *Runtime, args []Object, want bool) bool {
+	found := false
+	self.each(rt, func(i int, elem Object) bool {
+		found = holds(rt, args, elem) == want
+		return !found
+	})
+	return found
+}
+
+// Reduce(fn) folds from the first element, Reduce(init, fn) from init
+func (self *Enumerable) Reduce(rt *Runtime, args ...Object) (results []Object) {
+	var acc Object = rt.Nil
+	fn := args[0]
+	first := len(args) == 1
+	if !first {
+		acc, fn = args[0], args[1]
+	}
+	self.each(rt, func(i int, elem Object) bool {
+		if first {
+			acc, first = elem, false
+		} else {
+			acc = rt.Call(fn, acc, elem)
+		}
+		return true
+	})
+	results = append(results, acc)
+	return
+}
+
+func (self *Enumerable) Inject(rt *Runtime, args ...Object) (results []Object) {
+	return self.Reduce(rt, args...)
+}
+
+func (self *Enumerable) Find(rt *Runtime, args ...Object) (results []Object) {
+	var found Object = rt.Nil
+	self.each(rt, func(i int, elem Object) bool {
+		if Truthy(rt.Call(args[0], elem)) {
+			found = elem
+			return false
+		}
+		return true
+	})
+	results = append(results, found)
+	return
+}
+
+func (self *Enumerable) Any(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewBoolObject(self.seek(rt, args, true)))
+	return
+}
+
+func (self *Enumerable) All(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewBoolObject(!self.seek(rt, args, false)))
+	return
+}
+
+func (self *Enumerable) None(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewBoolObject(!self.seek(rt, args, true)))
+	return
+}
+
+// Count() counts every element, Count(fn) those fn holds for and Count(v)
+// those equal to v
+func (self *Enumerable) Count(rt *Runtime, args ...Object) (results []Object) {
+	if len(args) == 0 {
+		results = append(results, rt.NewIntegerObject(self.size(rt)))
+		return
+	}
+	n := 0
+	self.each(rt, func(i int, elem Object) bool {
+		if self.matches(rt, args[0], elem) {
+			n++
+		}
+		return true
+	})
+	results = append(results, rt.NewIntegerObject(n))
+	return
+}
+
+// a function argument is a predicate, any other value is compared
+func (self *Enumerable) matches(rt *Runtime, pat, elem Object) bool {
+	switch pat.(type) {
+	case *ClosureObject, *GoFuncObject, *FuncObject:
+		return Truthy(rt.Call(pat, elem))
+	}
+	return rt.MatchValue(elem, pat)
+}
+
+func (self *Enumerable) Reject(rt *Runtime, args ...Object) (results []Object) {
+	_, no := self.split(rt, args)
+	results = append(results, rt.NewArrayObject(no))
+	return
+}
+
+// [[elements fn holds for], [the others]]
+func (self *Enumerable) Partition(rt *Runtime, args ...Object) (results []Object) {
+	yes, no := self.split(rt, args)
+	pair := []Object{rt.NewArrayObject(yes), rt.NewArrayObject(no)}
+	results = append(results, rt.NewArrayObject(pair))
+	return
+}
+
+// a dict from fn(elem) to the array of elements with that key
+func (self *Enumerable) GroupBy(rt *Runtime, args ...Object) (results []Object) {
+	groups := rt.NewDictObject(nil).(*DictObject)
+	self.each(rt, func(i int, elem Object) bool {
+		key := rt.Call(args[0], elem)
+		group, ok := groups.get(key)
+		if !ok {
+			group = rt.NewArrayObject(nil)
+			groups.put(key, group)
+		}
+		arr := group.(*ArrayObject)
+		arr.Vals = append(arr.Vals, elem)
+		return true
+	})
+	results = append(results, groups)
+	return
+}
+
+// [[a0, b0, ...], [a1, b1, ...], ...], shorter arguments are padded with nil
+func (self *Enumerable) Zip(rt *Runtime, args ...Object) (results []Object) {
+	others := make([][]Object, len(args))
+	for i, arg := range args {
+		others[i] = rt.enumArg("Zip", arg)
+	}
+	rows := []Object{}
+	self.each(rt, func(i int, elem Object) bool {
+		row := []Object{elem}
+		for _, other := range others {
+			if i < len(other) {
+				row = append(row, other[i])
+			} else {
+				row = append(row, rt.Nil)
+			}
+		}
+		rows = append(rows, rt.NewArrayObject(row))
+		return true
+	})
+	results = append(results, rt.NewArrayObject(rows))
+	return
+}
+
+// Flatten() flattens nested arrays completely, Flatten(n) n levels deep
+func (self *Enumerable) Flatten(rt *Runtime, args ...Object) (results []Object) {
+	depth := -1
+	if len(args) > 0 {
+		depth = args[0].(*IntegerObject).Val
+	}
+	var flatten func(elems []Object, depth int) []Object
+	flatten = func(elems []Object, depth int) []Object {
+		vals := []Object{}
+		for _, elem := range elems {
+			if arr, ok := elem.(*ArrayObject); ok && depth != 0 {
+				vals = append(vals, flatten(arr.Vals, depth-1)...)
+			} else {
+				vals = append(vals, elem)
+			}
+		}
+		return vals
+	}
+	results = append(results, rt.NewArrayObject(flatten(self.elems(rt), depth)))
+	return
+}
+
+// the first of the elements that are equal, or have equal fn(elem)
+func (self *Enumerable) Uniq(rt *Runtime, args ...Object) (results []Object) {
+	seen := map[string]bool{}
+	vals := []Object{}
+	self.each(rt, func(i int, elem Object) bool {
+		key := elem
+		if len(args) > 0 {
+			key = rt.Call(args[0], elem)
+		}
+		if !seen[setKey(key)] {
+			seen[setKey(key)] = true
+			vals = append(vals, elem)
+		}
+		return true
+	})
+	results = append(results, rt.NewArrayObject(vals))
+	return
+}
+
+func (self *Enumerable) Reverse(rt *Runtime, args ...Object) (results []Object) {
+	elems := self.elems(rt)
+	vals := make([]Object, len(elems))
+	for i, elem := range elems {
+		vals[len(elems)-1-i] = elem
+	}
+	results = append(results, rt.NewArrayObject(vals))
+	return
+}
+
+// the least element by < on the elements or on fn(elem), nil when empty
+func (self *Enumerable) Min(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, self.extreme(rt, args, false))
+	return
+}
+
+func (self *Enumerable) Max(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, self.extreme(rt, args, true))
+	return
+}
+
+func (self *Enumerable) extreme(rt *Runtime, args []Object, max bool) Object {
+	var best, bestKey Object = rt.Nil, nil
+	self.each(rt, func(i int, elem Object) bool {
+		key := elem
+		if len(args) > 0 {
+			key = rt.Call(args[0], elem)
+		}
+		if bestKey == nil || (max && rt.less(bestKey, key)) || (!max && rt.less(key, bestKey)) {
+			best, bestKey = elem, key
+		}
+		return true
+	})
+	return best
+}
+
+// Sum() adds the elements to 0, Sum(init) to init
+func (self *Enumerable) Sum(rt *Runtime, args ...Object) (results []Object) {
+	var acc Object = rt.NewIntegerObject(0)
+	if len(args) > 0 {
+		acc = args[0]
+	}
+	self.each(rt, func(i int, elem Object) bool {
+		acc = Invoke(rt, acc, "__add__", elem)[0]
+		return true
+	})
+	results = append(results, acc)
+	return
+}
+
+// a stable sort on fn(elem)
+func (self *Enumerable) SortBy(rt *Runtime, args ...Object) (results []Object) {
+	vals := append([]Object{}, self.elems(rt)...)
+	keys := make([]Object, len(vals))
+	for i, val := range vals {
+		keys[i] = rt.Call(args[0], val)
+	}
+	rt.sortKeys(vals, keys)
+	results = append(results, rt.NewArrayObject(vals))
+	return
+}
+
+// a sorted array of the elements, see Array.Sort for the optional function
+func (self *Enumerable) Sorted(rt *Runtime, args ...Object) (results []Object) {
+	vals := append([]Object{}, self.elems(rt)...)
+	rt.sortObjects(vals, sortFunc(args))
+	results = append(results, rt.NewArrayObject(vals))
+	return
+}
+
+// calls fn(elem, index) for every element
+func (self *Enumerable) EachWithIndex(rt *Runtime, args ...Object) (results []Object) {
+	self.each(rt, func(i int, elem Object) bool {
+		rt.Call(args[0], elem, rt.NewIntegerObject(i))
+		return true
+	})
+	results = append(results, self.owner)
+	return
+}
+
+// EachSlice(n) splits the elements into arrays of n, EachSlice(n, fn) calls
+// fn with each of them
+func (self *Enumerable) EachSlice(rt *Runtime, args ...Object) (results []Object) {
+	n, ok := args[0].(*IntegerObject)
+	if !ok || n.Val <= 0 {
+		rt.Fatalf("enumerable::EachSlice needs a positive slice size, %s given", args[0])
+	}
+	elems := self.elems(rt)
+	slices := []Object{}
+	for i := 0; i < len(elems); i += n.Val {
+		end := i + n.Val
+		if end > len(elems) {
+			end = len(elems)
+		}
+		slice := rt.NewArrayObject(append([]Object{}, elems[i:end]...))
+		if len(args) > 1 {
+			rt.Call(args[1], slice)
+		}
+		slices = append(slices, slice)
+	}
+	if len(args) > 1 {
+		results = append(results, self.owner)
+	} else {
+		results = append(results, rt.NewArrayObject(slices))
+	}
+	return
+}
+
+// the strings of the elements joined by the optional separator
+func (self *Enumerable) Join(rt *Runtime, args ...Object) (results []Object) {
+	sep := ""
+	if len(args) > 0 {
+		sep = args[0].String()
+	}
+	elems := self.elems(rt)
+	strs := make([]string, len(elems))
+	for i, elem := range elems {
+		strs[i] = elem.String()
+	}
+	results = append(results, rt.NewStringObject(strings.Join(strs, sep)))
+	return
+}
+
+func (self *Enumerable) Include(rt *Runtime, args ...Object) (results []Object) {
+	found := false
+	self.each(rt, func(i int, elem Object) bool {
+		found = rt.MatchValue(elem, args[0])
+		return !found
+	})
+	results = append(results, rt.NewBoolObject(found))
+	return
+}
+
+// the index of the first element equal to the argument or, for a function,
+// the first one it holds for. -1 when there is none
+func (self *Enumerable) IndexOf(rt *Runtime, args ...Object) (results []Object) {
+	idx := -1
+	self.each(rt, func(i int, elem Object) bool {
+		if self.matches(rt, args[0], elem) {
+			idx = i
+			return false
+		}
+		return true
+	})
+	results = append(results, rt.NewIntegerObject(idx))
+	return
+}
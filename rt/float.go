@@ -2,6 +2,7 @@ package rt
 
 import (
 	"fmt"
+	"math"
 )
 
 /// float
@@ -37,27 +38,52 @@ func (self *FloatObject) Abs(rt *Runtime, args ...Object) (results []Object) {
 	return
 }
 
-// +=
-func (self *FloatObject) OP__add_assign__(rt *Runtime, args ...Object) (results []Object) {
-	results = self.assign(rt, "__+=__", args[0])
+func (self *FloatObject) Pow(rt *Runtime, args ...Object) (results []Object) {
+	return self.OP__pow__(rt, args...)
+}
+
+// [q, r] with r = x % y, the remainder of the truncated division, and q the
+// integral float with q * y + r == x
+func (self *FloatObject) DivMod(rt *Runtime, args ...Object) (results []Object) {
+	y := rt.floatArg("float::DivMod", args[0])
+	r := math.Mod(self.Val, y)
+	q := math.Trunc((self.Val - r) / y)
+	results = append(results, rt.NewArrayObject([]Object{rt.NewFloatObject(q), rt.NewFloatObject(r)}))
 	return
 }
 
-// -=
-func (self *FloatObject) OP__sub_assign__(rt *Runtime, args ...Object) (results []Object) {
-	results = self.assign(rt, "__-=__", args[0])
+// Round(), Floor() and Ceil() give integers, Round(n) a float rounded to n
+// decimal places, half away from zero
+func (self *FloatObject) Round(rt *Runtime, args ...Object) (results []Object) {
+	return rt.roundFloatObject("float::Round", self.Val, "round", args)
+}
+
+func (self *FloatObject) Floor(rt *Runtime, args ...Object) (results []Object) {
+	return rt.roundFloatObject("float::Floor", self.Val, "floor", args)
+}
+
+func (self *FloatObject) Ceil(rt *Runtime, args ...Object) (results []Object) {
+	return rt.roundFloatObject("float::Ceil", self.Val, "ceil", args)
+}
+
+func (self *FloatObject) ToF(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, self)
 	return
 }
 
-// *=
-func (self *FloatObject) OP__mul_assign__(rt *Runtime, args ...Object) (results []Object) {
-	results = self.assign(rt, "__*=__", args[0])
+// the integer part, a bigint when it does not fit int64
+func (self *FloatObject) ToI(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.floatToInteger("float::ToI", self.Val))
 	return
 }
 
-// /=
-func (self *FloatObject) OP__quo_assign__(rt *Runtime, args ...Object) (results []Object) {
-	results = self.assign(rt, "__/=__", args[0])
+func (self *FloatObject) IsNaN(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewBoolObject(math.IsNaN(self.Val)))
+	return
+}
+
+func (self *FloatObject) IsInf(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewBoolObject(math.IsInf(self.Val, 0)))
 	return
 }
 
@@ -91,6 +117,18 @@ func (self *FloatObject) OP__quo__(rt *Runtime, args ...Object) (results []Objec
 	return
 }
 
+// %
+func (self *FloatObject) OP__rem__(rt *Runtime, args ...Object) (results []Object) {
+	results = self.binary(rt, "__rem__", args[0])
+	return
+}
+
+// **
+func (self *FloatObject) OP__pow__(rt *Runtime, args ...Object) (results []Object) {
+	results = self.binary(rt, "__pow__", args[0])
+	return
+}
+
 // ==
 func (self *FloatObject) OP__eql__(rt *Runtime, args ...Object) (results []Object) {
 	results = self.binary(rt, "__eql__", args[0])
@@ -127,34 +165,11 @@ func (self *FloatObject) OP__leq__(rt *Runtime, args ...Object) (results []Objec
 	return
 }
 
-func (self *FloatObject) assign(rt *Runtime, method string, obj Object) (results []Object) {
-	var val float64
-
-	switch arg := obj.(type) {
-	case *IntegerObject:
-		val = float64(arg.Val)
-	case *FloatObject:
-		val = arg.Val
-	}
-
-	switch method {
-	case "__+=__":
-		self.Val += val
-		results = append(results, rt.NewFloatObject(val))
-	case "__-=__":
-		self.Val -= val
-		results = append(results, rt.NewFloatObject(val))
-	case "__*=__":
-		self.Val *= val
-		results = append(results, rt.NewFloatObject(val))
-	case "__/=__":
-		self.Val /= val
-		results = append(results, rt.NewFloatObject(val))
+func (self *FloatObject) binary(rt *Runtime, method string, obj Object) (results []Object) {
+	if _, ok := obj.(*ComplexObject); ok {
+		return complexBinary(rt, method, complex(self.Val, 0), obj)
 	}
-	return
-}
 
-func (self *FloatObject) binary(rt *Runtime, method string, obj Object) (results []Object) {
 	var val float64
 
 	switch arg := obj.(type) {
@@ -162,6 +177,17 @@ func (self *FloatObject) binary(rt *Runtime, method string, obj Object) (results
 		val = float64(arg.Val)
 	case *FloatObject:
 		val = arg.Val
+	case *BigIntObject:
+		val = bigToFloat(arg.Val)
+	default:
+		switch method {
+		case "__eql__":
+			return append(results, rt.False)
+		case "__neq__":
+			return append(results, rt.True)
+		}
+		rt.Fatalf("float::%s unsupported operand %s", method, obj.Name())
+		return
 	}
 
 	switch method {
@@ -177,6 +203,12 @@ func (self *FloatObject) binary(rt *Runtime, method string, obj Object) (results
 	case "__quo__":
 		val = self.Val / val
 		results = append(results, rt.NewFloatObject(val))
+	case "__rem__":
+		val = math.Mod(self.Val, val)
+		results = append(results, rt.NewFloatObject(val))
+	case "__pow__":
+		val = math.Pow(self.Val, val)
+		results = append(results, rt.NewFloatObject(val))
 	case "__eql__":
 		cmp := self.Val == val
 		results = append(results, rt.NewBoolObject(cmp))
@@ -195,6 +227,8 @@ func (self *FloatObject) binary(rt *Runtime, method string, obj Object) (results
 	case "__neq__":
 		cmp := self.Val != val
 		results = append(results, rt.NewBoolObject(cmp))
+	default:
+		rt.Fatalf("float::%s not supported", method)
 	}
 	return
 }
@@ -10,6 +10,7 @@ import (
 
 type ClosureRunner interface {
 	RunClosure(obj *ClosureObject)
+	RunGenerator(gen *GeneratorObject)
 }
 
 type ClosureObject struct {
@@ -28,7 +29,7 @@ func (self *ClosureObject) HashCode() string {
 }
 
 func (self *ClosureObject) String() string {
-	return fmt.Sprintf("closure#%d", self.Proto.Seq)
+	return fmt.Sprintf("closure#%d", self.Proto.Seq())
 }
 
 func (self *ClosureObject) ToString(rt *Runtime, args ...Object) []Object {
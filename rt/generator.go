@@ -0,0 +1,167 @@
+package rt
+
+import (
+	"fmt"
+	"runtime"
+)
+
+/// generator
+
+// A generator runs the body of a closure containing 'yield' as a coroutine.
+// The body lives in its own goroutine with its own operand stack, control is
+// handed back and forth through channels so only one side runs at a time.
+type GeneratorObject struct {
+	Property
+
+	fn      *ClosureObject
+	stack   *Stack
+	started bool
+	done    bool
+	resume  chan bool
+	yield   chan Object
+	// where the body stopped, the caller's position is restored on a yield
+	pos int
+	// a panic of the body, raised again on the side that resumed it
+	failed interface{}
+}
+
+func (self *GeneratorObject) Name() string {
+	return "generator"
+}
+
+func (self *GeneratorObject) HashCode() string {
+	return fmt.Sprintf("%p", self)
+}
+
+func (self *GeneratorObject) String() string {
+	return fmt.Sprintf("generator#%d", self.fn.Proto.Seq())
+}
+
+func (self *GeneratorObject) ToString(rt *Runtime, args ...Object) []Object {
+	return []Object{rt.NewStringObject(self.String())}
+}
+
+func (self *GeneratorObject) Closure() *ClosureObject {
+	return self.fn
+}
+
+// run the body until the next yield, returns false when the body finished
+func (self *GeneratorObject) Resume(rt *Runtime) (Object, bool) {
+	if self.done {
+		return nil, false
+	}
+
+	saved, pos := rt.Stack, rt.Pos
+	rt.Stack = self.stack
+	rt.Pos = self.pos
+	if !self.started {
+		self.started = true
+		go func() {
+			defer close(self.yield)
+			defer func() {
+				self.failed = recover()
+			}()
+			rt.Runner.RunGenerator(self)
+		}()
+	} else {
+		self.resume <- true
+	}
+	val, ok := <-self.yield
+	self.pos = rt.Pos
+	rt.Stack, rt.Pos = saved, pos
+
+	if !ok {
+		self.done = true
+		if self.failed != nil {
+			panic(self.failed)
+		}
+	}
+	return val, ok
+}
+
+// called by the vm in the generator goroutine, blocks until resumed. a
+// closed generator ends its goroutine here
+func (self *GeneratorObject) Yield(val Object) {
+	self.yield <- val
+	if !<-self.resume {
+		runtime.Goexit()
+	}
+}
+
+// stops a generator waiting in a yield, it never runs again
+func (self *GeneratorObject) stop() {
+	if self.started && !self.done {
+		self.resume <- false
+		for range self.yield {
+		}
+	}
+	self.done = true
+}
+
+// a loop over a dict walks a snapshot, the body may change the dict
+func (rt *Runtime) OpenIter(obj Object) Object {
+	if dict, ok := obj.(*DictObject); ok {
+		return rt.newDictIter(dict)
+	}
+	return obj
+}
+
+// a range loop or a lazy chain that stops before the end closes what it
+// iterates, so a generator left behind doesn't keep its goroutine
+func (rt *Runtime) CloseIter(obj Object) {
+	switch obj := obj.(type) {
+	case *GeneratorObject:
+		obj.stop()
+	case *LazyObject:
+		obj.next = nil
+		if obj.src != nil {
+			rt.CloseIter(obj.src)
+		}
+	}
+}
+
+/// methods
+
+func (self *GeneratorObject) Next(rt *Runtime, args ...Object) (results []Object) {
+	val, ok := self.Resume(rt)
+	if ok {
+		results = append(results, val, rt.True)
+	} else {
+		results = append(results, rt.Nil, rt.False)
+	}
+	return
+}
+
+func (self *GeneratorObject) Close(rt *Runtime, args ...Object) (results []Object) {
+	self.stop()
+	return
+}
+
+func (self *GeneratorObject) Lazy(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewLazyObject(self))
+	return
+}
+
+func (self *GeneratorObject) ToArray(rt *Runtime, args ...Object) (results []Object) {
+	vals := []Object{}
+	for {
+		val, ok := self.Resume(rt)
+		if !ok {
+			break
+		}
+		vals = append(vals, val)
+	}
+	results = append(results, rt.NewArrayObject(vals))
+	return
+}
+
+// like a go channel, a generator has no keys, both range variables get the value
+func (self *GeneratorObject) OP__iter__(rt *Runtime, args ...Object) (results []Object) {
+	val, ok := self.Resume(rt)
+	if ok {
+		results = append(results, val, val, rt.True)
+	} else {
+		results = append(results, rt.False)
+	}
+	return
+}
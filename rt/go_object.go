@@ -2,22 +2,62 @@ package rt
 
 import (
 	"fmt"
+	"math/big"
 	"reflect"
 )
 
 /// go object wrapper
 
+// A go value. Struct and array values are boxed, obj then points to the
+// value, so fields and elements can be set and methods with pointer
+// receivers called. the value is copied where go wants one.
 type GoObject struct {
 	Property
-	obj interface{}
+	obj   interface{}
+	boxed bool
 }
 
 func (self *GoObject) Name() string {
 	return "goobj"
 }
 
+// the wrapped value, a copy of a boxed one
+func (self *GoObject) value() interface{} {
+	if self.boxed {
+		return reflect.ValueOf(self.obj).Elem().Interface()
+	}
+	return self.obj
+}
+
+// the wrapped value for a go parameter of type typ, nil when it is unknown.
+// pointers are followed where go wants the value, a boxed value is passed
+// by pointer where only the pointer fits
+func (self *GoObject) valueFor(typ reflect.Type) reflect.Value {
+	v := reflect.ValueOf(self.obj)
+	if typ == nil || (typ.Kind() == reflect.Interface && typ.NumMethod() == 0) {
+		return reflect.ValueOf(self.value())
+	}
+	if self.boxed && v.Elem().Type().AssignableTo(typ) {
+		return v.Elem()
+	}
+	if v.Type().AssignableTo(typ) {
+		return v
+	}
+	if v.Kind() == reflect.Ptr && !v.IsNil() && v.Elem().Type().AssignableTo(typ) {
+		return v.Elem()
+	}
+	if v.Type().ConvertibleTo(typ) {
+		return v.Convert(typ)
+	}
+	return v
+}
+
 func (self *GoObject) String() string {
-	return fmt.Sprint(self.obj)
+	switch self.obj.(type) {
+	case fmt.Stringer, error:
+		return fmt.Sprint(self.obj)
+	}
+	return fmt.Sprint(self.value())
 }
 
 func (self *GoObject) ToString(rt *Runtime, args ...Object) []Object {
@@ -31,18 +71,139 @@ func (self *GoObject) HashCode() string {
 func (self *GoObject) OP__eql__(rt *Runtime, args ...Object) (results []Object) {
 	gobj, ok := args[0].(*GoObject)
 	if ok {
-		boolObj := rt.NewBoolObject(self.obj == gobj)
+		boolObj := rt.NewBoolObject(self.equal(gobj))
 		results = append(results, boolObj)
 	} else {
 		_, ok = args[0].(*NilObject)
 		if ok {
-			boolObj := rt.NewBoolObject(self.obj == nil)
+			boolObj := rt.NewBoolObject(self.isNil())
 			results = append(results, boolObj)
 		} else {
-			panic("OP__eql__ on GoObject")
+			// go strings and numbers compare as doby values
+			val := rt.goResult(reflect.ValueOf(self.obj))
+			if _, ok := val.(*GoObject); !ok {
+				return Invoke(rt, val, "__eql__", args[0])
+			}
+			results = append(results, rt.False)
+		}
+	}
+
+	return
+}
+
+// a nil slice, map or pointer is nil too
+func (self *GoObject) isNil() bool {
+	v := reflect.ValueOf(self.obj)
+	switch v.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
+		return v.IsNil()
+	}
+	return false
+}
+
+// go values compare like go does, slices, maps and funcs only to themselves
+func (self *GoObject) equal(other *GoObject) bool {
+	if self == other {
+		return true
+	}
+	t := reflect.TypeOf(self.obj)
+	if t != nil && !t.Comparable() {
+		return false
+	}
+	return self.obj == other.obj
+}
+
+// an exported field of a struct, or of the struct a pointer points to,
+// named by a symbol or a string
+func (self *GoObject) field(name Object) (reflect.Value, bool) {
+	var fname string
+	switch k := name.(type) {
+	case *SymbolObject:
+		fname = k.Val
+	case *StringObject:
+		fname = k.Val
+	default:
+		return reflect.Value{}, false
+	}
+	v := reflect.Indirect(reflect.ValueOf(self.obj))
+	if !v.IsValid() || v.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+	if f, ok := v.Type().FieldByName(fname); !ok || f.PkgPath != "" {
+		return reflect.Value{}, false
+	}
+	return v.FieldByName(fname), true
+}
+
+func (self *GoObject) setField(rt *Runtime, f reflect.Value, name Object, val Object) {
+	if !f.CanSet() {
+		rt.Fatalf("cannot set field %s of %s, it is not addressable", name, reflect.TypeOf(self.obj))
+	}
+	f.Set(rt.goValue(val, f.Type()))
+}
+
+// the slice, array or map to index, arrays through their pointer so their
+// elements can be set
+func (self *GoObject) container(rt *Runtime, op string) reflect.Value {
+	v := reflect.ValueOf(self.obj)
+	if v.Kind() == reflect.Ptr && !v.IsNil() && v.Elem().Kind() == reflect.Array {
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return v
+	}
+	rt.Fatalf("goobj::%s %s is not a slice, array or map", op, reflect.TypeOf(self.obj))
+	return v
+}
+
+// a missing key of a go map gives the zero value like in go
+func (self *GoObject) OP__get_index__(rt *Runtime, args ...Object) (results []Object) {
+	v := self.container(rt, "[]")
+	if v.Kind() == reflect.Map {
+		elem := v.MapIndex(rt.goValue(args[0], v.Type().Key()))
+		if !elem.IsValid() {
+			elem = reflect.Zero(v.Type().Elem())
 		}
+		results = append(results, rt.goResult(elem))
+	} else {
+		results = append(results, rt.goResult(v.Index(rt.index(args[0], v.Len()))))
 	}
+	return
+}
 
+func (self *GoObject) OP__set_index__(rt *Runtime, args ...Object) (results []Object) {
+	rt.checkFrozen(self)
+	v := self.container(rt, "[]=")
+	if v.Kind() == reflect.Map {
+		if v.IsNil() {
+			rt.Fatalf("goobj::[]= assignment to entry in nil map")
+		}
+		v.SetMapIndex(rt.goValue(args[0], v.Type().Key()), rt.goValue(args[1], v.Type().Elem()))
+	} else {
+		elem := v.Index(rt.index(args[0], v.Len()))
+		if !elem.CanSet() {
+			rt.Fatalf("goobj::[]= %s is not addressable", v.Type())
+		}
+		elem.Set(rt.goValue(args[1], elem.Type()))
+	}
+	return
+}
+
+// ranges over the elements of go slices and arrays
+func (self *GoObject) OP__iter__(rt *Runtime, args ...Object) (results []Object) {
+	v := self.container(rt, "range")
+	if v.Kind() == reflect.Map {
+		rt.Fatalf("goobj::range cannot range over go map %s", v.Type())
+	}
+	idx := args[0].(*IntegerObject)
+	if idx.Val < v.Len() {
+		results = append(results, args[0], rt.goResult(v.Index(idx.Val)), rt.True)
+	} else {
+		results = append(results, rt.False)
+	}
 	return
 }
 
@@ -55,12 +216,21 @@ type GoFuncObject struct {
 	fn   interface{}
 }
 
+// NativeFunc is a go function of a native module, it gets the objects as
+// they are instead of converted go values
+type NativeFunc func(rt *Runtime, args ...Object) []Object
+
 func (self *GoFuncObject) CallGoFunc(rt *Runtime, args ...Object) (results []Object) {
+	if fn, ok := self.fn.(NativeFunc); ok {
+		return fn(rt, args...)
+	}
+
 	inNum := self.typ.NumIn()
 	inArgs := []reflect.Value{}
 
 	dummy := []interface{}{}
-	for i := 0; i < inNum && i < len(args); i++ {
+	variadic := self.typ.IsVariadic()
+	for i := 0; i < len(args) && (i < inNum || variadic); i++ {
 		arg := args[i]
 		if i == inNum-1 && self.typ.In(i) == reflect.TypeOf(dummy) {
 			for j := i; j < len(args); j++ {
@@ -72,6 +242,15 @@ func (self *GoFuncObject) CallGoFunc(rt *Runtime, args ...Object) (results []Obj
 				case *FloatObject:
 					v := reflect.ValueOf(arg.Val)
 					inArgs = append(inArgs, v)
+				case *BigIntObject:
+					v, _ := bigIntToValue(arg, nil)
+					inArgs = append(inArgs, v)
+				case *ComplexObject:
+					v := reflect.ValueOf(arg.Val)
+					inArgs = append(inArgs, v)
+				case *BytesObject:
+					v := reflect.ValueOf(arg.Val)
+					inArgs = append(inArgs, v)
 				case *StringObject:
 					v := reflect.ValueOf(arg.Val)
 					inArgs = append(inArgs, v)
@@ -83,85 +262,129 @@ func (self *GoFuncObject) CallGoFunc(rt *Runtime, args ...Object) (results []Obj
 						var nilObj *NilObject
 						inArgs = append(inArgs, reflect.ValueOf(nilObj))
 					} else {
-						v := reflect.ValueOf(arg.obj)
-						inArgs = append(inArgs, v)
+						inArgs = append(inArgs, reflect.ValueOf(arg.value()))
 					}
 				default:
 					inArgs = append(inArgs, reflect.ValueOf(arg))
 				}
 			}
+			break
 		} else {
+			in := self.paramType(i)
 			switch arg := arg.(type) {
 			case *IntegerObject:
 				v := reflect.ValueOf(arg.Val)
 				t := reflect.TypeOf(arg.Val)
-				if t.ConvertibleTo(self.typ.In(i)) {
-					v = v.Convert(self.typ.In(i))
+				if t.ConvertibleTo(in) {
+					v = v.Convert(in)
+				} else if in == reflect.TypeOf((*big.Int)(nil)) {
+					v = reflect.ValueOf(big.NewInt(int64(arg.Val)))
 				}
 				inArgs = append(inArgs, v)
 			case *FloatObject:
 				v := reflect.ValueOf(arg.Val)
 				t := reflect.TypeOf(arg.Val)
-				if t.ConvertibleTo(self.typ.In(i)) {
-					v = v.Convert(self.typ.In(i))
+				if t.ConvertibleTo(in) {
+					v = v.Convert(in)
+				}
+				inArgs = append(inArgs, v)
+			case *BigIntObject:
+				v, ok := bigIntToValue(arg, in)
+				if !ok {
+					rt.Fatalf("%s: %s overflows %s", self.name, arg.Val, in)
+				}
+				inArgs = append(inArgs, v)
+			case *ComplexObject:
+				v := reflect.ValueOf(arg.Val)
+				t := reflect.TypeOf(arg.Val)
+				if t.ConvertibleTo(in) {
+					v = v.Convert(in)
+				}
+				inArgs = append(inArgs, v)
+			case *RuneObject:
+				v := reflect.ValueOf(arg.Val)
+				t := reflect.TypeOf(arg.Val)
+				if t.ConvertibleTo(in) {
+					v = v.Convert(in)
+				}
+				inArgs = append(inArgs, v)
+			case *BytesObject:
+				v := reflect.ValueOf(arg.Val)
+				t := reflect.TypeOf(arg.Val)
+				if t.ConvertibleTo(in) {
+					v = v.Convert(in)
 				}
 				inArgs = append(inArgs, v)
 			case *StringObject:
 				v := reflect.ValueOf(arg.Val)
 				t := reflect.TypeOf(arg.Val)
-				if t.ConvertibleTo(self.typ.In(i)) {
-					v = v.Convert(self.typ.In(i))
+				if t.ConvertibleTo(in) {
+					v = v.Convert(in)
 				}
 				inArgs = append(inArgs, v)
 			case *BoolObject:
 				v := reflect.ValueOf(arg.Val)
 				t := reflect.TypeOf(arg.Val)
-				if t.ConvertibleTo(self.typ.In(i)) {
-					v = v.Convert(self.typ.In(i))
+				if t.ConvertibleTo(in) {
+					v = v.Convert(in)
 				}
 				inArgs = append(inArgs, v)
+			case *ClosureObject, *GoFuncObject, *FuncObject, *GoTypeObject:
+				if in.Kind() == reflect.Func {
+					inArgs = append(inArgs, rt.goFunc(arg, in))
+				} else {
+					inArgs = append(inArgs, reflect.ValueOf(arg))
+				}
 			case *GoObject:
-				v := reflect.ValueOf(arg.obj)
-				t := reflect.TypeOf(arg.obj)
-				if t != nil {
-					if t.ConvertibleTo(self.typ.In(i)) {
-						v = v.Convert(self.typ.In(i))
-					}
-					inArgs = append(inArgs, v)
+				if arg.obj != nil {
+					inArgs = append(inArgs, arg.valueFor(in))
 				} else {
-					typ := self.typ.In(i)
+					typ := in
 					inArgs = append(inArgs, reflect.Zero(typ))
 				}
+			case *ArrayObject:
+				// the elements share the array, sort.Slice sorts it in place
+				if in.Kind() == reflect.Interface {
+					inArgs = append(inArgs, reflect.ValueOf(arg.Vals))
+				} else {
+					inArgs = append(inArgs, rt.goValue(arg, in))
+				}
+			case *DictObject:
+				if in.Kind() == reflect.Interface {
+					inArgs = append(inArgs, reflect.ValueOf(arg))
+				} else {
+					inArgs = append(inArgs, rt.goValue(arg, in))
+				}
 			default:
 				v := reflect.ValueOf(arg)
 				t := reflect.TypeOf(arg)
-				if t.ConvertibleTo(self.typ.In(i)) {
-					v = v.Convert(self.typ.In(i))
+				if t.ConvertibleTo(in) {
+					v = v.Convert(in)
 				}
 				inArgs = append(inArgs, v)
 			}
 		}
 	}
 
+	// callbacks may run doby code while go has the call
+	rt.Unlock()
 	outVals := reflect.ValueOf(self.fn).Call(inArgs)
+	rt.Lock()
 	for _, val := range outVals {
-		switch val.Kind() {
-		case reflect.Bool:
-			results = append(results, rt.NewBoolObject(val.Bool()))
-		case reflect.String:
-			results = append(results, rt.NewStringObject(val.String()))
-		case reflect.Int, reflect.Int16, reflect.Int32, reflect.Int64:
-			results = append(results, rt.NewIntegerObject(int(val.Int())))
-		case reflect.Float64, reflect.Float32:
-			results = append(results, rt.NewFloatObject(val.Float()))
-		default:
-			results = append(results, rt.NewGoObject(val.Interface()))
-		}
+		results = append(results, rt.goResult(val))
 	}
-
 	return
 }
 
+// the type of the i-th argument, the element type for the variadic ones
+func (self *GoFuncObject) paramType(i int) reflect.Type {
+	n := self.typ.NumIn()
+	if self.typ.IsVariadic() && i >= n-1 {
+		return self.typ.In(n - 1).Elem()
+	}
+	return self.typ.In(i)
+}
+
 func (self *GoFuncObject) Name() string {
 	return "gofunc"
 }
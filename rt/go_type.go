@@ -0,0 +1,204 @@
+package rt
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+)
+
+/// go types
+
+// A registered go type. Calling it converts its argument like go does, or
+// gives the zero value without one, T{...} builds a struct, map or slice and
+// new(T) a pointer to a zero T.
+type GoTypeObject struct {
+	Property
+	typ reflect.Type
+}
+
+func (self *GoTypeObject) Name() string {
+	return "gotype"
+}
+
+func (self *GoTypeObject) HashCode() string {
+	return fmt.Sprintf("%p", self)
+}
+
+func (self *GoTypeObject) String() string {
+	return self.typ.String()
+}
+
+func (self *GoTypeObject) ToString(rt *Runtime, args ...Object) []Object {
+	return []Object{rt.NewStringObject(self.String())}
+}
+
+func (self *GoTypeObject) OP__call__(rt *Runtime, args ...Object) (results []Object) {
+	switch len(args) {
+	case 0:
+		results = append(results, rt.goResult(reflect.New(self.typ).Elem()))
+	case 1:
+		results = append(results, rt.goResult(rt.goValue(args[0], self.typ)))
+	default:
+		rt.Fatalf("%s() takes one argument, %d given", self.typ, len(args))
+	}
+	return
+}
+
+// T{k: v} passes a dict, T{a, b} an array
+func (self *GoTypeObject) OP__composite__(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.goResult(rt.compose(self.typ, args[0])))
+	return
+}
+
+func (self *Runtime) NewGoTypeObject(typ reflect.Type) *GoTypeObject {
+	return &GoTypeObject{MakeProperty(nil, &self.gotypeProperties), typ}
+}
+
+// RegisterTypes adds go types to a module under their names, a type is given
+// by a value of it or, for any type, a nil pointer to it like (*T)(nil)
+func (self *Runtime) RegisterTypes(name string, types []interface{}) {
+	mod := self.module(name)
+
+	for _, v := range types {
+		typ := reflect.TypeOf(v)
+		if typ.Kind() == reflect.Ptr && reflect.ValueOf(v).IsNil() {
+			typ = typ.Elem()
+		}
+		mod.put(self.Intern(typ.Name()), self.NewGoTypeObject(typ))
+	}
+}
+
+// compose builds a go value of type typ from the fields or keys of a dict,
+// or from the elements of an array. structs take their fields in order
+func (self *Runtime) compose(typ reflect.Type, obj Object) reflect.Value {
+	v := reflect.New(typ).Elem()
+	switch src := obj.(type) {
+	case *DictObject:
+		switch typ.Kind() {
+		case reflect.Struct:
+			for _, slot := range src.slots() {
+				f := v.FieldByName(slot.Key.String())
+				if !f.IsValid() || !f.CanSet() {
+					self.Fatalf("%s has no exported field %s", typ, slot.Key)
+				}
+				f.Set(self.goValue(slot.Val, f.Type()))
+			}
+			return v
+		case reflect.Map:
+			v.Set(reflect.MakeMapWithSize(typ, len(src.slots())))
+			for _, slot := range src.slots() {
+				v.SetMapIndex(self.goValue(slot.Key, typ.Key()), self.goValue(slot.Val, typ.Elem()))
+			}
+			return v
+		case reflect.Slice, reflect.Array:
+			// T{} is a dict without fields
+			if len(src.slots()) == 0 {
+				return self.compose(typ, self.NewArrayObject(nil))
+			}
+		}
+	case *ArrayObject:
+		switch typ.Kind() {
+		case reflect.Struct:
+			if len(src.Vals) != typ.NumField() {
+				self.Fatalf("%s{} needs %d fields, %d given", typ, typ.NumField(), len(src.Vals))
+			}
+			for i, val := range src.Vals {
+				if !v.Field(i).CanSet() {
+					self.Fatalf("%s has unexported fields, name the fields to set", typ)
+				}
+				v.Field(i).Set(self.goValue(val, typ.Field(i).Type))
+			}
+			return v
+		case reflect.Slice:
+			v.Set(reflect.MakeSlice(typ, len(src.Vals), len(src.Vals)))
+			for i, val := range src.Vals {
+				v.Index(i).Set(self.goValue(val, typ.Elem()))
+			}
+			return v
+		case reflect.Array:
+			if len(src.Vals) > typ.Len() {
+				self.Fatalf("%s{} takes %d elements, %d given", typ, typ.Len(), len(src.Vals))
+			}
+			for i, val := range src.Vals {
+				v.Index(i).Set(self.goValue(val, typ.Elem()))
+			}
+			return v
+		}
+	}
+	self.Fatalf("cannot make a %s from %s", typ, obj.Name())
+	return v
+}
+
+// goResult converts a go value for doby, numbers, strings and bytes become
+// doby values and everything else is wrapped in a GoObject. a struct which
+// lives in a variable, field or slice element is shared, not copied
+func (self *Runtime) goResult(val reflect.Value) Object {
+	switch val.Kind() {
+	case reflect.Bool:
+		return self.NewBoolObject(val.Bool())
+	case reflect.String:
+		return self.NewStringObject(val.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return self.goIntToObject(val)
+	case reflect.Float64, reflect.Float32:
+		return self.NewFloatObject(val.Float())
+	case reflect.Complex128, reflect.Complex64:
+		return self.NewComplexObject(val.Complex())
+	case reflect.Slice:
+		if val.Type().Elem().Kind() == reflect.Uint8 {
+			return self.NewBytesObject(val.Bytes())
+		}
+	case reflect.Struct, reflect.Array:
+		if val.CanAddr() {
+			return self.goObject(val.Addr(), true)
+		}
+	case reflect.Interface:
+		if !val.IsNil() {
+			return self.goResult(val.Elem())
+		}
+	case reflect.Invalid:
+		return self.NewGoObject(nil)
+	}
+	if b, ok := val.Interface().(*big.Int); ok && b != nil {
+		return self.NewInteger(new(big.Int).Set(b))
+	}
+	return self.NewGoObject(val.Interface())
+}
+
+/// builtin functions
+
+// new(T) is a pointer to a new zero T
+func goNew(rt *Runtime, args ...Object) []Object {
+	if len(args) != 1 {
+		rt.Fatalf("new takes one go type, %d arguments given", len(args))
+	}
+	t, ok := args[0].(*GoTypeObject)
+	if !ok {
+		rt.Fatalf("new needs a go type, %s given", args[0].Name())
+	}
+	return []Object{rt.NewGoObject(reflect.New(t.typ).Interface())}
+}
+
+// append(s, x...) appends to a go slice like go does, it gives a new array
+// for a doby array
+func goAppend(rt *Runtime, args ...Object) []Object {
+	if len(args) > 0 {
+		switch s := args[0].(type) {
+		case *ArrayObject:
+			vals := append(append([]Object{}, s.Vals...), args[1:]...)
+			return []Object{rt.NewArrayObject(vals)}
+		case *GoObject:
+			v := reflect.ValueOf(s.obj)
+			if v.Kind() == reflect.Slice {
+				for _, arg := range args[1:] {
+					v = reflect.Append(v, rt.goValue(arg, v.Type().Elem()))
+				}
+				return []Object{rt.NewGoObject(v.Interface())}
+			}
+		}
+		rt.Fatalf("append needs a slice, %s given", args[0].Name())
+	}
+	rt.Fatalf("append needs a slice")
+	return nil
+}
@@ -2,6 +2,8 @@ package rt
 
 import (
 	"fmt"
+	"math"
+	"math/big"
 )
 
 /// integer
@@ -38,6 +40,10 @@ func (self *IntegerObject) Times(rt *Runtime, args ...Object) (results []Object)
 
 func (self *IntegerObject) Abs(rt *Runtime, args ...Object) (results []Object) {
 	val := self.Val
+	if val == math.MinInt64 {
+		results = append(results, rt.NewInteger(new(big.Int).Neg(big.NewInt(int64(val)))))
+		return
+	}
 	if val < 0 {
 		val = 0 - val
 	}
@@ -45,85 +51,49 @@ func (self *IntegerObject) Abs(rt *Runtime, args ...Object) (results []Object) {
 	return
 }
 
-func (self *IntegerObject) OP__inc__(rt *Runtime, args ...Object) (results []Object) {
-	self.Val++
-	return
-}
-
-func (self *IntegerObject) OP__dec__(rt *Runtime, args ...Object) (results []Object) {
-	self.Val--
-	return
+func (self *IntegerObject) Pow(rt *Runtime, args ...Object) (results []Object) {
+	return self.OP__pow__(rt, args...)
 }
 
-func (self *IntegerObject) OP__minus__(rt *Runtime, args ...Object) (results []Object) {
-	val := -self.Val
-	rt.Push(rt.NewIntegerObject(val))
-	return
+func (self *IntegerObject) DivMod(rt *Runtime, args ...Object) (results []Object) {
+	return rt.divMod(big.NewInt(int64(self.Val)), args[0])
 }
 
-// +=
-func (self *IntegerObject) OP__add_assign__(rt *Runtime, args ...Object) (results []Object) {
-	results = self.assign(rt, "__+=__", args[0])
-	return
+func (self *IntegerObject) Gcd(rt *Runtime, args ...Object) (results []Object) {
+	return rt.gcd(big.NewInt(int64(self.Val)), args[0])
 }
 
-// -=
-func (self *IntegerObject) OP__sub_assign__(rt *Runtime, args ...Object) (results []Object) {
-	results = self.assign(rt, "__-=__", args[0])
-	return
-}
-
-// *=
-func (self *IntegerObject) OP__mul_assign__(rt *Runtime, args ...Object) (results []Object) {
-	results = self.assign(rt, "__*=__", args[0])
-	return
-}
-
-// /=
-func (self *IntegerObject) OP__quo_assign__(rt *Runtime, args ...Object) (results []Object) {
-	results = self.assign(rt, "__/=__", args[0])
-	return
-}
-
-// %=
-func (self *IntegerObject) OP__rem_assign__(rt *Runtime, args ...Object) (results []Object) {
-	results = self.assign(rt, "__%=__", args[0])
-	return
-}
-
-// &=
-func (self *IntegerObject) OP__and_assign__(rt *Runtime, args ...Object) (results []Object) {
-	results = self.assign(rt, "__&=__", args[0])
-	return
+// Round(), Floor() and Ceil() are the integer itself, Round(-n) rounds to a
+// multiple of 10**n
+func (self *IntegerObject) Round(rt *Runtime, args ...Object) (results []Object) {
+	return rt.roundInteger("integer::Round", big.NewInt(int64(self.Val)), "round", args)
 }
 
-// |=
-func (self *IntegerObject) OP__or_assign__(rt *Runtime, args ...Object) (results []Object) {
-	results = self.assign(rt, "__|=__", args[0])
-	return
+func (self *IntegerObject) Floor(rt *Runtime, args ...Object) (results []Object) {
+	return rt.roundInteger("integer::Floor", big.NewInt(int64(self.Val)), "floor", args)
 }
 
-// ^=
-func (self *IntegerObject) OP__xor_assign__(rt *Runtime, args ...Object) (results []Object) {
-	results = self.assign(rt, "__^=__", args[0])
-	return
+func (self *IntegerObject) Ceil(rt *Runtime, args ...Object) (results []Object) {
+	return rt.roundInteger("integer::Ceil", big.NewInt(int64(self.Val)), "ceil", args)
 }
 
-// <<=
-func (self *IntegerObject) OP__shl_assign__(rt *Runtime, args ...Object) (results []Object) {
-	results = self.assign(rt, "__<<=__", args[0])
+func (self *IntegerObject) ToF(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewFloatObject(float64(self.Val)))
 	return
 }
 
-// >>=
-func (self *IntegerObject) OP__shr_assign__(rt *Runtime, args ...Object) (results []Object) {
-	results = self.assign(rt, "__>>=__", args[0])
+func (self *IntegerObject) ToI(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, self)
 	return
 }
 
-// &^=
-func (self *IntegerObject) OP__and_not_assign__(rt *Runtime, args ...Object) (results []Object) {
-	results = self.assign(rt, "__&^=__", args[0])
+func (self *IntegerObject) OP__minus__(rt *Runtime, args ...Object) (results []Object) {
+	if self.Val == math.MinInt64 {
+		rt.Push(rt.NewInteger(new(big.Int).Neg(big.NewInt(int64(self.Val)))))
+		return
+	}
+	val := -self.Val
+	rt.Push(rt.NewIntegerObject(val))
 	return
 }
 
@@ -157,6 +127,12 @@ func (self *IntegerObject) OP__rem__(rt *Runtime, args ...Object) (results []Obj
 	return
 }
 
+// **
+func (self *IntegerObject) OP__pow__(rt *Runtime, args ...Object) (results []Object) {
+	results = self.binary(rt, "__pow__", args[0])
+	return
+}
+
 // &
 func (self *IntegerObject) OP__and__(rt *Runtime, args ...Object) (results []Object) {
 	results = self.binary(rt, "__and__", args[0])
@@ -229,118 +205,109 @@ func (self *IntegerObject) OP__leq__(rt *Runtime, args ...Object) (results []Obj
 	return
 }
 
-func (self *IntegerObject) assign(rt *Runtime, method string, obj Object) (results []Object) {
-	var val float64
-
-	switch arg := obj.(type) {
-	case *IntegerObject:
-		val = float64(arg.Val)
-	case *FloatObject:
-		val = arg.Val
-	}
-
-	switch method {
-	case "__+=__":
-		self.Val += int(val)
-	case "__-=__":
-		self.Val -= int(val)
-	case "__*=__":
-		self.Val *= int(val)
-	case "__/=__":
-		self.Val /= int(val)
-	case "__%=__":
-		self.Val %= int(val)
-	case "__|=__":
-		self.Val |= int(val)
-	case "__&=__":
-		self.Val &= int(val)
-	case "__^=__":
-		self.Val ^= int(val)
-	case "__<<=__":
-		self.Val <<= uint(val)
-	case "__>>=__":
-		self.Val >>= uint(val)
-	case "__&^=___":
-		self.Val &^= int(val)
-	}
-	return
-}
-
+// binary keeps integer arithmetic exact, it promotes to bigint when an
+// operation overflows int64 and to float when the operand is a float
 func (self *IntegerObject) binary(rt *Runtime, method string, obj Object) (results []Object) {
-	var val float64
+	var y int
 
-	isFloat := false
 	switch arg := obj.(type) {
 	case *IntegerObject:
-		val = float64(arg.Val)
+		y = arg.Val
 	case *FloatObject:
-		isFloat = true
-		val = arg.Val
+		return rt.NewFloatObject(float64(self.Val)).binary(rt, method, obj)
+	case *ComplexObject:
+		return complexBinary(rt, method, complex(float64(self.Val), 0), obj)
+	default:
+		return bigBinary(rt, method, big.NewInt(int64(self.Val)), obj)
 	}
 
+	x := self.Val
+	overflow := false
+	var z int
+
 	switch method {
-	// binop
 	case "__add__":
-		val = float64(self.Val) + val
+		z = x + y
+		overflow = (x^z)&(y^z) < 0
 	case "__sub__":
-		val = float64(self.Val) - val
+		z = x - y
+		overflow = (x^y)&(x^z) < 0
 	case "__mul__":
-		val = float64(self.Val) * val
+		z = x * y
+		overflow = x != 0 && (z/x != y || (x == -1 && y == math.MinInt64))
 	case "__quo__":
-		val = float64(self.Val) / val
+		if y == 0 {
+			rt.Fatalf("integer divide by zero")
+		}
+		overflow = x == math.MinInt64 && y == -1
+		if !overflow {
+			z = x / y
+		}
 	case "__rem__":
-		val = float64(self.Val % int(val))
+		if y == 0 {
+			rt.Fatalf("integer divide by zero")
+		}
+		z = x % y
+	case "__pow__":
+		return append(results, bigPow(rt, big.NewInt(int64(x)), big.NewInt(int64(y))))
 	case "__and__":
-		val = float64(self.Val & int(val))
+		z = x & y
 	case "__or__":
-		val = float64(self.Val | int(val))
+		z = x | y
 	case "__xor__":
-		val = float64(self.Val ^ int(val))
+		z = x ^ y
+	case "__and_not__":
+		z = x &^ y
 	case "__shl__":
-		val = float64(uint(self.Val) << uint(val))
+		if y < 0 {
+			rt.Fatalf("invalid shift count %d", y)
+		}
+		overflow = y >= 63 || (x<<uint(y))>>uint(y) != x
+		if !overflow {
+			z = x << uint(y)
+		}
 	case "__shr__":
-		val = float64(uint(self.Val) >> uint(val))
-	case "__and_not__":
-		val = float64(uint(self.Val) &^ uint(val))
+		if y < 0 {
+			rt.Fatalf("invalid shift count %d", y)
+		}
+		z = x >> uint(y)
 	}
 
-	if isFloat {
-		results = append(results, rt.NewFloatObject(val))
-	} else {
-		results = append(results, rt.NewIntegerObject(int(val)))
+	if overflow {
+		return bigBinary(rt, method, big.NewInt(int64(x)), obj)
 	}
+	results = append(results, rt.NewIntegerObject(z))
 	return
 }
 
 func (self *IntegerObject) logic(rt *Runtime, method string, obj Object) (results []Object) {
-	var val float64
+	var y int
 
 	switch arg := obj.(type) {
 	case *IntegerObject:
-		val = float64(arg.Val)
+		y = arg.Val
 	case *FloatObject:
-		val = arg.Val
+		return rt.NewFloatObject(float64(self.Val)).binary(rt, method, obj)
+	case *ComplexObject:
+		return complexBinary(rt, method, complex(float64(self.Val), 0), obj)
+	default:
+		return bigBinary(rt, method, big.NewInt(int64(self.Val)), obj)
 	}
 
+	x := self.Val
 	switch method {
 	case "__eql__":
-		cmp := float64(self.Val) == val
-		results = append(results, rt.NewBoolObject(cmp))
+		results = append(results, rt.NewBoolObject(x == y))
 	case "__lss__":
-		cmp := float64(self.Val) < val
-		results = append(results, rt.NewBoolObject(cmp))
+		results = append(results, rt.NewBoolObject(x < y))
 	case "__gtr__":
-		cmp := float64(self.Val) > val
-		results = append(results, rt.NewBoolObject(cmp))
+		results = append(results, rt.NewBoolObject(x > y))
 	case "__leq__":
-		cmp := float64(self.Val) <= val
-		results = append(results, rt.NewBoolObject(cmp))
+		results = append(results, rt.NewBoolObject(x <= y))
 	case "__geq__":
-		cmp := float64(self.Val) >= val
-		results = append(results, rt.NewBoolObject(cmp))
+		results = append(results, rt.NewBoolObject(x >= y))
 	case "__neq__":
-		cmp := float64(self.Val) != val
-		results = append(results, rt.NewBoolObject(cmp))
+		results = append(results, rt.NewBoolObject(x != y))
 	}
 	return
 }
@@ -0,0 +1,288 @@
+package rt
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/// json module
+
+// jsonReader remembers where the lines of the input start, so that decode
+// errors can be reported with a line and column
+type jsonReader struct {
+	r     io.Reader
+	read  int64
+	lines []int64 // offsets of the newlines read so far
+}
+
+func (self *jsonReader) Read(p []byte) (int, error) {
+	n, err := self.r.Read(p)
+	for i := 0; i < n; i++ {
+		if p[i] == '\n' {
+			self.lines = append(self.lines, self.read+int64(i))
+		}
+	}
+	self.read += int64(n)
+	return n, err
+}
+
+// line and column of a byte offset, both counting from 1
+func (self *jsonReader) position(offset int64) (int, int) {
+	i := sort.Search(len(self.lines), func(i int) bool { return self.lines[i] >= offset })
+	if i == 0 {
+		return 1, int(offset) + 1
+	}
+	return i + 1, int(offset - self.lines[i-1])
+}
+
+// a decoder builds doby values from the tokens of encoding/json, objects
+// become dicts with string keys in document order, integers become integers
+// or bigints and other numbers floats
+type jsonDecoder struct {
+	rt  *Runtime
+	src *jsonReader
+	dec *json.Decoder
+}
+
+// the source of a parse function, a string, bytes or an io.Reader GoObject
+func (self *Runtime) readerArg(fname string, arg Object) io.Reader {
+	var r io.Reader
+	switch arg := arg.(type) {
+	case *StringObject:
+		r = strings.NewReader(arg.Val)
+	case *BytesObject:
+		r = bytes.NewReader(arg.Val)
+	case *GoObject:
+		r, _ = arg.obj.(io.Reader)
+	}
+	if r == nil {
+		self.Fatalf("%s needs a string, bytes or a reader, %s given", fname, arg.Name())
+	}
+	return r
+}
+
+func (self *Runtime) newJSONDecoder(method string, arg Object) *jsonDecoder {
+	src := &jsonReader{r: self.readerArg("json."+method, arg)}
+	dec := json.NewDecoder(src)
+	dec.UseNumber()
+	return &jsonDecoder{self, src, dec}
+}
+
+func (self *jsonDecoder) fail(err error) {
+	offset := self.dec.InputOffset()
+	msg := err.Error()
+	if e, ok := err.(*json.SyntaxError); ok {
+		offset = e.Offset - 1
+	}
+	if err == io.ErrUnexpectedEOF {
+		offset = self.src.read
+		msg = "unexpected end of input"
+	}
+	line, col := self.src.position(offset)
+	self.rt.Fatalf("json: %s at line %d, col %d", msg, line, col)
+}
+
+func (self *jsonDecoder) token() json.Token {
+	tok, err := self.dec.Token()
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		self.fail(err)
+	}
+	return tok
+}
+
+// the next value of the input, false at its end
+func (self *jsonDecoder) next() (Object, bool) {
+	tok, err := self.dec.Token()
+	if err == io.EOF {
+		return nil, false
+	}
+	if err != nil {
+		self.fail(err)
+	}
+	return self.value(tok), true
+}
+
+func (self *jsonDecoder) value(tok json.Token) Object {
+	rt := self.rt
+	switch tok := tok.(type) {
+	case json.Delim:
+		if tok == '[' {
+			vals := []Object{}
+			for self.dec.More() {
+				vals = append(vals, self.value(self.token()))
+			}
+			self.token()
+			return rt.NewArrayObject(vals)
+		}
+		slots := []Slot{}
+		for self.dec.More() {
+			// keys stay strings, interning data would grow the symbol
+			// table without bound
+			key := rt.NewStringObject(self.token().(string))
+			slots = append(slots, Slot{key, self.value(self.token())})
+		}
+		self.token()
+		return rt.NewDictObject(slots)
+	case json.Number:
+		if !strings.ContainsAny(string(tok), ".eE") {
+			if v, ok := new(big.Int).SetString(string(tok), 10); ok {
+				return rt.NewInteger(v)
+			}
+		}
+		v, err := tok.Float64()
+		if err != nil {
+			self.fail(err)
+		}
+		return rt.NewFloatObject(v)
+	case string:
+		return rt.NewStringObject(tok)
+	case bool:
+		return rt.NewBoolObject(tok)
+	}
+	return rt.Nil
+}
+
+// json.Parse(src) decodes the single value of a string, bytes or a reader
+func jsonParse(rt *Runtime, args ...Object) []Object {
+	dec := rt.newJSONDecoder("Parse", args[0])
+	val, ok := dec.next()
+	if !ok {
+		dec.fail(io.ErrUnexpectedEOF)
+	}
+	if _, err := dec.dec.Token(); err != io.EOF {
+		offset := dec.dec.InputOffset()
+		line, col := dec.src.position(offset - 1)
+		rt.Fatalf("json: unexpected data after the value at line %d, col %d", line, col)
+	}
+	return []Object{val}
+}
+
+// json.Stream(src) is a lazy enumerator of the values following one another
+// in src, they are decoded as the enumerator is iterated
+func jsonStream(rt *Runtime, args ...Object) []Object {
+	dec := rt.newJSONDecoder("Stream", args[0])
+	lazy := rt.NewLazyObject(args[0])
+	lazy.pull = dec.next
+	return []Object{lazy}
+}
+
+type jsonEncoder struct {
+	rt   *Runtime
+	buf  bytes.Buffer
+	path map[Object]bool // containers being encoded, to catch cycles
+}
+
+func (self *Runtime) jsonEncode(obj Object) []byte {
+	enc := &jsonEncoder{rt: self, path: map[Object]bool{}}
+	enc.encode(obj)
+	return enc.buf.Bytes()
+}
+
+func (self *jsonEncoder) encode(obj Object) {
+	rt := self.rt
+	switch obj := obj.(type) {
+	case *NilObject:
+		self.buf.WriteString("null")
+	case *BoolObject:
+		self.buf.WriteString(strconv.FormatBool(obj.Val))
+	case *IntegerObject:
+		self.buf.WriteString(strconv.Itoa(obj.Val))
+	case *BigIntObject:
+		self.buf.WriteString(obj.Val.String())
+	case *FloatObject:
+		if math.IsNaN(obj.Val) || math.IsInf(obj.Val, 0) {
+			rt.Fatalf("json: cannot encode %v", obj.Val)
+		}
+		s := strconv.FormatFloat(obj.Val, 'g', -1, 64)
+		if !strings.ContainsAny(s, ".e") {
+			s += ".0"
+		}
+		self.buf.WriteString(s)
+	case *StringObject, *SymbolObject, *RuneObject:
+		self.str(obj.String())
+	case *GoObject:
+		t, ok := obj.value().(time.Time)
+		if !ok {
+			rt.Fatalf("json: cannot encode %s %s", obj.Name(), obj)
+		}
+		self.str(t.Format(time.RFC3339Nano))
+	case *DictObject:
+		self.enter(obj)
+		self.buf.WriteByte('{')
+		for i, slot := range obj.slots() {
+			if i > 0 {
+				self.buf.WriteByte(',')
+			}
+			self.key(slot.Key)
+			self.buf.WriteByte(':')
+			self.encode(slot.Val)
+		}
+		self.buf.WriteByte('}')
+		delete(self.path, obj)
+	case *ArrayObject, *TupleObject, *SetObject:
+		self.enter(obj)
+		self.buf.WriteByte('[')
+		for i, elem := range rt.enumArg("Dump", obj) {
+			if i > 0 {
+				self.buf.WriteByte(',')
+			}
+			self.encode(elem)
+		}
+		self.buf.WriteByte(']')
+		delete(self.path, obj)
+	default:
+		rt.Fatalf("json: cannot encode %s %s", obj.Name(), obj)
+	}
+}
+
+func (self *jsonEncoder) enter(obj Object) {
+	if self.path[obj] {
+		self.rt.Fatalf("json: cannot encode %s, it contains itself", obj.Name())
+	}
+	self.path[obj] = true
+}
+
+// strings and symbols are keys as they are, integers as their digits
+func (self *jsonEncoder) key(obj Object) {
+	switch obj.(type) {
+	case *StringObject, *SymbolObject, *IntegerObject, *BigIntObject:
+		self.str(obj.String())
+	default:
+		self.rt.Fatalf("json: cannot encode the %s key %s", obj.Name(), obj)
+	}
+}
+
+func (self *jsonEncoder) str(s string) {
+	enc := json.NewEncoder(&self.buf)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s)
+	// Encode ends the value with a newline
+	self.buf.Truncate(self.buf.Len() - 1)
+}
+
+// json.Dump(v) is the compact json of v, dict keys keep their order and
+// timestamps become RFC 3339 strings
+func jsonDump(rt *Runtime, args ...Object) []Object {
+	return []Object{rt.NewStringObject(string(rt.jsonEncode(args[0])))}
+}
+
+// json.Pretty(v) is indented json, json.Pretty(v, indent) indents with indent
+func jsonPretty(rt *Runtime, args ...Object) []Object {
+	indent := "  "
+	if len(args) > 1 {
+		indent = args[1].String()
+	}
+	var out bytes.Buffer
+	json.Indent(&out, rt.jsonEncode(args[0]), "", indent)
+	return []Object{rt.NewStringObject(out.String())}
+}
@@ -0,0 +1,222 @@
+package rt
+
+import (
+	"fmt"
+)
+
+/// lazy enumerator
+
+// A lazy enumerator is a chain of Map/Select/Take... stages over an array,
+// a generator or another enumerator. Nothing is computed until the chain is
+// iterated, and every element flows through the whole chain one at a time.
+type LazyObject struct {
+	Property
+
+	src  Object
+	op   string
+	fn   Object
+	n    int
+	next func() (Object, bool)
+
+	// a stream source like json.Stream pulls its values itself
+	pull func() (Object, bool)
+}
+
+func (self *LazyObject) Name() string {
+	return "lazy"
+}
+
+func (self *LazyObject) HashCode() string {
+	return fmt.Sprintf("%p", self)
+}
+
+func (self *LazyObject) String() string {
+	return fmt.Sprintf("#<lazy %s>", self.src.String())
+}
+
+func (self *LazyObject) ToString(rt *Runtime, args ...Object) []Object {
+	return []Object{rt.NewStringObject(self.String())}
+}
+
+// pull function over anything that can be ranged over
+func (rt *Runtime) pullValues(obj Object) func() (Object, bool) {
+	switch obj := obj.(type) {
+	case *LazyObject:
+		return obj.iterate(rt)
+	case *GeneratorObject:
+		return func() (Object, bool) {
+			return obj.Resume(rt)
+		}
+	case *DictObject:
+		next := obj.walk()
+		return func() (Object, bool) {
+			slot, ok := next()
+			return slot.Val, ok
+		}
+	}
+
+	idx := 0
+	return func() (Object, bool) {
+		rets := Invoke(rt, obj, "__iter__", rt.NewIntegerObject(idx))
+		if len(rets) < 3 {
+			return nil, false
+		}
+		idx++
+		return rets[1], true
+	}
+}
+
+func (self *LazyObject) iterate(rt *Runtime) func() (Object, bool) {
+	if self.pull != nil {
+		return self.pull
+	}
+	next := rt.pullValues(self.src)
+	fn := self.fn
+
+	switch self.op {
+	case "map":
+		return func() (Object, bool) {
+			val, ok := next()
+			if !ok {
+				return nil, false
+			}
+			return rt.Call(fn, val), true
+		}
+	case "select", "reject":
+		want := self.op == "select"
+		return func() (Object, bool) {
+			for {
+				val, ok := next()
+				if !ok {
+					return nil, false
+				}
+				if Truthy(rt.Call(fn, val)) == want {
+					return val, true
+				}
+			}
+		}
+	case "take":
+		count := 0
+		return func() (Object, bool) {
+			// never pull more than n from the source, it may be endless
+			if count >= self.n {
+				return nil, false
+			}
+			count++
+			return next()
+		}
+	case "drop":
+		dropped := false
+		return func() (Object, bool) {
+			if !dropped {
+				dropped = true
+				for i := 0; i < self.n; i++ {
+					if _, ok := next(); !ok {
+						return nil, false
+					}
+				}
+			}
+			return next()
+		}
+	}
+	return next
+}
+
+func (self *LazyObject) chain(rt *Runtime, op string, args ...Object) (results []Object) {
+	obj := rt.NewLazyObject(self)
+	obj.op = op
+	switch op {
+	case "take", "drop":
+		obj.n = args[0].(*IntegerObject).Val
+	default:
+		obj.fn = args[0]
+	}
+	results = append(results, obj)
+	return
+}
+
+/// methods
+
+func (self *LazyObject) Lazy(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, self)
+	return
+}
+
+func (self *LazyObject) Map(rt *Runtime, args ...Object) (results []Object) {
+	return self.chain(rt, "map", args...)
+}
+
+func (self *LazyObject) Select(rt *Runtime, args ...Object) (results []Object) {
+	return self.chain(rt, "select", args...)
+}
+
+func (self *LazyObject) Reject(rt *Runtime, args ...Object) (results []Object) {
+	return self.chain(rt, "reject", args...)
+}
+
+func (self *LazyObject) Take(rt *Runtime, args ...Object) (results []Object) {
+	return self.chain(rt, "take", args...)
+}
+
+func (self *LazyObject) Drop(rt *Runtime, args ...Object) (results []Object) {
+	return self.chain(rt, "drop", args...)
+}
+
+func (self *LazyObject) Each(rt *Runtime, args ...Object) (results []Object) {
+	next := self.iterate(rt)
+	defer rt.CloseIter(self)
+	for {
+		val, ok := next()
+		if !ok {
+			break
+		}
+		rt.Call(args[0], val)
+	}
+	return
+}
+
+func (self *LazyObject) First(rt *Runtime, args ...Object) (results []Object) {
+	val, ok := self.iterate(rt)()
+	rt.CloseIter(self)
+	if !ok {
+		val = rt.Nil
+	}
+	results = append(results, val)
+	return
+}
+
+func (self *LazyObject) ToArray(rt *Runtime, args ...Object) (results []Object) {
+	vals := []Object{}
+	next := self.iterate(rt)
+	defer rt.CloseIter(self)
+	for {
+		val, ok := next()
+		if !ok {
+			break
+		}
+		vals = append(vals, val)
+	}
+	results = append(results, rt.NewArrayObject(vals))
+	return
+}
+
+func (self *LazyObject) Force(rt *Runtime, args ...Object) (results []Object) {
+	return self.ToArray(rt, args...)
+}
+
+// iterates values like a generator, both range variables get the value
+func (self *LazyObject) OP__iter__(rt *Runtime, args ...Object) (results []Object) {
+	idx := args[0].(*IntegerObject)
+	if idx.Val == 0 || self.next == nil {
+		self.next = self.iterate(rt)
+	}
+
+	val, ok := self.next()
+	if ok {
+		results = append(results, val, val, rt.True)
+	} else {
+		self.next = nil
+		results = append(results, rt.False)
+	}
+	return
+}
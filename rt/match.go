@@ -0,0 +1,113 @@
+package rt
+
+/// pattern matching
+
+// values of different kinds never match, numbers compare across integer,
+// bigint and float and runes compare with one character strings
+func matchKind(obj Object) string {
+	switch obj.(type) {
+	case *IntegerObject, *BigIntObject, *FloatObject:
+		return "number"
+	case *StringObject, *RuneObject:
+		return "string"
+	}
+	return obj.Name()
+}
+
+func (self *Runtime) invokeBool(obj Object, method string, arg Object) bool {
+	rets := Invoke(self, obj, method, arg)
+	b, ok := rets[0].(*BoolObject)
+	return ok && b.Val
+}
+
+// MatchValue tests a subject against a literal or computed value pattern, a
+// regexp pattern matches the strings it finds a match in
+func (self *Runtime) MatchValue(obj, pat Object) bool {
+	switch pat := pat.(type) {
+	case *NilObject:
+		_, ok := obj.(*NilObject)
+		return ok
+	case *BoolObject:
+		b, ok := obj.(*BoolObject)
+		return ok && b.Val == pat.Val
+	case *RegexpObject:
+		return matchKind(obj) == "string" && pat.re.MatchString(obj.String())
+	}
+
+	if matchKind(obj) != matchKind(pat) {
+		return false
+	}
+	switch obj.(type) {
+	case *IntegerObject, *BigIntObject, *FloatObject, *ComplexObject,
+		*StringObject, *RuneObject, *BytesObject:
+		return self.invokeBool(obj, "__eql__", pat)
+	}
+	return obj.HashCode() == pat.HashCode()
+}
+
+// MatchRange tests lo <= obj <= hi for numbers and strings
+func (self *Runtime) MatchRange(obj, lo, hi Object) bool {
+	kind := matchKind(obj)
+	if kind != matchKind(lo) || kind != matchKind(hi) {
+		return false
+	}
+
+	switch kind {
+	case "number":
+		return self.invokeBool(obj, "__geq__", lo) && self.invokeBool(obj, "__leq__", hi)
+	case "string":
+		s := obj.String()
+		return s >= lo.String() && s <= hi.String()
+	}
+	return false
+}
+
+// MatchType tests the type of a subject, int and number cover several of
+// the runtime types and function covers closures and go functions
+func (self *Runtime) MatchType(obj Object, typ string) bool {
+	name := obj.Name()
+	switch typ {
+	case "int":
+		return name == "integer" || name == "bigint"
+	case "number":
+		return matchKind(obj) == "number"
+	case "function":
+		return name == "closure" || name == "function" || name == "gofunc"
+	}
+	return name == typ
+}
+
+// MatchArray tests the shape of an array pattern, see UnpackArray
+func (self *Runtime) MatchArray(obj Object, num, rest int) bool {
+	vals, ok := sequenceVals(obj)
+	if !ok {
+		return false
+	}
+	if rest < 0 {
+		return len(vals) == num
+	}
+	return len(vals) >= num
+}
+
+// MatchDict tests that a dict has every key of a dict pattern, or a go
+// struct every field
+func (self *Runtime) MatchDict(obj Object, keys []Object) bool {
+	if gobj, ok := obj.(*GoObject); ok {
+		for _, key := range keys {
+			if _, ok := gobj.field(key); !ok {
+				return false
+			}
+		}
+		return true
+	}
+	dict, ok := obj.(*DictObject)
+	if !ok {
+		return false
+	}
+	for _, key := range keys {
+		if _, ok := dict.get(key); !ok {
+			return false
+		}
+	}
+	return true
+}
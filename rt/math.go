@@ -0,0 +1,170 @@
+package rt
+
+import (
+	"math"
+	"math/big"
+)
+
+/// math module and number helpers
+
+// the value of an integer, bigint or float argument
+func (self *Runtime) floatArg(method string, arg Object) float64 {
+	switch arg := arg.(type) {
+	case *IntegerObject:
+		return float64(arg.Val)
+	case *BigIntObject:
+		return bigToFloat(arg.Val)
+	case *FloatObject:
+		return arg.Val
+	}
+	self.Fatalf("%s needs a number, %s given", method, arg.Name())
+	return 0
+}
+
+// the digits argument of Round, Floor and Ceil, 0 when it is missing
+func (self *Runtime) digitsArg(method string, args []Object) (int, bool) {
+	if len(args) == 0 {
+		return 0, false
+	}
+	return self.intArg(method, args[0]), true
+}
+
+func bigArg(obj Object) (*big.Int, bool) {
+	switch obj := obj.(type) {
+	case *IntegerObject:
+		return big.NewInt(int64(obj.Val)), true
+	case *BigIntObject:
+		return obj.Val, true
+	}
+	return nil, false
+}
+
+// the integer part of a float, a bigint when it does not fit int64
+func (self *Runtime) floatToInteger(method string, f float64) Object {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		self.Fatalf("%s cannot convert %v to an integer", method, f)
+	}
+	f = math.Trunc(f)
+	if f >= -(1<<63) && f < 1<<63 {
+		return self.NewIntegerObject(int(f))
+	}
+	b, _ := big.NewFloat(f).Int(nil)
+	return self.NewInteger(b)
+}
+
+// rounds f to digits decimal places, negative digits round to tens,
+// hundreds... the mode is "round" (half away from zero), "floor" or "ceil"
+func roundFloat(f float64, digits int, mode string) float64 {
+	scale := math.Pow(10, float64(digits))
+	x := f * scale
+	if math.IsInf(x, 0) || math.IsNaN(x) || scale == 0 {
+		return f
+	}
+	switch mode {
+	case "floor":
+		x = math.Floor(x)
+	case "ceil":
+		x = math.Ceil(x)
+	default:
+		x = math.Round(x)
+	}
+	return x / scale
+}
+
+// rounds x to a multiple of 10**-digits, integers have no decimal places so
+// only negative digits change them
+func roundBig(x *big.Int, digits int, mode string) *big.Int {
+	if digits >= 0 {
+		return x
+	}
+	p := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-digits)), nil)
+	q, r := new(big.Int).DivMod(x, p, new(big.Int))
+	floor := q.Mul(q, p)
+	if r.Sign() == 0 {
+		return floor
+	}
+	up := false
+	switch mode {
+	case "ceil":
+		up = true
+	case "round":
+		c := new(big.Int).Lsh(r, 1).Cmp(p)
+		up = c > 0 || (c == 0 && x.Sign() >= 0)
+	}
+	if up {
+		return floor.Add(floor, p)
+	}
+	return floor
+}
+
+// Round, Floor and Ceil of integers and bigints
+func (self *Runtime) roundInteger(method string, x *big.Int, mode string, args []Object) []Object {
+	digits, _ := self.digitsArg(method, args)
+	return []Object{self.NewInteger(new(big.Int).Set(roundBig(x, digits, mode)))}
+}
+
+// Round, Floor and Ceil of floats give integers, or floats with digits
+func (self *Runtime) roundFloatObject(method string, f float64, mode string, args []Object) []Object {
+	digits, ok := self.digitsArg(method, args)
+	if ok {
+		return []Object{self.NewFloatObject(roundFloat(f, digits, mode))}
+	}
+	return []Object{self.floatToInteger(method, roundFloat(f, 0, mode))}
+}
+
+// x ** y of integers is exact for a non negative y and a float otherwise
+func bigPow(rt *Runtime, x, y *big.Int) Object {
+	if y.Sign() < 0 {
+		return rt.NewFloatObject(math.Pow(bigToFloat(x), bigToFloat(y)))
+	}
+	if !y.IsInt64() || (y.Int64() > math.MaxInt32 && x.CmpAbs(big.NewInt(1)) > 0) {
+		rt.Fatalf("integer::** exponent %s is too large", y)
+	}
+	return rt.NewInteger(new(big.Int).Exp(x, y, nil))
+}
+
+// DivMod of integers and bigints, [x / y, x % y] with go's truncated division
+func (self *Runtime) divMod(x *big.Int, obj Object) []Object {
+	if _, ok := obj.(*FloatObject); ok {
+		return self.NewFloatObject(bigToFloat(x)).DivMod(self, obj)
+	}
+	q := bigBinary(self, "__quo__", x, obj)[0]
+	r := bigBinary(self, "__rem__", x, obj)[0]
+	return []Object{self.NewArrayObject([]Object{q, r})}
+}
+
+// the greatest common divisor, never negative
+func (self *Runtime) gcd(x *big.Int, obj Object) []Object {
+	y, ok := bigArg(obj)
+	if !ok {
+		self.Fatalf("integer::Gcd needs an integer, %s given", obj.Name())
+	}
+	z := new(big.Int).GCD(nil, nil, new(big.Int).Abs(x), new(big.Int).Abs(y))
+	return []Object{self.NewInteger(z)}
+}
+
+// math.Min(x, ...) and math.Max(x, ...) return the smallest or largest of
+// their numbers as it is, a NaN argument gives NaN
+func mathMin(rt *Runtime, args ...Object) []Object {
+	return []Object{rt.extremum("math.Min", args, -1)}
+}
+
+func mathMax(rt *Runtime, args ...Object) []Object {
+	return []Object{rt.extremum("math.Max", args, 1)}
+}
+
+func (self *Runtime) extremum(fname string, args []Object, want int) Object {
+	if len(args) == 0 {
+		self.Fatalf("%s needs at least one number", fname)
+	}
+	var best Object
+	for _, arg := range args {
+		if f := self.floatArg(fname, arg); f != f {
+			return arg
+		}
+		if best == nil || self.Compare(arg, best) == want {
+			best = arg
+		}
+	}
+	return best
+}
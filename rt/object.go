@@ -2,7 +2,6 @@ package rt
 
 import (
 	"fmt"
-	"os"
 	"reflect"
 	"strings"
 )
@@ -14,13 +13,26 @@ type Object interface {
 	SetProp(obj Object, val Object)
 	GetProp(obj Object) Object
 	ToString(*Runtime, ...Object) []Object
+	Freeze(*Runtime, ...Object) []Object
+	Frozen() bool
 }
 
 func Invoke(rt *Runtime, obj Object, method string, args ...Object) (results []Object) {
 	isBuiltin := false
 	if strings.HasPrefix(method, "__") {
 		if method == "__get_property__" {
+			if _, ok := obj.(*NilObject); ok {
+				rt.Fatalf("cannot get property %s of nil, use ?. for nil-safe access", args[0])
+			}
+			// struct fields are read from the go value each time
+			if gobj, ok := obj.(*GoObject); ok {
+				if f, ok := gobj.field(args[0]); ok {
+					results = append(results, rt.goResult(f))
+					return
+				}
+			}
 			// builtin function
+			defer rt.propertyError()
 			val := obj.GetProp(args[0])
 			fnobj, ok := val.(*FuncObject)
 			if ok {
@@ -29,7 +41,19 @@ func Invoke(rt *Runtime, obj Object, method string, args ...Object) (results []O
 			results = append(results, val)
 			return
 		} else if method == "__set_property__" {
+			rt.checkFrozen(obj)
 			val := args[1]
+			if gobj, ok := obj.(*GoObject); ok {
+				if f, ok := gobj.field(args[0]); ok {
+					gobj.setField(rt, f, args[0], val)
+					return
+				}
+				// a misspelt field would otherwise become a property
+				if v := reflect.Indirect(reflect.ValueOf(gobj.obj)); v.IsValid() && v.Kind() == reflect.Struct {
+					rt.Fatalf("%s has no exported field %s", v.Type(), args[0])
+				}
+			}
+			defer rt.propertyError()
 			obj.SetProp(args[0], val)
 			return
 		} else {
@@ -39,6 +63,10 @@ func Invoke(rt *Runtime, obj Object, method string, args ...Object) (results []O
 	}
 
 	theMethod := reflect.ValueOf(obj).MethodByName(method)
+	if theMethod.IsValid() && !isBuiltin && !isBuiltinMethod(theMethod) {
+		// go helpers like String or Name, a GoObject passes them to its value
+		theMethod = reflect.Value{}
+	}
 	if theMethod.IsValid() || isBuiltin {
 		// doubi object methods
 		theArgs := []reflect.Value{reflect.ValueOf(rt)}
@@ -51,6 +79,10 @@ func Invoke(rt *Runtime, obj Object, method string, args ...Object) (results []O
 		}
 		vals := theMethod.Call(theArgs)
 		results = vals[0].Interface().([]Object)
+		if method == "Freeze" && len(results) == 0 {
+			// Property.Freeze doesn't know its object, x.Freeze() gives x
+			results = append(results, obj)
+		}
 		return
 	} else {
 		// go object methods
@@ -67,19 +99,23 @@ func Invoke(rt *Runtime, obj Object, method string, args ...Object) (results []O
 				i := 0
 				for ; i < methodType.NumIn()-1; i++ {
 					reqTyp := methodType.In(i)
-					theArgs = append(theArgs, ObjectToValue(args[i], reqTyp))
+					theArgs = append(theArgs, rt.goArg(args[i], reqTyp))
 				}
 
 				for ; i < len(args); i++ {
 					var reqTyp reflect.Type
-					if i < methodType.NumIn() && methodType.In(i).Kind() != reflect.Slice {
+					if methodType.IsVariadic() {
+						reqTyp = methodType.In(methodType.NumIn() - 1).Elem()
+					} else if i < methodType.NumIn() {
 						reqTyp = methodType.In(i)
 					}
-					theArgs = append(theArgs, ObjectToValue(args[i], reqTyp))
+					theArgs = append(theArgs, rt.goArg(args[i], reqTyp))
 				}
 			}
 
+			rt.Unlock()
 			rets := theMethod.Call(theArgs)
+			rt.Lock()
 			for _, ret := range rets {
 				results = append(results, rt.NewGoObject(ret.Interface()))
 			}
@@ -90,11 +126,27 @@ func Invoke(rt *Runtime, obj Object, method string, args ...Object) (results []O
 	return
 
 err:
-	fmt.Printf("Error: Unknown Method %s for %s\n", method, obj.String())
-	os.Exit(1)
+	rt.exit(fmt.Sprintf("Error: Unknown Method %s for %s\n", method, obj.String()))
 	return
 }
 
+// GetProp and SetProp panic with a message, it becomes a runtime error
+func (self *Runtime) propertyError() {
+	if r := recover(); r != nil {
+		msg, ok := r.(string)
+		if !ok {
+			panic(r)
+		}
+		self.Fatalf("%s", strings.TrimPrefix(strings.TrimSpace(msg), "Error: "))
+	}
+}
+
+var builtinMethodType = reflect.TypeOf(func(*Runtime, ...Object) []Object { return nil })
+
+func isBuiltinMethod(method reflect.Value) bool {
+	return method.Type() == builtinMethodType
+}
+
 type Slot struct {
 	Key Object
 	Val Object
@@ -103,17 +155,21 @@ type Slot struct {
 type Property struct {
 	Slots  map[string]Slot
 	Parent *Property
+	frozen bool
 }
 
 func MakeProperty(slots map[string]Slot, parent *Property) Property {
-	return Property{slots, parent}
+	return Property{slots, parent, false}
 }
 
 func EmptyProperty() Property {
-	return Property{nil, nil}
+	return Property{nil, nil, false}
 }
 
 func (self *Property) SetProp(obj Object, val Object) {
+	if self.frozen {
+		panic(fmt.Sprintf("Error: cannot set property %v of a frozen object\n", obj))
+	}
 	if self.Slots == nil {
 		self.Slots = map[string]Slot{}
 	}
@@ -122,21 +178,44 @@ func (self *Property) SetProp(obj Object, val Object) {
 }
 
 func (self *Property) GetProp(obj Object) Object {
-	s := self
-	for {
-		hash := obj.HashCode()
-		slot, ok := s.Slots[hash]
-		if !ok {
-			if s.Parent != nil {
-				s = self.Parent
-				continue
-			} else {
-				panic(fmt.Sprintf("Error: no property %v\n", obj))
-			}
+	val, ok := self.lookup(obj)
+	if !ok {
+		panic(fmt.Sprintf("Error: no property %v\n", obj))
+	}
+	return val
+}
+
+// lookup searches the property chain, own slots first
+func (self *Property) lookup(obj Object) (Object, bool) {
+	hash := obj.HashCode()
+	for s := self; s != nil; s = s.Parent {
+		if slot, ok := s.Slots[hash]; ok {
+			return slot.Val, true
 		}
-		return slot.Val
 	}
-	return nil
+	return nil, false
+}
+
+// a frozen object rejects every mutation, containers override Freeze to
+// freeze their elements as well
+func (self *Property) Freeze(rt *Runtime, args ...Object) (results []Object) {
+	self.frozen = true
+	return
+}
+
+func (self *Property) IsFrozen(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewBoolObject(self.frozen))
+	return
+}
+
+func (self *Property) Frozen() bool {
+	return self.frozen
+}
+
+func (self *Runtime) checkFrozen(obj Object) {
+	if obj.Frozen() {
+		self.Fatalf("cannot modify frozen %s %s", obj.Name(), obj)
+	}
 }
 
 type NilObject struct {
@@ -0,0 +1,139 @@
+package rt
+
+import (
+	"fmt"
+)
+
+/// range
+
+// lo..hi is the integers from lo to hi, both included. the elements are
+// computed when they are asked for, a range holds only its bounds
+type RangeObject struct {
+	Property
+	Enumerable
+
+	Lo, Hi int
+}
+
+func (self *Runtime) NewRangeObject(lo, hi Object) *RangeObject {
+	l, ok1 := lo.(*IntegerObject)
+	h, ok2 := hi.(*IntegerObject)
+	if !ok1 || !ok2 {
+		self.Fatalf("range bounds must be integers, %s..%s given", lo.Name(), hi.Name())
+	}
+	obj := &RangeObject{Property: MakeProperty(nil, &self.rangeProperties), Lo: l.Val, Hi: h.Val}
+	obj.owner = obj
+	obj.frozen = true
+	return obj
+}
+
+func (self *RangeObject) Name() string {
+	return "range"
+}
+
+func (self *RangeObject) HashCode() string {
+	return self.String()
+}
+
+func (self *RangeObject) String() string {
+	return fmt.Sprintf("%d..%d", self.Lo, self.Hi)
+}
+
+func (self *RangeObject) ToString(rt *Runtime, args ...Object) []Object {
+	return []Object{rt.NewStringObject(self.String())}
+}
+
+func (self *RangeObject) size() int {
+	if self.Hi < self.Lo {
+		return 0
+	}
+	return self.Hi - self.Lo + 1
+}
+
+func (self *RangeObject) vals(rt *Runtime) []Object {
+	vals := make([]Object, self.size())
+	for i := range vals {
+		vals[i] = rt.NewIntegerObject(self.Lo + i)
+	}
+	return vals
+}
+
+/// methods
+
+func (self *RangeObject) Size(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewIntegerObject(self.size()))
+	return
+}
+
+func (self *RangeObject) Length(rt *Runtime, args ...Object) (results []Object) {
+	return self.Size(rt, args...)
+}
+
+func (self *RangeObject) Each(rt *Runtime, args ...Object) (results []Object) {
+	for i := self.Lo; i <= self.Hi; i++ {
+		rt.Call(args[0], rt.NewIntegerObject(i))
+	}
+	return
+}
+
+func (self *RangeObject) Map(rt *Runtime, args ...Object) (results []Object) {
+	arr := make([]Object, 0, self.size())
+	for i := self.Lo; i <= self.Hi; i++ {
+		arr = append(arr, rt.Call(args[0], rt.NewIntegerObject(i)))
+	}
+	results = append(results, rt.NewArrayObject(arr))
+	return
+}
+
+func (self *RangeObject) Select(rt *Runtime, args ...Object) (results []Object) {
+	arr := []Object{}
+	for i := self.Lo; i <= self.Hi; i++ {
+		val := rt.NewIntegerObject(i)
+		if Truthy(rt.Call(args[0], val)) {
+			arr = append(arr, val)
+		}
+	}
+	results = append(results, rt.NewArrayObject(arr))
+	return
+}
+
+// a bound check, without walking the range
+func (self *RangeObject) Include(rt *Runtime, args ...Object) (results []Object) {
+	n, ok := args[0].(*IntegerObject)
+	results = append(results, rt.NewBoolObject(ok && n.Val >= self.Lo && n.Val <= self.Hi))
+	return
+}
+
+func (self *RangeObject) ToArray(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewArrayObject(self.vals(rt)))
+	return
+}
+
+func (self *RangeObject) Lazy(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewLazyObject(self))
+	return
+}
+
+/// operators
+
+func (self *RangeObject) OP__iter__(rt *Runtime, args ...Object) (results []Object) {
+	idx := args[0].(*IntegerObject)
+	if idx.Val < self.size() {
+		results = append(results, args[0], rt.NewIntegerObject(self.Lo+idx.Val), rt.True)
+	} else {
+		results = append(results, rt.False)
+	}
+	return
+}
+
+func (self *RangeObject) OP__eql__(rt *Runtime, args ...Object) (results []Object) {
+	other, ok := args[0].(*RangeObject)
+	results = append(results, rt.NewBoolObject(ok && other.Lo == self.Lo && other.Hi == self.Hi))
+	return
+}
+
+func (self *RangeObject) OP__neq__(rt *Runtime, args ...Object) (results []Object) {
+	other, ok := args[0].(*RangeObject)
+	results = append(results, rt.NewBoolObject(!ok || other.Lo != self.Lo || other.Hi != self.Hi))
+	return
+}
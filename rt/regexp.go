@@ -0,0 +1,219 @@
+package rt
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/jxwr/doby/vm/instr"
+)
+
+/// regexp
+
+// a compiled regular expression, /pattern/flags literals share one compiled
+// pattern per literal
+type RegexpObject struct {
+	Property
+
+	re     *regexp.Regexp
+	source string
+	flags  string
+}
+
+func (self *Runtime) NewRegexpObject(re *regexp.Regexp, source, flags string) *RegexpObject {
+	return &RegexpObject{MakeProperty(nil, &self.regexpProperties), re, source, flags}
+}
+
+// patterns built at run time are compiled on every use, only literals are
+// compiled once
+func (self *Runtime) compileRegexp(source, flags string) (*regexp.Regexp, error) {
+	return instr.CompileRegexp(source, flags)
+}
+
+// a regexp argument may also be a pattern string, an invalid one is a
+// runtime error
+func (self *Runtime) regexpArg(method string, arg Object) *regexp.Regexp {
+	if re, ok := arg.(*RegexpObject); ok {
+		return re.re
+	}
+	re, err := self.compileRegexp(arg.String(), "")
+	if err != nil {
+		self.Fatalf("%s invalid pattern %s", method, err)
+	}
+	return re
+}
+
+func (self *RegexpObject) Name() string {
+	return "regexp"
+}
+
+func (self *RegexpObject) HashCode() string {
+	return self.String()
+}
+
+func (self *RegexpObject) String() string {
+	return "/" + strings.Replace(self.source, "/", "\\/", -1) + "/" + self.flags
+}
+
+func (self *RegexpObject) ToString(rt *Runtime, args ...Object) []Object {
+	return []Object{rt.NewStringObject(self.String())}
+}
+
+// the match and its groups as strings, nil for a group that did not match
+func (self *Runtime) matchArray(groups []string, loc []int) Object {
+	vals := make([]Object, len(groups))
+	for i, group := range groups {
+		if loc[2*i] < 0 {
+			vals[i] = self.Nil
+		} else {
+			vals[i] = self.NewStringObject(group)
+		}
+	}
+	return self.NewArrayObject(vals)
+}
+
+// the named groups of a match keyed by symbols
+func (self *RegexpObject) namedDict(rt *Runtime, groups []string, loc []int) Object {
+	slots := []Slot{}
+	for i, name := range self.re.SubexpNames() {
+		if name == "" {
+			continue
+		}
+		var val Object = rt.Nil
+		if loc[2*i] >= 0 {
+			val = rt.NewStringObject(groups[i])
+		}
+		slots = append(slots, Slot{rt.Intern(name), val})
+	}
+	return rt.NewDictObject(slots)
+}
+
+func (self *RegexpObject) Source(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewStringObject(self.source))
+	return
+}
+
+func (self *RegexpObject) Test(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewBoolObject(self.re.MatchString(args[0].String())))
+	return
+}
+
+// [match, group1, ...] of the first match, nil when there is none
+func (self *RegexpObject) Match(rt *Runtime, args ...Object) (results []Object) {
+	s := args[0].String()
+	loc := self.re.FindStringSubmatchIndex(s)
+	if loc == nil {
+		results = append(results, rt.Nil)
+		return
+	}
+	results = append(results, rt.matchArray(submatches(s, loc), loc))
+	return
+}
+
+// [match, group1, ...] of every match
+func (self *RegexpObject) MatchAll(rt *Runtime, args ...Object) (results []Object) {
+	s := args[0].String()
+	locs := self.re.FindAllStringSubmatchIndex(s, -1)
+	matches := make([]Object, len(locs))
+	for i, loc := range locs {
+		matches[i] = rt.matchArray(submatches(s, loc), loc)
+	}
+	results = append(results, rt.NewArrayObject(matches))
+	return
+}
+
+// a dict of the named groups of the first match, nil when there is none
+func (self *RegexpObject) Named(rt *Runtime, args ...Object) (results []Object) {
+	s := args[0].String()
+	loc := self.re.FindStringSubmatchIndex(s)
+	if loc == nil {
+		results = append(results, rt.Nil)
+		return
+	}
+	results = append(results, self.namedDict(rt, submatches(s, loc), loc))
+	return
+}
+
+// a dict of the named groups of every match
+func (self *RegexpObject) NamedAll(rt *Runtime, args ...Object) (results []Object) {
+	s := args[0].String()
+	locs := self.re.FindAllStringSubmatchIndex(s, -1)
+	dicts := make([]Object, len(locs))
+	for i, loc := range locs {
+		dicts[i] = self.namedDict(rt, submatches(s, loc), loc)
+	}
+	results = append(results, rt.NewArrayObject(dicts))
+	return
+}
+
+func submatches(s string, loc []int) []string {
+	groups := make([]string, len(loc)/2)
+	for i := range groups {
+		if loc[2*i] >= 0 {
+			groups[i] = s[loc[2*i]:loc[2*i+1]]
+		}
+	}
+	return groups
+}
+
+// the first matching string, nil when there is none
+func (self *RegexpObject) Find(rt *Runtime, args ...Object) (results []Object) {
+	s := args[0].String()
+	loc := self.re.FindStringIndex(s)
+	if loc == nil {
+		results = append(results, rt.Nil)
+	} else {
+		results = append(results, rt.NewStringObject(s[loc[0]:loc[1]]))
+	}
+	return
+}
+
+// FindAll(s) gives every matching string, FindAll(s, n) at most n
+func (self *RegexpObject) FindAll(rt *Runtime, args ...Object) (results []Object) {
+	n := -1
+	if len(args) > 1 {
+		n = rt.intArg("regexp::FindAll", args[1])
+	}
+	results = append(results, rt.stringArray(self.re.FindAllString(args[0].String(), n)))
+	return
+}
+
+// ReplaceAll(s, repl) replaces every match, see string::Gsub for repl
+func (self *RegexpObject) ReplaceAll(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewStringObject(rt.replaceAll(self.re, args[0].String(), args[1])))
+	return
+}
+
+func (self *RegexpObject) Split(rt *Runtime, args ...Object) (results []Object) {
+	n := -1
+	if len(args) > 1 {
+		n = rt.intArg("regexp::Split", args[1])
+	}
+	results = append(results, rt.stringArray(self.re.Split(args[0].String(), n)))
+	return
+}
+
+// the names of the groups, "" for unnamed ones
+func (self *RegexpObject) Names(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.stringArray(self.re.SubexpNames()[1:]))
+	return
+}
+
+/// operators
+
+// re =~ s and s =~ re test for a match
+func (self *RegexpObject) OP__match__(rt *Runtime, args ...Object) (results []Object) {
+	return self.Test(rt, args...)
+}
+
+// regexps are equal when their sources and flags are
+func (self *RegexpObject) OP__eql__(rt *Runtime, args ...Object) (results []Object) {
+	other, ok := args[0].(*RegexpObject)
+	results = append(results, rt.NewBoolObject(ok && self.String() == other.String()))
+	return
+}
+
+func (self *RegexpObject) OP__neq__(rt *Runtime, args ...Object) (results []Object) {
+	other, ok := args[0].(*RegexpObject)
+	results = append(results, rt.NewBoolObject(!ok || self.String() != other.String()))
+	return
+}
@@ -0,0 +1,145 @@
+package rt
+
+import (
+	"fmt"
+)
+
+/// rune
+
+type RuneObject struct {
+	Property
+
+	Val rune
+}
+
+func (self *RuneObject) Name() string {
+	return "rune"
+}
+
+func (self *RuneObject) HashCode() string {
+	return self.String()
+}
+
+func (self *RuneObject) String() string {
+	return string(self.Val)
+}
+
+func (self *RuneObject) ToString(rt *Runtime, args ...Object) []Object {
+	return []Object{rt.NewStringObject(self.String())}
+}
+
+// the code point of the rune
+func (self *RuneObject) Int(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewIntegerObject(int(self.Val)))
+	return
+}
+
+func (self *RuneObject) Quote(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewStringObject(fmt.Sprintf("%q", self.Val)))
+	return
+}
+
+// +, rune + integer is a rune, rune + string or rune concats like the one
+// character strings char literals used to be, 'a' + 'b' is "ab"
+func (self *RuneObject) OP__add__(rt *Runtime, args ...Object) (results []Object) {
+	switch arg := args[0].(type) {
+	case *StringObject:
+		results = append(results, rt.NewStringObject(self.String()+arg.Val))
+	case *RuneObject:
+		results = append(results, rt.NewStringObject(self.String()+arg.String()))
+	case *IntegerObject:
+		results = append(results, rt.NewRuneObject(self.Val+rune(arg.Val)))
+	default:
+		rt.Fatalf("rune::+ unsupported operand %s", arg.Name())
+	}
+	return
+}
+
+// -, rune - rune is the integer distance
+func (self *RuneObject) OP__sub__(rt *Runtime, args ...Object) (results []Object) {
+	switch arg := args[0].(type) {
+	case *RuneObject:
+		results = append(results, rt.NewIntegerObject(int(self.Val-arg.Val)))
+	case *IntegerObject:
+		results = append(results, rt.NewRuneObject(self.Val-rune(arg.Val)))
+	default:
+		rt.Fatalf("rune::- unsupported operand %s", arg.Name())
+	}
+	return
+}
+
+// ==
+func (self *RuneObject) OP__eql__(rt *Runtime, args ...Object) (results []Object) {
+	results = self.logic(rt, "__eql__", args[0])
+	return
+}
+
+// !=
+func (self *RuneObject) OP__neq__(rt *Runtime, args ...Object) (results []Object) {
+	results = self.logic(rt, "__neq__", args[0])
+	return
+}
+
+// <
+func (self *RuneObject) OP__lss__(rt *Runtime, args ...Object) (results []Object) {
+	results = self.logic(rt, "__lss__", args[0])
+	return
+}
+
+// >
+func (self *RuneObject) OP__gtr__(rt *Runtime, args ...Object) (results []Object) {
+	results = self.logic(rt, "__gtr__", args[0])
+	return
+}
+
+// <=
+func (self *RuneObject) OP__leq__(rt *Runtime, args ...Object) (results []Object) {
+	results = self.logic(rt, "__leq__", args[0])
+	return
+}
+
+// >=
+func (self *RuneObject) OP__geq__(rt *Runtime, args ...Object) (results []Object) {
+	results = self.logic(rt, "__geq__", args[0])
+	return
+}
+
+// a rune equals the one character string holding it
+func (self *RuneObject) logic(rt *Runtime, method string, obj Object) (results []Object) {
+	var val rune
+
+	switch arg := obj.(type) {
+	case *RuneObject:
+		val = arg.Val
+	case *IntegerObject:
+		val = rune(arg.Val)
+	case *StringObject:
+		runes := []rune(arg.Val)
+		if len(runes) != 1 {
+			cmp := method == "__neq__"
+			results = append(results, rt.NewBoolObject(cmp))
+			return
+		}
+		val = runes[0]
+	default:
+		cmp := method == "__neq__"
+		results = append(results, rt.NewBoolObject(cmp))
+		return
+	}
+
+	switch method {
+	case "__eql__":
+		results = append(results, rt.NewBoolObject(self.Val == val))
+	case "__neq__":
+		results = append(results, rt.NewBoolObject(self.Val != val))
+	case "__lss__":
+		results = append(results, rt.NewBoolObject(self.Val < val))
+	case "__gtr__":
+		results = append(results, rt.NewBoolObject(self.Val > val))
+	case "__leq__":
+		results = append(results, rt.NewBoolObject(self.Val <= val))
+	case "__geq__":
+		results = append(results, rt.NewBoolObject(self.Val >= val))
+	}
+	return
+}
@@ -1,16 +1,15 @@
 package rt
 
 import (
-	"bufio"
 	"fmt"
-	"io/ioutil"
-	"log"
-	"math/rand"
+	"math"
+	"math/big"
 	"os"
 	"reflect"
 	"runtime"
+	"sort"
 	"strings"
-	"time"
+	"sync"
 
 	"github.com/jxwr/doby/env"
 	"github.com/jxwr/doby/vm/instr"
@@ -24,23 +23,43 @@ type Runtime struct {
 	False Object
 
 	Runner ClosureRunner
+	lock   sync.Mutex
+
+	// source offset of the running instruction and the hook showing it
+	Pos     int
+	PosInfo func(pos int) string
+	// Fail gets a runtime error instead of it being printed before exiting,
+	// it must not return
+	Fail func(msg string)
 
-	tmpString  *StringObject
 	tmpInteger *IntegerObject
 
 	goTypeMap map[string]*Property
-
-	integerProperties Property
-	floatProperties   Property
-	stringProperties  Property
-	arrayProperties   Property
-	dictProperties    Property
-	setProperties     Property
-	boolProperties    Property
-	nilProperties     Property
-	funcProperties    Property
-	gofuncProperties  Property
-	goobjProperties   Property
+	symbols   map[string]*SymbolObject
+
+	integerProperties  Property
+	bigintProperties   Property
+	floatProperties    Property
+	complexProperties  Property
+	stringProperties   Property
+	symbolProperties   Property
+	regexpProperties   Property
+	runeProperties     Property
+	bytesProperties    Property
+	arrayProperties    Property
+	dictProperties     Property
+	setProperties      Property
+	tupleProperties    Property
+	rangeProperties    Property
+	boolProperties     Property
+	nilProperties      Property
+	funcProperties     Property
+	gofuncProperties   Property
+	goobjProperties    Property
+	gotypeProperties   Property
+	genProperties      Property
+	lazyProperties     Property
+	dictIterProperties Property
 }
 
 func NewRuntime() *Runtime {
@@ -48,9 +67,9 @@ func NewRuntime() *Runtime {
 
 	rt := &Runtime{Env: env, Stack: NewStack()}
 
-	rt.tmpString = rt.NewStringObject("")
 	rt.Nil = &NilObject{}
 	rt.goTypeMap = map[string]*Property{}
+	rt.symbols = map[string]*SymbolObject{}
 
 	rt.registerGlobals(env)
 	rt.initBuiltinObjectProperties()
@@ -61,7 +80,12 @@ func NewRuntime() *Runtime {
 }
 
 func (self *Runtime) CallFuncObj(fnobj *ClosureObject, args ...Object) {
-	self.MarkN(-len(args))
+	if fnobj.Proto.IsGenerator() {
+		self.Push(self.NewGeneratorObject(fnobj, args))
+		return
+	}
+
+	self.Mark()
 	for _, arg := range args {
 		self.Push(arg)
 	}
@@ -73,8 +97,31 @@ func (self *Runtime) NewIntegerObject(val int) *IntegerObject {
 	return obj
 }
 
+func (self *Runtime) NewBigIntObject(val *big.Int) *BigIntObject {
+	obj := &BigIntObject{MakeProperty(nil, &self.bigintProperties), val}
+	return obj
+}
+
+// NewInteger demotes val to an IntegerObject when it fits in int64
+func (self *Runtime) NewInteger(val *big.Int) Object {
+	if val.IsInt64() {
+		return self.NewIntegerObject(int(val.Int64()))
+	}
+	return self.NewBigIntObject(val)
+}
+
 func (self *Runtime) NewStringObject(val string) *StringObject {
-	obj := &StringObject{MakeProperty(nil, &self.stringProperties), val}
+	obj := &StringObject{MakeProperty(nil, &self.stringProperties), val, nil}
+	return obj
+}
+
+func (self *Runtime) NewRuneObject(val rune) *RuneObject {
+	obj := &RuneObject{MakeProperty(nil, &self.runeProperties), val}
+	return obj
+}
+
+func (self *Runtime) NewBytesObject(val []byte) *BytesObject {
+	obj := &BytesObject{MakeProperty(nil, &self.bytesProperties), val}
 	return obj
 }
 
@@ -83,24 +130,44 @@ func (self *Runtime) NewFloatObject(val float64) *FloatObject {
 	return obj
 }
 
+func (self *Runtime) NewComplexObject(val complex128) *ComplexObject {
+	obj := &ComplexObject{MakeProperty(nil, &self.complexProperties), val}
+	return obj
+}
+
 func (self *Runtime) NewGoFuncObject(fname string, fn interface{}) *GoFuncObject {
 	gf := &GoFuncObject{MakeProperty(nil, &self.gofuncProperties), fname, reflect.TypeOf(fn), fn}
 	return gf
 }
 
+// struct and array values are copied into a box, see GoObject
 func (self *Runtime) NewGoObject(obj interface{}) *GoObject {
-	gobj := &GoObject{MakeProperty(nil, &self.goobjProperties), obj}
 	val := reflect.ValueOf(obj)
+	if k := val.Kind(); k == reflect.Struct || k == reflect.Array {
+		box := reflect.New(val.Type())
+		box.Elem().Set(val)
+		return self.goObject(box, true)
+	}
+	return self.goObject(val, false)
+}
+
+// the methods of a go type are shared by its values
+func (self *Runtime) goObject(val reflect.Value, boxed bool) *GoObject {
+	var obj interface{}
+	if val.IsValid() {
+		obj = val.Interface()
+	}
+	gobj := &GoObject{MakeProperty(nil, &self.goobjProperties), obj, boxed}
 
 	if obj != nil && reflect.Indirect(val).IsValid() && val.Kind() > reflect.Invalid && val.Kind() <= reflect.UnsafePointer {
-		key := reflect.Indirect(val).Type().PkgPath() + "::" + reflect.Indirect(val).Type().String()
+		key := reflect.Indirect(val).Type().PkgPath() + "::" + val.Type().String()
 		_, ok := self.goTypeMap[key]
 		if !ok {
 			prop := MakeProperty(nil, &self.goobjProperties)
 			self.addObjectProperties(obj, &prop)
 			self.goTypeMap[key] = &prop
 		}
-		gobj = &GoObject{MakeProperty(nil, self.goTypeMap[key]), obj}
+		gobj = &GoObject{MakeProperty(nil, self.goTypeMap[key]), obj, boxed}
 	}
 	return gobj
 }
@@ -111,23 +178,51 @@ func (self *Runtime) NewClosureObject(proto *instr.ClosureProto,
 	return obj
 }
 
+// the args are left on the generator's own stack for the body to pick up
+func (self *Runtime) NewGeneratorObject(fn *ClosureObject, args []Object) *GeneratorObject {
+	stack := NewStack()
+	stack.Mark()
+	for _, arg := range args {
+		stack.Push(arg)
+	}
+	obj := &GeneratorObject{MakeProperty(nil, &self.genProperties), fn, stack,
+		false, false, make(chan bool), make(chan Object), 0, nil}
+	return obj
+}
+
+func (self *Runtime) NewLazyObject(src Object) *LazyObject {
+	obj := &LazyObject{Property: MakeProperty(nil, &self.lazyProperties), src: src}
+	return obj
+}
+
 func (self *Runtime) NewBuiltinFuncObject(name string) *FuncObject {
 	obj := &FuncObject{MakeProperty(nil, &self.funcProperties), name, nil}
 	return obj
 }
 
-func (self *Runtime) NewDictObject(fields map[string]Slot) Object {
-	obj := &DictObject{MakeProperty(fields, &self.dictProperties), nil}
+// NewDictObject keeps the order of slots, a repeated key keeps its first
+// position and its last value
+func (self *Runtime) NewDictObject(slots []Slot) Object {
+	obj := &DictObject{Property: MakeProperty(nil, &self.dictProperties)}
+	obj.owner = obj
+	for _, slot := range slots {
+		obj.put(slot.Key, slot.Val)
+	}
 	return obj
 }
 
 func (self *Runtime) NewArrayObject(vals []Object) Object {
-	obj := &ArrayObject{MakeProperty(nil, &self.arrayProperties), vals}
+	obj := &ArrayObject{Property: MakeProperty(nil, &self.arrayProperties), Vals: vals}
+	obj.owner = obj
 	return obj
 }
 
 func (self *Runtime) NewSetObject(vals []Object) Object {
-	obj := &SetObject{MakeProperty(nil, &self.setProperties), vals}
+	obj := &SetObject{Property: MakeProperty(nil, &self.setProperties)}
+	obj.owner = obj
+	for _, val := range vals {
+		obj.add(val)
+	}
 	return obj
 }
 
@@ -155,8 +250,34 @@ func ObjectToValue(obj Object, typ reflect.Type) reflect.Value {
 		} else {
 			v = reflect.ValueOf(obj.Val).Convert(typ)
 		}
+	case *BigIntObject:
+		var ok bool
+		if v, ok = bigIntToValue(obj, typ); !ok {
+			v, _ = bigIntToValue(obj, nil)
+		}
+	case *ComplexObject:
+		if typ == nil {
+			v = reflect.ValueOf(obj.Val)
+		} else {
+			v = reflect.ValueOf(obj.Val).Convert(typ)
+		}
+	case *RuneObject:
+		if typ == nil {
+			v = reflect.ValueOf(obj.Val)
+		} else {
+			v = reflect.ValueOf(obj.Val).Convert(typ)
+		}
+	case *BytesObject:
+		if typ == nil {
+			v = reflect.ValueOf(obj.Val)
+		} else {
+			v = reflect.ValueOf(obj.Val).Convert(typ)
+		}
 	case *StringObject:
 		v = reflect.ValueOf(obj.Val)
+		if typ != nil && typ.Kind() == reflect.Slice && v.Type().ConvertibleTo(typ) {
+			v = v.Convert(typ)
+		}
 	case *BoolObject:
 		v = reflect.ValueOf(obj.Val)
 	case *GoObject:
@@ -164,7 +285,7 @@ func ObjectToValue(obj Object, typ reflect.Type) reflect.Value {
 			var nilObj *NilObject
 			v = reflect.ValueOf(nilObj)
 		} else {
-			v = reflect.ValueOf(obj.obj)
+			v = obj.valueFor(typ)
 		}
 	default:
 		v = reflect.ValueOf(obj)
@@ -172,21 +293,68 @@ func ObjectToValue(obj Object, typ reflect.Type) reflect.Value {
 	return v
 }
 
+// ObjectToGo converts plain data to go values, dicts become maps with string
+// keys, arrays, tuples and sets slices, and the wrapped value of a GoObject is
+// returned as it is. functions and modules have no go form
+func ObjectToGo(obj Object) (interface{}, error) {
+	switch obj := obj.(type) {
+	case *NilObject:
+		return nil, nil
+	case *BoolObject:
+		return obj.Val, nil
+	case *IntegerObject:
+		return obj.Val, nil
+	case *BigIntObject:
+		return new(big.Int).Set(obj.Val), nil
+	case *FloatObject:
+		return obj.Val, nil
+	case *ComplexObject:
+		return obj.Val, nil
+	case *StringObject, *SymbolObject, *RuneObject:
+		return obj.String(), nil
+	case *BytesObject:
+		return append([]byte{}, obj.Val...), nil
+	case *GoObject:
+		return obj.value(), nil
+	case *DictObject:
+		if obj.module {
+			break
+		}
+		m := make(map[string]interface{}, len(obj.slots()))
+		for _, slot := range obj.slots() {
+			val, err := ObjectToGo(slot.Val)
+			if err != nil {
+				return nil, err
+			}
+			m[slot.Key.String()] = val
+		}
+		return m, nil
+	case *ArrayObject:
+		return objectsToGo(obj.Vals)
+	case *TupleObject:
+		return objectsToGo(obj.Vals)
+	case *SetObject:
+		return objectsToGo(obj.Vals)
+	}
+	return nil, fmt.Errorf("%s %s has no go value", obj.Name(), obj)
+}
+
+func objectsToGo(vals []Object) ([]interface{}, error) {
+	s := make([]interface{}, len(vals))
+	for i, val := range vals {
+		v, err := ObjectToGo(val)
+		if err != nil {
+			return nil, err
+		}
+		s[i] = v
+	}
+	return s, nil
+}
+
 /// init object methods
 
 func (self *Runtime) addObjectProperties(obj interface{}, prop *Property) {
 	typ := reflect.TypeOf(obj)
-	// if obj is a struct, add all fileds to its property
-	val := reflect.ValueOf(obj)
-	if typ.Kind() == reflect.Struct {
-		for i := 0; i < val.NumField(); i++ {
-			ch := typ.Field(i).Name[0]
-			if ch >= 'A' && ch <= 'Z' {
-				self.tmpString.Val = typ.Field(i).Name
-				prop.SetProp(self.tmpString, self.NewGoObject(val.Field(i).Interface()))
-			}
-		}
-	}
 
 	// add methods of the type
 	numMethods := typ.NumMethod()
@@ -197,16 +365,14 @@ func (self *Runtime) addObjectProperties(obj interface{}, prop *Property) {
 			m := typ.Method(i)
 			if m.Type == to_s.Type {
 				fn := self.NewBuiltinFuncObject(m.Name)
-				self.tmpString.Val = m.Name
-				prop.SetProp(self.tmpString, fn)
+				prop.SetProp(self.Intern(m.Name), fn)
 			}
 		}
 	} else {
 		for i := 0; i < numMethods; i++ {
 			m := typ.Method(i)
 			fn := self.NewBuiltinFuncObject(m.Name)
-			self.tmpString.Val = m.Name
-			prop.SetProp(self.tmpString, fn)
+			prop.SetProp(self.Intern(m.Name), fn)
 		}
 	}
 }
@@ -215,12 +381,30 @@ func (self *Runtime) initBuiltinObjectProperties() {
 	intObj := self.NewIntegerObject(0)
 	self.addObjectProperties(intObj, &self.integerProperties)
 
+	bigintObj := self.NewBigIntObject(new(big.Int))
+	self.addObjectProperties(bigintObj, &self.bigintProperties)
+
 	floatObj := self.NewFloatObject(0)
 	self.addObjectProperties(floatObj, &self.floatProperties)
 
+	complexObj := self.NewComplexObject(0)
+	self.addObjectProperties(complexObj, &self.complexProperties)
+
 	stringObj := self.NewStringObject("")
 	self.addObjectProperties(stringObj, &self.stringProperties)
 
+	symbolObj := self.Intern("")
+	self.addObjectProperties(symbolObj, &self.symbolProperties)
+
+	regexpObj := self.NewRegexpObject(nil, "", "")
+	self.addObjectProperties(regexpObj, &self.regexpProperties)
+
+	runeObj := self.NewRuneObject(0)
+	self.addObjectProperties(runeObj, &self.runeProperties)
+
+	bytesObj := self.NewBytesObject(nil)
+	self.addObjectProperties(bytesObj, &self.bytesProperties)
+
 	arrayObj := self.NewArrayObject(nil)
 	self.addObjectProperties(arrayObj, &self.arrayProperties)
 
@@ -230,6 +414,15 @@ func (self *Runtime) initBuiltinObjectProperties() {
 	setObj := self.NewSetObject(nil)
 	self.addObjectProperties(setObj, &self.setProperties)
 
+	tupleObj := self.NewTupleObject(nil)
+	self.addObjectProperties(tupleObj, &self.tupleProperties)
+
+	rangeObj := &RangeObject{}
+	self.addObjectProperties(rangeObj, &self.rangeProperties)
+
+	dictIterObj := &dictIter{}
+	self.addObjectProperties(dictIterObj, &self.dictIterProperties)
+
 	boolObj := self.NewBoolObject(false)
 	self.addObjectProperties(boolObj, &self.boolProperties)
 
@@ -239,7 +432,16 @@ func (self *Runtime) initBuiltinObjectProperties() {
 	goObj := self.NewGoObject(nil)
 	self.addObjectProperties(goObj, &self.goobjProperties)
 
+	gotypeObj := self.NewGoTypeObject(reflect.TypeOf(0))
+	self.addObjectProperties(gotypeObj, &self.gotypeProperties)
+
 	self.addObjectProperties(self.Nil, &self.nilProperties)
+
+	genObj := &GeneratorObject{}
+	self.addObjectProperties(genObj, &self.genProperties)
+
+	lazyObj := self.NewLazyObject(nil)
+	self.addObjectProperties(lazyObj, &self.lazyProperties)
 }
 
 /// register
@@ -251,6 +453,9 @@ func (self *Runtime) GoValueToObject(obj interface{}) Object {
 
 	switch kind {
 	case reflect.Slice, reflect.Array:
+		if val.Type().Elem().Kind() == reflect.Uint8 && kind == reflect.Slice {
+			return self.NewBytesObject(val.Bytes())
+		}
 		elems := []Object{}
 		for i := 0; i < val.Len(); i++ {
 			elems = append(elems, self.GoValueToObject(val.Index(i).Interface()))
@@ -258,10 +463,13 @@ func (self *Runtime) GoValueToObject(obj interface{}) Object {
 		return self.NewArrayObject(elems)
 	case reflect.String:
 		return self.NewStringObject(obj.(string))
-	case reflect.Int, reflect.Int64:
-		return self.NewIntegerObject(obj.(int))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return self.goIntToObject(val)
 	case reflect.Float32, reflect.Float64:
-		return self.NewFloatObject(obj.(float64))
+		return self.NewFloatObject(val.Float())
+	case reflect.Complex64, reflect.Complex128:
+		return self.NewComplexObject(val.Complex())
 	case reflect.Bool:
 		if obj.(bool) == true {
 			return self.True
@@ -269,72 +477,122 @@ func (self *Runtime) GoValueToObject(obj interface{}) Object {
 			return self.False
 		}
 	default:
+		if b, ok := obj.(*big.Int); ok && b != nil {
+			return self.NewInteger(new(big.Int).Set(b))
+		}
 		return self.NewGoObject(obj)
 	}
 }
 
-func (self *Runtime) RegisterVars(name string, vars map[string]interface{}) {
-	dict, _ := self.Env.LookUp(name)
+// uint64 values above the int64 range become bigints
+func (self *Runtime) goIntToObject(val reflect.Value) Object {
+	switch val.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u := val.Uint()
+		if u > math.MaxInt64 {
+			return self.NewBigIntObject(new(big.Int).SetUint64(u))
+		}
+		return self.NewIntegerObject(int(u))
+	}
+	return self.NewIntegerObject(int(val.Int()))
+}
 
-	m := map[string]Slot{}
-	for k, v := range vars {
-		self.tmpString.Val = k
-		m[self.tmpString.HashCode()] = Slot{self.tmpString, self.GoValueToObject(v)}
+// bigIntToValue converts to *big.Int or to an integer type holding it
+func bigIntToValue(obj *BigIntObject, typ reflect.Type) (reflect.Value, bool) {
+	bigTyp := reflect.TypeOf(obj.Val)
+	if typ == nil || bigTyp.AssignableTo(typ) {
+		return reflect.ValueOf(new(big.Int).Set(obj.Val)), true
 	}
 
-	if dict == nil {
-		dict = self.NewDictObject(m)
-	} else {
-		for k, v := range m {
-			self.tmpString.Val = k
-			dict.(*DictObject).SetProp(self.tmpString, v.Val)
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if obj.Val.IsInt64() {
+			return reflect.ValueOf(obj.Val.Int64()).Convert(typ), true
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if obj.Val.IsUint64() {
+			return reflect.ValueOf(obj.Val.Uint64()).Convert(typ), true
 		}
+	case reflect.Float32, reflect.Float64:
+		return reflect.ValueOf(bigToFloat(obj.Val)).Convert(typ), true
 	}
-	self.Env.Put(name, dict)
+	return reflect.Value{}, false
 }
 
-func (self *Runtime) RegisterFunctions(name string, vars []interface{}) {
+// modules are dicts whose keys win over the dict methods, so a go function
+// like strings.Map is not hidden by dict::Map
+func (self *Runtime) module(name string) *DictObject {
 	dict, _ := self.Env.LookUp(name)
+	if dict == nil {
+		mod := self.NewDictObject(nil).(*DictObject)
+		mod.module = true
+		self.Env.Put(name, mod)
+		return mod
+	}
+	return dict.(*DictObject)
+}
+
+func (self *Runtime) RegisterVars(name string, vars map[string]interface{}) {
+	mod := self.module(name)
+
+	keys := make([]string, 0, len(vars))
+	for k := range vars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		mod.put(self.Intern(k), self.GoValueToObject(vars[k]))
+	}
+}
+
+func (self *Runtime) RegisterFunctions(name string, vars []interface{}) {
+	mod := self.module(name)
 
-	m := map[string]Slot{}
 	for _, v := range vars {
 		name := runtime.FuncForPC(reflect.ValueOf(v).Pointer()).Name()
 		xs := strings.Split(name, ".")
-		self.tmpString.Val = xs[len(xs)-1]
-		m[self.tmpString.HashCode()] = Slot{self.tmpString, self.NewGoFuncObject(name, v)}
+		mod.put(self.Intern(xs[len(xs)-1]), self.NewGoFuncObject(name, v))
 	}
+}
 
-	if dict == nil {
-		dict = self.NewDictObject(m)
-	} else {
-		for k, v := range m {
-			self.tmpString.Val = k
-			dict.(*DictObject).SetProp(self.tmpString, v.Val)
-		}
+// RegisterFuncMap adds go functions to a module under the given names
+func (self *Runtime) RegisterFuncMap(name string, fns map[string]interface{}) {
+	mod := self.module(name)
+
+	names := make([]string, 0, len(fns))
+	for k := range fns {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	for _, k := range names {
+		mod.put(self.Intern(k), self.NewGoFuncObject(name+"."+k, fns[k]))
 	}
-	self.Env.Put(name, dict)
 }
 
-func (self *Runtime) registerGlobals(env *env.Env) {
-	self.RegisterFunctions("fmt", []interface{}{
-		fmt.Errorf,
-		fmt.Println, fmt.Print, fmt.Printf,
-		fmt.Fprint, fmt.Fprint, fmt.Fprintln, fmt.Fscan, fmt.Fscanf, fmt.Fscanln,
-		fmt.Scan, fmt.Scanf, fmt.Scanln,
-		fmt.Sscan, fmt.Sscanf, fmt.Sscanln,
-		fmt.Sprint, fmt.Sprintf, fmt.Sprintln,
-	})
+// RegisterNatives adds native functions to a module, see NativeFunc
+func (self *Runtime) RegisterNatives(name string, fns map[string]NativeFunc) {
+	mod := self.module(name)
 
-	self.RegisterFunctions("log", []interface{}{
-		log.Fatal, log.Fatalf, log.Fatalln, log.Flags, log.Panic, log.Panicf, log.Panicln,
-		log.Print, log.Printf, log.Println, log.SetFlags, log.SetOutput, log.SetPrefix,
-	})
+	names := make([]string, 0, len(fns))
+	for k := range fns {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	for _, k := range names {
+		mod.put(self.Intern(k), self.NewGoFuncObject(name+"."+k, fns[k]))
+	}
+}
 
-	self.RegisterFunctions("os", []interface{}{
-		os.Chdir, os.Chmod, os.Chown, os.Exit, os.Getpid,
-		os.Hostname, os.Environ, os.Getenv, os.Setenv,
-		os.Create, os.Open,
-	})
+//go:generate go run ../cmd/dobybind -go go1.21 -o stdlib.go bufio bytes errors fmt io io/ioutil log math math/rand net/http net/http/httptest os path path/filepath sort strconv strings time unicode unicode/utf8
+
+// the go packages of stdlib.go are registered whole, the entries below
+// replace some of them
+func (self *Runtime) registerGlobals(env *env.Env) {
+	self.registerStdlib()
+
+	builtin := self.module("builtin")
+	builtin.put(self.Intern("new"), self.NewGoFuncObject("new", NativeFunc(goNew)))
+	builtin.put(self.Intern("append"), self.NewGoFuncObject("append", NativeFunc(goAppend)))
 
 	argsStart := 1
 	if len(os.Args) > 2 {
@@ -344,24 +602,20 @@ func (self *Runtime) registerGlobals(env *env.Env) {
 		"Args": os.Args[argsStart:],
 	})
 
-	self.RegisterFunctions("time", []interface{}{
-		time.Sleep, time.Now, time.Unix,
+	self.RegisterNatives("math", map[string]NativeFunc{
+		"Min": mathMin, "Max": mathMax,
 	})
 
-	self.RegisterFunctions("math/rand", []interface{}{
-		rand.New, rand.NewSource,
-		rand.Float64, rand.ExpFloat64, rand.Float32, rand.Int,
-		rand.Int31, rand.Int31n, rand.Int63, rand.Int63n, rand.Intn,
-		rand.NormFloat64, rand.Perm, rand.Seed, rand.Uint32,
+	self.RegisterNatives("json", map[string]NativeFunc{
+		"Parse": jsonParse, "Stream": jsonStream, "Dump": jsonDump, "Pretty": jsonPretty,
 	})
 
-	self.RegisterFunctions("io/ioutil", []interface{}{
-		ioutil.WriteFile, ioutil.ReadFile, ioutil.TempDir, ioutil.TempFile,
-		ioutil.ReadAll, ioutil.ReadDir, ioutil.NopCloser,
+	self.RegisterNatives("yaml", map[string]NativeFunc{
+		"Parse": yamlParse, "ParseAll": yamlParseAll,
 	})
 
-	self.RegisterFunctions("bufio", []interface{}{
-		bufio.NewWriter, bufio.NewReader, bufio.NewReadWriter, bufio.NewScanner,
+	self.RegisterNatives("toml", map[string]NativeFunc{
+		"Parse": tomlParse,
 	})
 }
 
@@ -395,9 +649,26 @@ func (self *Runtime) ShiftTopN(n, pos int) {
 	self.Stack.ShiftTopN(n, pos)
 }
 
+// a body without return gives nil, the values its statements left are dropped
+func (self *Runtime) ReturnNil(pos int) {
+	self.Stack.ShiftTopN(0, pos)
+	self.Push(self.Nil)
+}
+
 func (self *Runtime) Fatalf(format string, a ...interface{}) {
-	fmt.Printf("Runtime Error: "+format, a...)
-	fmt.Println()
+	msg := fmt.Sprintf("Runtime Error: "+format+"\n", a...)
+	if self.Pos > 0 && self.PosInfo != nil {
+		msg = self.PosInfo(self.Pos) + msg
+	}
+	self.exit(msg)
+}
+
+// exit ends the script with an error
+func (self *Runtime) exit(msg string) {
+	if self.Fail != nil {
+		self.Fail(msg)
+	}
+	fmt.Print(msg)
 	os.Exit(1)
 }
 
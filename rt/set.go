@@ -6,10 +6,48 @@ import (
 
 /// set
 
+// a hash set keeping its elements in insertion order, index maps the key of
+// an element to its position in Vals
 type SetObject struct {
 	Property
+	Enumerable
 
-	Vals []Object
+	Vals  []Object
+	index map[string]int
+}
+
+// the type is part of the key so that 1 and "1", whose hash codes are the
+// same string, stay different elements
+func setKey(obj Object) string {
+	return obj.Name() + "\x00" + obj.HashCode()
+}
+
+func (self *SetObject) add(obj Object) {
+	if self.index == nil {
+		self.index = map[string]int{}
+	}
+	key := setKey(obj)
+	if _, ok := self.index[key]; !ok {
+		self.index[key] = len(self.Vals)
+		self.Vals = append(self.Vals, obj)
+	}
+}
+
+func (self *SetObject) has(obj Object) bool {
+	_, ok := self.index[setKey(obj)]
+	return ok
+}
+
+func (self *SetObject) delete(obj Object) {
+	i, ok := self.index[setKey(obj)]
+	if !ok {
+		return
+	}
+	delete(self.index, setKey(obj))
+	self.Vals = append(self.Vals[:i], self.Vals[i+1:]...)
+	for j := i; j < len(self.Vals); j++ {
+		self.index[setKey(self.Vals[j])] = j
+	}
 }
 
 func (self *SetObject) Name() string {
@@ -37,6 +75,140 @@ func (self *SetObject) ToString(rt *Runtime, args ...Object) []Object {
 	return []Object{rt.NewStringObject(self.String())}
 }
 
+func (self *SetObject) Freeze(rt *Runtime, args ...Object) (results []Object) {
+	if !self.frozen {
+		self.frozen = true
+		for _, val := range self.Vals {
+			val.Freeze(rt)
+		}
+	}
+	results = append(results, self)
+	return
+}
+
+func (self *Runtime) setArg(method string, arg Object) *SetObject {
+	set, ok := arg.(*SetObject)
+	if !ok {
+		self.Fatalf("set::%s needs a set, %s given", method, arg.Name())
+	}
+	return set
+}
+
+func (self *SetObject) Add(rt *Runtime, args ...Object) (results []Object) {
+	rt.checkFrozen(self)
+	for _, arg := range args {
+		self.add(arg)
+	}
+	results = append(results, self)
+	return
+}
+
+func (self *SetObject) Delete(rt *Runtime, args ...Object) (results []Object) {
+	rt.checkFrozen(self)
+	for _, arg := range args {
+		self.delete(arg)
+	}
+	results = append(results, self)
+	return
+}
+
+func (self *SetObject) Has(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewBoolObject(self.has(args[0])))
+	return
+}
+
+func (self *SetObject) Include(rt *Runtime, args ...Object) (results []Object) {
+	return self.Has(rt, args...)
+}
+
+func (self *SetObject) Size(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewIntegerObject(len(self.Vals)))
+	return
+}
+
+func (self *SetObject) Length(rt *Runtime, args ...Object) (results []Object) {
+	return self.Size(rt, args...)
+}
+
+func (self *SetObject) ToArray(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewArrayObject(append([]Object{}, self.Vals...)))
+	return
+}
+
+func (self *SetObject) Union(rt *Runtime, args ...Object) (results []Object) {
+	other := rt.setArg("Union", args[0])
+	vals := append(append([]Object{}, self.Vals...), other.Vals...)
+	results = append(results, rt.NewSetObject(vals))
+	return
+}
+
+func (self *SetObject) Intersect(rt *Runtime, args ...Object) (results []Object) {
+	other := rt.setArg("Intersect", args[0])
+	vals := []Object{}
+	for _, val := range self.Vals {
+		if other.has(val) {
+			vals = append(vals, val)
+		}
+	}
+	results = append(results, rt.NewSetObject(vals))
+	return
+}
+
+func (self *SetObject) Difference(rt *Runtime, args ...Object) (results []Object) {
+	other := rt.setArg("Difference", args[0])
+	vals := []Object{}
+	for _, val := range self.Vals {
+		if !other.has(val) {
+			vals = append(vals, val)
+		}
+	}
+	results = append(results, rt.NewSetObject(vals))
+	return
+}
+
+func (self *SetObject) SubsetOf(rt *Runtime, args ...Object) (results []Object) {
+	other := rt.setArg("SubsetOf", args[0])
+	results = append(results, rt.NewBoolObject(self.subsetOf(other)))
+	return
+}
+
+func (self *SetObject) subsetOf(other *SetObject) bool {
+	for _, val := range self.Vals {
+		if !other.has(val) {
+			return false
+		}
+	}
+	return true
+}
+
+/// operators
+
+func (self *SetObject) OP__or__(rt *Runtime, args ...Object) (results []Object) {
+	return self.Union(rt, args...)
+}
+
+func (self *SetObject) OP__and__(rt *Runtime, args ...Object) (results []Object) {
+	return self.Intersect(rt, args...)
+}
+
+func (self *SetObject) OP__sub__(rt *Runtime, args ...Object) (results []Object) {
+	return self.Difference(rt, args...)
+}
+
+// sets are equal when they have the same elements in any order
+func (self *SetObject) OP__eql__(rt *Runtime, args ...Object) (results []Object) {
+	other, ok := args[0].(*SetObject)
+	eq := ok && len(self.Vals) == len(other.Vals) && self.subsetOf(other)
+	results = append(results, rt.NewBoolObject(eq))
+	return
+}
+
+func (self *SetObject) OP__neq__(rt *Runtime, args ...Object) (results []Object) {
+	eq := self.OP__eql__(rt, args...)[0].(*BoolObject)
+	results = append(results, rt.NewBoolObject(!eq.Val))
+	return
+}
+
 func (self *SetObject) OP__iter__(rt *Runtime, args ...Object) (results []Object) {
 	idx := args[0].(*IntegerObject)
 	if idx.Val < len(self.Vals) {
@@ -0,0 +1,78 @@
+package rt
+
+/// index and slice helpers shared by arrays, strings and bytes
+
+// index resolves a possibly negative index against a sequence of length n,
+// counting from the end like ruby and python
+func (self *Runtime) index(obj Object, n int) int {
+	idx, ok := obj.(*IntegerObject)
+	if !ok {
+		self.Fatalf("index must be an integer, %s given", obj.Name())
+	}
+	i := idx.Val
+	if i < 0 {
+		i += n
+	}
+	if i < 0 || i >= n {
+		self.Fatalf("index %d out of range for length %d", idx.Val, n)
+	}
+	return i
+}
+
+// sliceIndices resolves the low, high and step arguments of __slice__ the
+// way python does: nil takes the default, negative bounds count from the end
+// and out of range bounds are clamped to the sequence
+func (self *Runtime) sliceIndices(args []Object, n int) (lo, hi, step int) {
+	bound := func(obj Object, def int) int {
+		if _, ok := obj.(*NilObject); ok || obj == nil {
+			return def
+		}
+		v, ok := obj.(*IntegerObject)
+		if !ok {
+			self.Fatalf("slice indices must be integers, %s given", obj.Name())
+		}
+		return v.Val
+	}
+
+	step = 1
+	if len(args) > 2 {
+		step = bound(args[2], 1)
+	}
+	if step == 0 {
+		self.Fatalf("slice step cannot be zero")
+	}
+
+	clamp := func(i, min, max int) int {
+		if i < 0 {
+			i += n
+		}
+		if i < min {
+			return min
+		}
+		if i > max {
+			return max
+		}
+		return i
+	}
+
+	if step > 0 {
+		lo = clamp(bound(args[0], 0), 0, n)
+		hi = clamp(bound(args[1], n), 0, n)
+	} else {
+		lo = clamp(bound(args[0], n-1), -1, n-1)
+		hi = bound(args[1], -1-n)
+		hi = clamp(hi, -1, n-1)
+	}
+	return
+}
+
+// sliceLen counts the elements selected by resolved slice indices
+func sliceLen(lo, hi, step int) int {
+	if step > 0 && lo < hi {
+		return (hi - lo + step - 1) / step
+	}
+	if step < 0 && lo > hi {
+		return (lo - hi - step - 1) / -step
+	}
+	return 0
+}
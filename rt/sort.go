@@ -0,0 +1,231 @@
+package rt
+
+import (
+	"reflect"
+	"strings"
+)
+
+/// sorting
+
+// nil, bool, number and string come first in this order, other kinds follow
+// sorted by name
+var compareRanks = map[string]int{"nil": 1, "bool": 2, "number": 3, "string": 4}
+
+func sign(less, greater bool) int {
+	switch {
+	case less:
+		return -1
+	case greater:
+		return 1
+	}
+	return 0
+}
+
+// NaN sorts before every other float
+func compareFloat(x, y float64) int {
+	if x != x || y != y {
+		return sign(y == y, x == x)
+	}
+	return sign(x < y, x > y)
+}
+
+// Compare is the default order of Sort and Min/Max. Numbers compare by value,
+// strings, runes and symbols by bytes, arrays and tuples element by element, and
+// objects with a __cmp__ method, or dicts with a __cmp__ entry, by calling
+// it. Everything else falls back to __lss__ and __eql__
+func (self *Runtime) Compare(a, b Object) int {
+	ka, kb := matchKind(a), matchKind(b)
+	if ka != kb {
+		ra, rb := compareRanks[ka], compareRanks[kb]
+		if ra == 0 && rb == 0 {
+			return strings.Compare(ka, kb)
+		}
+		if ra == 0 || rb == 0 {
+			return sign(ra != 0, rb != 0)
+		}
+		return sign(ra < rb, ra > rb)
+	}
+
+	switch x := a.(type) {
+	case *NilObject:
+		return 0
+	case *BoolObject:
+		y := b.(*BoolObject)
+		return sign(!x.Val && y.Val, x.Val && !y.Val)
+	case *IntegerObject:
+		switch y := b.(type) {
+		case *IntegerObject:
+			return sign(x.Val < y.Val, x.Val > y.Val)
+		case *FloatObject:
+			return compareFloat(float64(x.Val), y.Val)
+		}
+	case *FloatObject:
+		switch y := b.(type) {
+		case *IntegerObject:
+			return compareFloat(x.Val, float64(y.Val))
+		case *FloatObject:
+			return compareFloat(x.Val, y.Val)
+		}
+	}
+	if ka == "string" || ka == "symbol" {
+		return strings.Compare(a.String(), b.String())
+	}
+
+	if xs, ok := sequenceVals(a); ok {
+		ys, _ := sequenceVals(b)
+		for i := 0; i < len(xs) && i < len(ys); i++ {
+			if c := self.Compare(xs[i], ys[i]); c != 0 {
+				return c
+			}
+		}
+		return sign(len(xs) < len(ys), len(xs) > len(ys))
+	}
+
+	if c, ok := self.userCompare(a, b); ok {
+		return c
+	}
+	if !reflect.ValueOf(a).MethodByName("OP__lss__").IsValid() {
+		self.Fatalf("cannot compare %s %s with %s", a.Name(), a, b)
+	}
+	if self.invokeBool(a, "__lss__", b) {
+		return -1
+	}
+	if self.invokeBool(a, "__eql__", b) {
+		return 0
+	}
+	return 1
+}
+
+// calls the __cmp__ method of a builtin object or the __cmp__ function of a
+// dict, which returns a negative, zero or positive integer
+func (self *Runtime) userCompare(a, b Object) (int, bool) {
+	var ret Object
+	if dict, ok := a.(*DictObject); ok {
+		fn, ok := dict.get(self.Intern("__cmp__"))
+		if !ok {
+			return 0, false
+		}
+		ret = self.Call(fn, a, b)
+	} else if reflect.ValueOf(a).MethodByName("OP__cmp__").IsValid() {
+		ret = Invoke(self, a, "__cmp__", b)[0]
+	} else {
+		return 0, false
+	}
+
+	c, ok := ret.(*IntegerObject)
+	if !ok {
+		self.Fatalf("__cmp__ of %s returned %s, it must return an integer", a.Name(), ret.Name())
+	}
+	return sign(c.Val < 0, c.Val > 0), true
+}
+
+func (self *Runtime) less(a, b Object) bool {
+	return self.Compare(a, b) < 0
+}
+
+func sortFunc(args []Object) Object {
+	if len(args) > 0 {
+		return args[0]
+	}
+	return nil
+}
+
+// the number of parameters of a function, native functions and builtin
+// methods count as taking one
+func arity(fn Object) int {
+	switch fn := fn.(type) {
+	case *ClosureObject:
+		return fn.Proto.NumArgs()
+	case *GoFuncObject:
+		if _, ok := fn.fn.(NativeFunc); ok {
+			return 1
+		}
+		return fn.typ.NumIn()
+	}
+	return 1
+}
+
+// sortObjects sorts vals in place and keeps equal elements in order. fn is
+// optional, a function of one argument is a key function called once per
+// element and a function of two is a comparator returning a negative, zero
+// or positive integer, or whether its first argument goes first
+func (self *Runtime) sortObjects(vals []Object, fn Object) {
+	if fn == nil {
+		self.sortKeys(vals, vals)
+		return
+	}
+	if arity(fn) != 2 {
+		keys := make([]Object, len(vals))
+		for i, val := range vals {
+			keys[i] = self.Call(fn, val)
+		}
+		self.sortKeys(vals, keys)
+		return
+	}
+
+	perm := mergeSort(len(vals), func(i, j int) bool {
+		switch ret := self.Call(fn, vals[i], vals[j]).(type) {
+		case *IntegerObject:
+			return ret.Val < 0
+		case *BoolObject:
+			return ret.Val
+		default:
+			self.Fatalf("sort comparator returned %s, it must return an integer or a bool", ret.Name())
+		}
+		return false
+	})
+	permute(vals, perm)
+}
+
+// sorts vals by keys, which may be vals itself
+func (self *Runtime) sortKeys(vals, keys []Object) {
+	perm := mergeSort(len(vals), func(i, j int) bool {
+		return self.Compare(keys[i], keys[j]) < 0
+	})
+	permute(vals, perm)
+}
+
+func permute(vals []Object, perm []int) {
+	sorted := make([]Object, len(vals))
+	for i, j := range perm {
+		sorted[i] = vals[j]
+	}
+	copy(vals, sorted)
+}
+
+// a stable merge sort of the indices 0..n-1, it calls less O(n log n) times
+// and never calls it with an index against itself
+func mergeSort(n int, less func(i, j int) bool) []int {
+	perm := make([]int, n)
+	tmp := make([]int, n)
+	for i := range perm {
+		perm[i] = i
+	}
+	for width := 1; width < n; width *= 2 {
+		for lo := 0; lo < n; lo += 2 * width {
+			mid, hi := lo+width, lo+2*width
+			if mid >= n {
+				copy(tmp[lo:], perm[lo:])
+				continue
+			}
+			if hi > n {
+				hi = n
+			}
+			i, j, k := lo, mid, lo
+			for i < mid && j < hi {
+				if less(perm[j], perm[i]) {
+					tmp[k] = perm[j]
+					j++
+				} else {
+					tmp[k] = perm[i]
+					i++
+				}
+				k++
+			}
+			k += copy(tmp[k:], perm[i:mid])
+			copy(tmp[k:], perm[j:hi])
+		}
+		perm, tmp = tmp, perm
+	}
+	return perm
+}
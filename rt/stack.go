@@ -32,6 +32,11 @@ func (self *Stack) Pop() Object {
 	return self.vals[self.cur]
 }
 
+// the number of values on the stack
+func (self *Stack) Len() int {
+	return self.cur
+}
+
 func (self *Stack) Mark() {
 	self.mark = append(self.mark, self.cur)
 }
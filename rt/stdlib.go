@@ -0,0 +1,1260 @@
+// Code generated by dobybind. DO NOT EDIT.
+
+package rt
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+func (self *Runtime) registerStdlib() {
+	self.RegisterFuncMap("bufio", map[string]interface{}{
+		"NewReadWriter": bufio.NewReadWriter,
+		"NewReader":     bufio.NewReader,
+		"NewReaderSize": bufio.NewReaderSize,
+		"NewScanner":    bufio.NewScanner,
+		"NewWriter":     bufio.NewWriter,
+		"NewWriterSize": bufio.NewWriterSize,
+		"ScanBytes":     bufio.ScanBytes,
+		"ScanLines":     bufio.ScanLines,
+		"ScanRunes":     bufio.ScanRunes,
+		"ScanWords":     bufio.ScanWords,
+	})
+	self.RegisterVars("bufio", map[string]interface{}{
+		"ErrAdvanceTooFar":     bufio.ErrAdvanceTooFar,
+		"ErrBadReadCount":      bufio.ErrBadReadCount,
+		"ErrBufferFull":        bufio.ErrBufferFull,
+		"ErrFinalToken":        bufio.ErrFinalToken,
+		"ErrInvalidUnreadByte": bufio.ErrInvalidUnreadByte,
+		"ErrInvalidUnreadRune": bufio.ErrInvalidUnreadRune,
+		"ErrNegativeAdvance":   bufio.ErrNegativeAdvance,
+		"ErrNegativeCount":     bufio.ErrNegativeCount,
+		"ErrTooLong":           bufio.ErrTooLong,
+		"MaxScanTokenSize":     int64(bufio.MaxScanTokenSize),
+	})
+	self.RegisterTypes("bufio", []interface{}{
+		(*bufio.ReadWriter)(nil),
+		(*bufio.Reader)(nil),
+		(*bufio.Scanner)(nil),
+		(*bufio.SplitFunc)(nil),
+		(*bufio.Writer)(nil),
+	})
+
+	self.RegisterFuncMap("bytes", map[string]interface{}{
+		"Clone":           bytes.Clone,
+		"Compare":         bytes.Compare,
+		"Contains":        bytes.Contains,
+		"ContainsAny":     bytes.ContainsAny,
+		"ContainsFunc":    bytes.ContainsFunc,
+		"ContainsRune":    bytes.ContainsRune,
+		"Count":           bytes.Count,
+		"Cut":             bytes.Cut,
+		"CutPrefix":       bytes.CutPrefix,
+		"CutSuffix":       bytes.CutSuffix,
+		"Equal":           bytes.Equal,
+		"EqualFold":       bytes.EqualFold,
+		"Fields":          bytes.Fields,
+		"FieldsFunc":      bytes.FieldsFunc,
+		"HasPrefix":       bytes.HasPrefix,
+		"HasSuffix":       bytes.HasSuffix,
+		"Index":           bytes.Index,
+		"IndexAny":        bytes.IndexAny,
+		"IndexByte":       bytes.IndexByte,
+		"IndexFunc":       bytes.IndexFunc,
+		"IndexRune":       bytes.IndexRune,
+		"Join":            bytes.Join,
+		"LastIndex":       bytes.LastIndex,
+		"LastIndexAny":    bytes.LastIndexAny,
+		"LastIndexByte":   bytes.LastIndexByte,
+		"LastIndexFunc":   bytes.LastIndexFunc,
+		"Map":             bytes.Map,
+		"NewBuffer":       bytes.NewBuffer,
+		"NewBufferString": bytes.NewBufferString,
+		"NewReader":       bytes.NewReader,
+		"Repeat":          bytes.Repeat,
+		"Replace":         bytes.Replace,
+		"ReplaceAll":      bytes.ReplaceAll,
+		"Runes":           bytes.Runes,
+		"Split":           bytes.Split,
+		"SplitAfter":      bytes.SplitAfter,
+		"SplitAfterN":     bytes.SplitAfterN,
+		"SplitN":          bytes.SplitN,
+		"Title":           bytes.Title,
+		"ToLower":         bytes.ToLower,
+		"ToLowerSpecial":  bytes.ToLowerSpecial,
+		"ToTitle":         bytes.ToTitle,
+		"ToTitleSpecial":  bytes.ToTitleSpecial,
+		"ToUpper":         bytes.ToUpper,
+		"ToUpperSpecial":  bytes.ToUpperSpecial,
+		"ToValidUTF8":     bytes.ToValidUTF8,
+		"Trim":            bytes.Trim,
+		"TrimFunc":        bytes.TrimFunc,
+		"TrimLeft":        bytes.TrimLeft,
+		"TrimLeftFunc":    bytes.TrimLeftFunc,
+		"TrimPrefix":      bytes.TrimPrefix,
+		"TrimRight":       bytes.TrimRight,
+		"TrimRightFunc":   bytes.TrimRightFunc,
+		"TrimSpace":       bytes.TrimSpace,
+		"TrimSuffix":      bytes.TrimSuffix,
+	})
+	self.RegisterVars("bytes", map[string]interface{}{
+		"ErrTooLarge": bytes.ErrTooLarge,
+		"MinRead":     int64(bytes.MinRead),
+	})
+	self.RegisterTypes("bytes", []interface{}{
+		(*bytes.Buffer)(nil),
+		(*bytes.Reader)(nil),
+	})
+
+	self.RegisterFuncMap("errors", map[string]interface{}{
+		"As":     errors.As,
+		"Is":     errors.Is,
+		"Join":   errors.Join,
+		"New":    errors.New,
+		"Unwrap": errors.Unwrap,
+	})
+	self.RegisterVars("errors", map[string]interface{}{
+		"ErrUnsupported": errors.ErrUnsupported,
+	})
+
+	self.RegisterFuncMap("fmt", map[string]interface{}{
+		"Append":       fmt.Append,
+		"Appendf":      fmt.Appendf,
+		"Appendln":     fmt.Appendln,
+		"Errorf":       fmt.Errorf,
+		"FormatString": fmt.FormatString,
+		"Fprint":       fmt.Fprint,
+		"Fprintf":      fmt.Fprintf,
+		"Fprintln":     fmt.Fprintln,
+		"Fscan":        fmt.Fscan,
+		"Fscanf":       fmt.Fscanf,
+		"Fscanln":      fmt.Fscanln,
+		"Print":        fmt.Print,
+		"Printf":       fmt.Printf,
+		"Println":      fmt.Println,
+		"Scan":         fmt.Scan,
+		"Scanf":        fmt.Scanf,
+		"Scanln":       fmt.Scanln,
+		"Sprint":       fmt.Sprint,
+		"Sprintf":      fmt.Sprintf,
+		"Sprintln":     fmt.Sprintln,
+		"Sscan":        fmt.Sscan,
+		"Sscanf":       fmt.Sscanf,
+		"Sscanln":      fmt.Sscanln,
+	})
+
+	self.RegisterFuncMap("io", map[string]interface{}{
+		"Copy":             io.Copy,
+		"CopyBuffer":       io.CopyBuffer,
+		"CopyN":            io.CopyN,
+		"LimitReader":      io.LimitReader,
+		"MultiReader":      io.MultiReader,
+		"MultiWriter":      io.MultiWriter,
+		"NewOffsetWriter":  io.NewOffsetWriter,
+		"NewSectionReader": io.NewSectionReader,
+		"NopCloser":        io.NopCloser,
+		"Pipe":             io.Pipe,
+		"ReadAll":          io.ReadAll,
+		"ReadAtLeast":      io.ReadAtLeast,
+		"ReadFull":         io.ReadFull,
+		"TeeReader":        io.TeeReader,
+		"WriteString":      io.WriteString,
+	})
+	self.RegisterVars("io", map[string]interface{}{
+		"Discard":          io.Discard,
+		"EOF":              io.EOF,
+		"ErrClosedPipe":    io.ErrClosedPipe,
+		"ErrNoProgress":    io.ErrNoProgress,
+		"ErrShortBuffer":   io.ErrShortBuffer,
+		"ErrShortWrite":    io.ErrShortWrite,
+		"ErrUnexpectedEOF": io.ErrUnexpectedEOF,
+		"SeekCurrent":      int64(io.SeekCurrent),
+		"SeekEnd":          int64(io.SeekEnd),
+		"SeekStart":        int64(io.SeekStart),
+	})
+	self.RegisterTypes("io", []interface{}{
+		(*io.LimitedReader)(nil),
+		(*io.OffsetWriter)(nil),
+		(*io.PipeReader)(nil),
+		(*io.PipeWriter)(nil),
+		(*io.SectionReader)(nil),
+	})
+
+	self.RegisterFuncMap("io/ioutil", map[string]interface{}{
+		"NopCloser": ioutil.NopCloser,
+		"ReadAll":   ioutil.ReadAll,
+		"ReadDir":   ioutil.ReadDir,
+		"ReadFile":  ioutil.ReadFile,
+		"TempDir":   ioutil.TempDir,
+		"TempFile":  ioutil.TempFile,
+		"WriteFile": ioutil.WriteFile,
+	})
+	self.RegisterVars("io/ioutil", map[string]interface{}{
+		"Discard": ioutil.Discard,
+	})
+
+	self.RegisterFuncMap("log", map[string]interface{}{
+		"Default":   log.Default,
+		"Fatal":     log.Fatal,
+		"Fatalf":    log.Fatalf,
+		"Fatalln":   log.Fatalln,
+		"Flags":     log.Flags,
+		"New":       log.New,
+		"Output":    log.Output,
+		"Panic":     log.Panic,
+		"Panicf":    log.Panicf,
+		"Panicln":   log.Panicln,
+		"Prefix":    log.Prefix,
+		"Print":     log.Print,
+		"Printf":    log.Printf,
+		"Println":   log.Println,
+		"SetFlags":  log.SetFlags,
+		"SetOutput": log.SetOutput,
+		"SetPrefix": log.SetPrefix,
+		"Writer":    log.Writer,
+	})
+	self.RegisterVars("log", map[string]interface{}{
+		"LUTC":          int64(log.LUTC),
+		"Ldate":         int64(log.Ldate),
+		"Llongfile":     int64(log.Llongfile),
+		"Lmicroseconds": int64(log.Lmicroseconds),
+		"Lmsgprefix":    int64(log.Lmsgprefix),
+		"Lshortfile":    int64(log.Lshortfile),
+		"LstdFlags":     int64(log.LstdFlags),
+		"Ltime":         int64(log.Ltime),
+	})
+	self.RegisterTypes("log", []interface{}{
+		(*log.Logger)(nil),
+	})
+
+	self.RegisterFuncMap("math", map[string]interface{}{
+		"Abs":             math.Abs,
+		"Acos":            math.Acos,
+		"Acosh":           math.Acosh,
+		"Asin":            math.Asin,
+		"Asinh":           math.Asinh,
+		"Atan":            math.Atan,
+		"Atan2":           math.Atan2,
+		"Atanh":           math.Atanh,
+		"Cbrt":            math.Cbrt,
+		"Ceil":            math.Ceil,
+		"Copysign":        math.Copysign,
+		"Cos":             math.Cos,
+		"Cosh":            math.Cosh,
+		"Dim":             math.Dim,
+		"Erf":             math.Erf,
+		"Erfc":            math.Erfc,
+		"Erfcinv":         math.Erfcinv,
+		"Erfinv":          math.Erfinv,
+		"Exp":             math.Exp,
+		"Exp2":            math.Exp2,
+		"Expm1":           math.Expm1,
+		"FMA":             math.FMA,
+		"Float32bits":     math.Float32bits,
+		"Float32frombits": math.Float32frombits,
+		"Float64bits":     math.Float64bits,
+		"Float64frombits": math.Float64frombits,
+		"Floor":           math.Floor,
+		"Frexp":           math.Frexp,
+		"Gamma":           math.Gamma,
+		"Hypot":           math.Hypot,
+		"Ilogb":           math.Ilogb,
+		"Inf":             math.Inf,
+		"IsInf":           math.IsInf,
+		"IsNaN":           math.IsNaN,
+		"J0":              math.J0,
+		"J1":              math.J1,
+		"Jn":              math.Jn,
+		"Ldexp":           math.Ldexp,
+		"Lgamma":          math.Lgamma,
+		"Log":             math.Log,
+		"Log10":           math.Log10,
+		"Log1p":           math.Log1p,
+		"Log2":            math.Log2,
+		"Logb":            math.Logb,
+		"Max":             math.Max,
+		"Min":             math.Min,
+		"Mod":             math.Mod,
+		"Modf":            math.Modf,
+		"NaN":             math.NaN,
+		"Nextafter":       math.Nextafter,
+		"Nextafter32":     math.Nextafter32,
+		"Pow":             math.Pow,
+		"Pow10":           math.Pow10,
+		"Remainder":       math.Remainder,
+		"Round":           math.Round,
+		"RoundToEven":     math.RoundToEven,
+		"Signbit":         math.Signbit,
+		"Sin":             math.Sin,
+		"Sincos":          math.Sincos,
+		"Sinh":            math.Sinh,
+		"Sqrt":            math.Sqrt,
+		"Tan":             math.Tan,
+		"Tanh":            math.Tanh,
+		"Trunc":           math.Trunc,
+		"Y0":              math.Y0,
+		"Y1":              math.Y1,
+		"Yn":              math.Yn,
+	})
+	self.RegisterVars("math", map[string]interface{}{
+		"E":                      float64(math.E),
+		"Ln10":                   float64(math.Ln10),
+		"Ln2":                    float64(math.Ln2),
+		"Log10E":                 float64(math.Log10E),
+		"Log2E":                  float64(math.Log2E),
+		"MaxFloat32":             float64(math.MaxFloat32),
+		"MaxFloat64":             float64(math.MaxFloat64),
+		"MaxInt":                 int64(math.MaxInt),
+		"MaxInt16":               int64(math.MaxInt16),
+		"MaxInt32":               int64(math.MaxInt32),
+		"MaxInt64":               int64(math.MaxInt64),
+		"MaxInt8":                int64(math.MaxInt8),
+		"MaxUint":                uint64(math.MaxUint),
+		"MaxUint16":              int64(math.MaxUint16),
+		"MaxUint32":              int64(math.MaxUint32),
+		"MaxUint64":              uint64(math.MaxUint64),
+		"MaxUint8":               int64(math.MaxUint8),
+		"MinInt":                 int64(math.MinInt),
+		"MinInt16":               int64(math.MinInt16),
+		"MinInt32":               int64(math.MinInt32),
+		"MinInt64":               int64(math.MinInt64),
+		"MinInt8":                int64(math.MinInt8),
+		"Phi":                    float64(math.Phi),
+		"Pi":                     float64(math.Pi),
+		"SmallestNonzeroFloat32": float64(math.SmallestNonzeroFloat32),
+		"SmallestNonzeroFloat64": float64(math.SmallestNonzeroFloat64),
+		"Sqrt2":                  float64(math.Sqrt2),
+		"SqrtE":                  float64(math.SqrtE),
+		"SqrtPhi":                float64(math.SqrtPhi),
+		"SqrtPi":                 float64(math.SqrtPi),
+	})
+
+	self.RegisterFuncMap("math/rand", map[string]interface{}{
+		"ExpFloat64":  rand.ExpFloat64,
+		"Float32":     rand.Float32,
+		"Float64":     rand.Float64,
+		"Int":         rand.Int,
+		"Int31":       rand.Int31,
+		"Int31n":      rand.Int31n,
+		"Int63":       rand.Int63,
+		"Int63n":      rand.Int63n,
+		"Intn":        rand.Intn,
+		"New":         rand.New,
+		"NewSource":   rand.NewSource,
+		"NewZipf":     rand.NewZipf,
+		"NormFloat64": rand.NormFloat64,
+		"Perm":        rand.Perm,
+		"Read":        rand.Read,
+		"Seed":        rand.Seed,
+		"Shuffle":     rand.Shuffle,
+		"Uint32":      rand.Uint32,
+		"Uint64":      rand.Uint64,
+	})
+	self.RegisterTypes("math/rand", []interface{}{
+		(*rand.Rand)(nil),
+		(*rand.Zipf)(nil),
+	})
+
+	self.RegisterFuncMap("net/http", map[string]interface{}{
+		"AllowQuerySemicolons":  http.AllowQuerySemicolons,
+		"CanonicalHeaderKey":    http.CanonicalHeaderKey,
+		"DetectContentType":     http.DetectContentType,
+		"Error":                 http.Error,
+		"FS":                    http.FS,
+		"FileServer":            http.FileServer,
+		"Get":                   http.Get,
+		"Handle":                http.Handle,
+		"HandleFunc":            http.HandleFunc,
+		"Head":                  http.Head,
+		"ListenAndServe":        http.ListenAndServe,
+		"ListenAndServeTLS":     http.ListenAndServeTLS,
+		"MaxBytesHandler":       http.MaxBytesHandler,
+		"MaxBytesReader":        http.MaxBytesReader,
+		"NewFileTransport":      http.NewFileTransport,
+		"NewRequest":            http.NewRequest,
+		"NewRequestWithContext": http.NewRequestWithContext,
+		"NewResponseController": http.NewResponseController,
+		"NewServeMux":           http.NewServeMux,
+		"NotFound":              http.NotFound,
+		"NotFoundHandler":       http.NotFoundHandler,
+		"ParseHTTPVersion":      http.ParseHTTPVersion,
+		"ParseTime":             http.ParseTime,
+		"Post":                  http.Post,
+		"PostForm":              http.PostForm,
+		"ProxyFromEnvironment":  http.ProxyFromEnvironment,
+		"ProxyURL":              http.ProxyURL,
+		"ReadRequest":           http.ReadRequest,
+		"ReadResponse":          http.ReadResponse,
+		"Redirect":              http.Redirect,
+		"RedirectHandler":       http.RedirectHandler,
+		"Serve":                 http.Serve,
+		"ServeContent":          http.ServeContent,
+		"ServeFile":             http.ServeFile,
+		"ServeTLS":              http.ServeTLS,
+		"SetCookie":             http.SetCookie,
+		"StatusText":            http.StatusText,
+		"StripPrefix":           http.StripPrefix,
+		"TimeoutHandler":        http.TimeoutHandler,
+	})
+	self.RegisterVars("net/http", map[string]interface{}{
+		"DefaultClient":                       http.DefaultClient,
+		"DefaultMaxHeaderBytes":               int64(http.DefaultMaxHeaderBytes),
+		"DefaultMaxIdleConnsPerHost":          int64(http.DefaultMaxIdleConnsPerHost),
+		"DefaultServeMux":                     http.DefaultServeMux,
+		"DefaultTransport":                    http.DefaultTransport,
+		"ErrAbortHandler":                     http.ErrAbortHandler,
+		"ErrBodyNotAllowed":                   http.ErrBodyNotAllowed,
+		"ErrBodyReadAfterClose":               http.ErrBodyReadAfterClose,
+		"ErrContentLength":                    http.ErrContentLength,
+		"ErrHandlerTimeout":                   http.ErrHandlerTimeout,
+		"ErrHeaderTooLong":                    http.ErrHeaderTooLong,
+		"ErrHijacked":                         http.ErrHijacked,
+		"ErrLineTooLong":                      http.ErrLineTooLong,
+		"ErrMissingBoundary":                  http.ErrMissingBoundary,
+		"ErrMissingContentLength":             http.ErrMissingContentLength,
+		"ErrMissingFile":                      http.ErrMissingFile,
+		"ErrNoCookie":                         http.ErrNoCookie,
+		"ErrNoLocation":                       http.ErrNoLocation,
+		"ErrNotMultipart":                     http.ErrNotMultipart,
+		"ErrNotSupported":                     http.ErrNotSupported,
+		"ErrSchemeMismatch":                   http.ErrSchemeMismatch,
+		"ErrServerClosed":                     http.ErrServerClosed,
+		"ErrShortBody":                        http.ErrShortBody,
+		"ErrSkipAltProtocol":                  http.ErrSkipAltProtocol,
+		"ErrUnexpectedTrailer":                http.ErrUnexpectedTrailer,
+		"ErrUseLastResponse":                  http.ErrUseLastResponse,
+		"ErrWriteAfterFlush":                  http.ErrWriteAfterFlush,
+		"LocalAddrContextKey":                 http.LocalAddrContextKey,
+		"MethodConnect":                       http.MethodConnect,
+		"MethodDelete":                        http.MethodDelete,
+		"MethodGet":                           http.MethodGet,
+		"MethodHead":                          http.MethodHead,
+		"MethodOptions":                       http.MethodOptions,
+		"MethodPatch":                         http.MethodPatch,
+		"MethodPost":                          http.MethodPost,
+		"MethodPut":                           http.MethodPut,
+		"MethodTrace":                         http.MethodTrace,
+		"NoBody":                              http.NoBody,
+		"SameSiteDefaultMode":                 http.SameSiteDefaultMode,
+		"SameSiteLaxMode":                     http.SameSiteLaxMode,
+		"SameSiteNoneMode":                    http.SameSiteNoneMode,
+		"SameSiteStrictMode":                  http.SameSiteStrictMode,
+		"ServerContextKey":                    http.ServerContextKey,
+		"StateActive":                         http.StateActive,
+		"StateClosed":                         http.StateClosed,
+		"StateHijacked":                       http.StateHijacked,
+		"StateIdle":                           http.StateIdle,
+		"StateNew":                            http.StateNew,
+		"StatusAccepted":                      int64(http.StatusAccepted),
+		"StatusAlreadyReported":               int64(http.StatusAlreadyReported),
+		"StatusBadGateway":                    int64(http.StatusBadGateway),
+		"StatusBadRequest":                    int64(http.StatusBadRequest),
+		"StatusConflict":                      int64(http.StatusConflict),
+		"StatusContinue":                      int64(http.StatusContinue),
+		"StatusCreated":                       int64(http.StatusCreated),
+		"StatusEarlyHints":                    int64(http.StatusEarlyHints),
+		"StatusExpectationFailed":             int64(http.StatusExpectationFailed),
+		"StatusFailedDependency":              int64(http.StatusFailedDependency),
+		"StatusForbidden":                     int64(http.StatusForbidden),
+		"StatusFound":                         int64(http.StatusFound),
+		"StatusGatewayTimeout":                int64(http.StatusGatewayTimeout),
+		"StatusGone":                          int64(http.StatusGone),
+		"StatusHTTPVersionNotSupported":       int64(http.StatusHTTPVersionNotSupported),
+		"StatusIMUsed":                        int64(http.StatusIMUsed),
+		"StatusInsufficientStorage":           int64(http.StatusInsufficientStorage),
+		"StatusInternalServerError":           int64(http.StatusInternalServerError),
+		"StatusLengthRequired":                int64(http.StatusLengthRequired),
+		"StatusLocked":                        int64(http.StatusLocked),
+		"StatusLoopDetected":                  int64(http.StatusLoopDetected),
+		"StatusMethodNotAllowed":              int64(http.StatusMethodNotAllowed),
+		"StatusMisdirectedRequest":            int64(http.StatusMisdirectedRequest),
+		"StatusMovedPermanently":              int64(http.StatusMovedPermanently),
+		"StatusMultiStatus":                   int64(http.StatusMultiStatus),
+		"StatusMultipleChoices":               int64(http.StatusMultipleChoices),
+		"StatusNetworkAuthenticationRequired": int64(http.StatusNetworkAuthenticationRequired),
+		"StatusNoContent":                     int64(http.StatusNoContent),
+		"StatusNonAuthoritativeInfo":          int64(http.StatusNonAuthoritativeInfo),
+		"StatusNotAcceptable":                 int64(http.StatusNotAcceptable),
+		"StatusNotExtended":                   int64(http.StatusNotExtended),
+		"StatusNotFound":                      int64(http.StatusNotFound),
+		"StatusNotImplemented":                int64(http.StatusNotImplemented),
+		"StatusNotModified":                   int64(http.StatusNotModified),
+		"StatusOK":                            int64(http.StatusOK),
+		"StatusPartialContent":                int64(http.StatusPartialContent),
+		"StatusPaymentRequired":               int64(http.StatusPaymentRequired),
+		"StatusPermanentRedirect":             int64(http.StatusPermanentRedirect),
+		"StatusPreconditionFailed":            int64(http.StatusPreconditionFailed),
+		"StatusPreconditionRequired":          int64(http.StatusPreconditionRequired),
+		"StatusProcessing":                    int64(http.StatusProcessing),
+		"StatusProxyAuthRequired":             int64(http.StatusProxyAuthRequired),
+		"StatusRequestEntityTooLarge":         int64(http.StatusRequestEntityTooLarge),
+		"StatusRequestHeaderFieldsTooLarge":   int64(http.StatusRequestHeaderFieldsTooLarge),
+		"StatusRequestTimeout":                int64(http.StatusRequestTimeout),
+		"StatusRequestURITooLong":             int64(http.StatusRequestURITooLong),
+		"StatusRequestedRangeNotSatisfiable":  int64(http.StatusRequestedRangeNotSatisfiable),
+		"StatusResetContent":                  int64(http.StatusResetContent),
+		"StatusSeeOther":                      int64(http.StatusSeeOther),
+		"StatusServiceUnavailable":            int64(http.StatusServiceUnavailable),
+		"StatusSwitchingProtocols":            int64(http.StatusSwitchingProtocols),
+		"StatusTeapot":                        int64(http.StatusTeapot),
+		"StatusTemporaryRedirect":             int64(http.StatusTemporaryRedirect),
+		"StatusTooEarly":                      int64(http.StatusTooEarly),
+		"StatusTooManyRequests":               int64(http.StatusTooManyRequests),
+		"StatusUnauthorized":                  int64(http.StatusUnauthorized),
+		"StatusUnavailableForLegalReasons":    int64(http.StatusUnavailableForLegalReasons),
+		"StatusUnprocessableEntity":           int64(http.StatusUnprocessableEntity),
+		"StatusUnsupportedMediaType":          int64(http.StatusUnsupportedMediaType),
+		"StatusUpgradeRequired":               int64(http.StatusUpgradeRequired),
+		"StatusUseProxy":                      int64(http.StatusUseProxy),
+		"StatusVariantAlsoNegotiates":         int64(http.StatusVariantAlsoNegotiates),
+		"TimeFormat":                          http.TimeFormat,
+		"TrailerPrefix":                       http.TrailerPrefix,
+	})
+	self.RegisterTypes("net/http", []interface{}{
+		(*http.Client)(nil),
+		(*http.ConnState)(nil),
+		(*http.Cookie)(nil),
+		(*http.Dir)(nil),
+		(*http.HandlerFunc)(nil),
+		(*http.Header)(nil),
+		(*http.MaxBytesError)(nil),
+		(*http.ProtocolError)(nil),
+		(*http.PushOptions)(nil),
+		(*http.Request)(nil),
+		(*http.Response)(nil),
+		(*http.ResponseController)(nil),
+		(*http.SameSite)(nil),
+		(*http.ServeMux)(nil),
+		(*http.Server)(nil),
+		(*http.Transport)(nil),
+	})
+
+	self.RegisterFuncMap("net/http/httptest", map[string]interface{}{
+		"NewRecorder":        httptest.NewRecorder,
+		"NewRequest":         httptest.NewRequest,
+		"NewServer":          httptest.NewServer,
+		"NewTLSServer":       httptest.NewTLSServer,
+		"NewUnstartedServer": httptest.NewUnstartedServer,
+	})
+	self.RegisterVars("net/http/httptest", map[string]interface{}{
+		"DefaultRemoteAddr": httptest.DefaultRemoteAddr,
+	})
+	self.RegisterTypes("net/http/httptest", []interface{}{
+		(*httptest.ResponseRecorder)(nil),
+		(*httptest.Server)(nil),
+	})
+
+	self.RegisterFuncMap("os", map[string]interface{}{
+		"Chdir":           os.Chdir,
+		"Chmod":           os.Chmod,
+		"Chown":           os.Chown,
+		"Chtimes":         os.Chtimes,
+		"Clearenv":        os.Clearenv,
+		"Create":          os.Create,
+		"CreateTemp":      os.CreateTemp,
+		"DirFS":           os.DirFS,
+		"Environ":         os.Environ,
+		"Executable":      os.Executable,
+		"Exit":            os.Exit,
+		"Expand":          os.Expand,
+		"ExpandEnv":       os.ExpandEnv,
+		"FindProcess":     os.FindProcess,
+		"Getegid":         os.Getegid,
+		"Getenv":          os.Getenv,
+		"Geteuid":         os.Geteuid,
+		"Getgid":          os.Getgid,
+		"Getgroups":       os.Getgroups,
+		"Getpagesize":     os.Getpagesize,
+		"Getpid":          os.Getpid,
+		"Getppid":         os.Getppid,
+		"Getuid":          os.Getuid,
+		"Getwd":           os.Getwd,
+		"Hostname":        os.Hostname,
+		"IsExist":         os.IsExist,
+		"IsNotExist":      os.IsNotExist,
+		"IsPathSeparator": os.IsPathSeparator,
+		"IsPermission":    os.IsPermission,
+		"IsTimeout":       os.IsTimeout,
+		"Lchown":          os.Lchown,
+		"Link":            os.Link,
+		"LookupEnv":       os.LookupEnv,
+		"Lstat":           os.Lstat,
+		"Mkdir":           os.Mkdir,
+		"MkdirAll":        os.MkdirAll,
+		"MkdirTemp":       os.MkdirTemp,
+		"NewFile":         os.NewFile,
+		"NewSyscallError": os.NewSyscallError,
+		"Open":            os.Open,
+		"OpenFile":        os.OpenFile,
+		"Pipe":            os.Pipe,
+		"ReadDir":         os.ReadDir,
+		"ReadFile":        os.ReadFile,
+		"Readlink":        os.Readlink,
+		"Remove":          os.Remove,
+		"RemoveAll":       os.RemoveAll,
+		"Rename":          os.Rename,
+		"SameFile":        os.SameFile,
+		"Setenv":          os.Setenv,
+		"StartProcess":    os.StartProcess,
+		"Stat":            os.Stat,
+		"Symlink":         os.Symlink,
+		"TempDir":         os.TempDir,
+		"Truncate":        os.Truncate,
+		"Unsetenv":        os.Unsetenv,
+		"UserCacheDir":    os.UserCacheDir,
+		"UserConfigDir":   os.UserConfigDir,
+		"UserHomeDir":     os.UserHomeDir,
+		"WriteFile":       os.WriteFile,
+	})
+	self.RegisterVars("os", map[string]interface{}{
+		"Args":                os.Args,
+		"DevNull":             os.DevNull,
+		"ErrClosed":           os.ErrClosed,
+		"ErrDeadlineExceeded": os.ErrDeadlineExceeded,
+		"ErrExist":            os.ErrExist,
+		"ErrInvalid":          os.ErrInvalid,
+		"ErrNoDeadline":       os.ErrNoDeadline,
+		"ErrNotExist":         os.ErrNotExist,
+		"ErrPermission":       os.ErrPermission,
+		"ErrProcessDone":      os.ErrProcessDone,
+		"Interrupt":           os.Interrupt,
+		"Kill":                os.Kill,
+		"ModeAppend":          os.ModeAppend,
+		"ModeCharDevice":      os.ModeCharDevice,
+		"ModeDevice":          os.ModeDevice,
+		"ModeDir":             os.ModeDir,
+		"ModeExclusive":       os.ModeExclusive,
+		"ModeIrregular":       os.ModeIrregular,
+		"ModeNamedPipe":       os.ModeNamedPipe,
+		"ModePerm":            os.ModePerm,
+		"ModeSetgid":          os.ModeSetgid,
+		"ModeSetuid":          os.ModeSetuid,
+		"ModeSocket":          os.ModeSocket,
+		"ModeSticky":          os.ModeSticky,
+		"ModeSymlink":         os.ModeSymlink,
+		"ModeTemporary":       os.ModeTemporary,
+		"ModeType":            os.ModeType,
+		"O_APPEND":            os.O_APPEND,
+		"O_CREATE":            os.O_CREATE,
+		"O_EXCL":              os.O_EXCL,
+		"O_RDONLY":            os.O_RDONLY,
+		"O_RDWR":              os.O_RDWR,
+		"O_SYNC":              os.O_SYNC,
+		"O_TRUNC":             os.O_TRUNC,
+		"O_WRONLY":            os.O_WRONLY,
+		"PathListSeparator":   rune(os.PathListSeparator),
+		"PathSeparator":       rune(os.PathSeparator),
+		"SEEK_CUR":            os.SEEK_CUR,
+		"SEEK_END":            os.SEEK_END,
+		"SEEK_SET":            os.SEEK_SET,
+		"Stderr":              os.Stderr,
+		"Stdin":               os.Stdin,
+		"Stdout":              os.Stdout,
+	})
+	self.RegisterTypes("os", []interface{}{
+		(*os.File)(nil),
+		(*os.LinkError)(nil),
+		(*os.ProcAttr)(nil),
+		(*os.Process)(nil),
+		(*os.ProcessState)(nil),
+		(*os.SyscallError)(nil),
+	})
+
+	self.RegisterFuncMap("path", map[string]interface{}{
+		"Base":  path.Base,
+		"Clean": path.Clean,
+		"Dir":   path.Dir,
+		"Ext":   path.Ext,
+		"IsAbs": path.IsAbs,
+		"Join":  path.Join,
+		"Match": path.Match,
+		"Split": path.Split,
+	})
+	self.RegisterVars("path", map[string]interface{}{
+		"ErrBadPattern": path.ErrBadPattern,
+	})
+
+	self.RegisterFuncMap("path/filepath", map[string]interface{}{
+		"Abs":          filepath.Abs,
+		"Base":         filepath.Base,
+		"Clean":        filepath.Clean,
+		"Dir":          filepath.Dir,
+		"EvalSymlinks": filepath.EvalSymlinks,
+		"Ext":          filepath.Ext,
+		"FromSlash":    filepath.FromSlash,
+		"Glob":         filepath.Glob,
+		"HasPrefix":    filepath.HasPrefix,
+		"IsAbs":        filepath.IsAbs,
+		"IsLocal":      filepath.IsLocal,
+		"Join":         filepath.Join,
+		"Match":        filepath.Match,
+		"Rel":          filepath.Rel,
+		"Split":        filepath.Split,
+		"SplitList":    filepath.SplitList,
+		"ToSlash":      filepath.ToSlash,
+		"VolumeName":   filepath.VolumeName,
+		"Walk":         filepath.Walk,
+		"WalkDir":      filepath.WalkDir,
+	})
+	self.RegisterVars("path/filepath", map[string]interface{}{
+		"ErrBadPattern": filepath.ErrBadPattern,
+		"ListSeparator": rune(filepath.ListSeparator),
+		"Separator":     rune(filepath.Separator),
+		"SkipAll":       filepath.SkipAll,
+		"SkipDir":       filepath.SkipDir,
+	})
+	self.RegisterTypes("path/filepath", []interface{}{
+		(*filepath.WalkFunc)(nil),
+	})
+
+	self.RegisterFuncMap("sort", map[string]interface{}{
+		"Find":              sort.Find,
+		"Float64s":          sort.Float64s,
+		"Float64sAreSorted": sort.Float64sAreSorted,
+		"Ints":              sort.Ints,
+		"IntsAreSorted":     sort.IntsAreSorted,
+		"IsSorted":          sort.IsSorted,
+		"Reverse":           sort.Reverse,
+		"Search":            sort.Search,
+		"SearchFloat64s":    sort.SearchFloat64s,
+		"SearchInts":        sort.SearchInts,
+		"SearchStrings":     sort.SearchStrings,
+		"Slice":             sort.Slice,
+		"SliceIsSorted":     sort.SliceIsSorted,
+		"SliceStable":       sort.SliceStable,
+		"Sort":              sort.Sort,
+		"Stable":            sort.Stable,
+		"Strings":           sort.Strings,
+		"StringsAreSorted":  sort.StringsAreSorted,
+	})
+	self.RegisterTypes("sort", []interface{}{
+		(*sort.Float64Slice)(nil),
+		(*sort.IntSlice)(nil),
+		(*sort.StringSlice)(nil),
+	})
+
+	self.RegisterFuncMap("strconv", map[string]interface{}{
+		"AppendBool":               strconv.AppendBool,
+		"AppendFloat":              strconv.AppendFloat,
+		"AppendInt":                strconv.AppendInt,
+		"AppendQuote":              strconv.AppendQuote,
+		"AppendQuoteRune":          strconv.AppendQuoteRune,
+		"AppendQuoteRuneToASCII":   strconv.AppendQuoteRuneToASCII,
+		"AppendQuoteRuneToGraphic": strconv.AppendQuoteRuneToGraphic,
+		"AppendQuoteToASCII":       strconv.AppendQuoteToASCII,
+		"AppendQuoteToGraphic":     strconv.AppendQuoteToGraphic,
+		"AppendUint":               strconv.AppendUint,
+		"Atoi":                     strconv.Atoi,
+		"CanBackquote":             strconv.CanBackquote,
+		"FormatBool":               strconv.FormatBool,
+		"FormatComplex":            strconv.FormatComplex,
+		"FormatFloat":              strconv.FormatFloat,
+		"FormatInt":                strconv.FormatInt,
+		"FormatUint":               strconv.FormatUint,
+		"IsGraphic":                strconv.IsGraphic,
+		"IsPrint":                  strconv.IsPrint,
+		"Itoa":                     strconv.Itoa,
+		"ParseBool":                strconv.ParseBool,
+		"ParseComplex":             strconv.ParseComplex,
+		"ParseFloat":               strconv.ParseFloat,
+		"ParseInt":                 strconv.ParseInt,
+		"ParseUint":                strconv.ParseUint,
+		"Quote":                    strconv.Quote,
+		"QuoteRune":                strconv.QuoteRune,
+		"QuoteRuneToASCII":         strconv.QuoteRuneToASCII,
+		"QuoteRuneToGraphic":       strconv.QuoteRuneToGraphic,
+		"QuoteToASCII":             strconv.QuoteToASCII,
+		"QuoteToGraphic":           strconv.QuoteToGraphic,
+		"QuotedPrefix":             strconv.QuotedPrefix,
+		"Unquote":                  strconv.Unquote,
+		"UnquoteChar":              strconv.UnquoteChar,
+	})
+	self.RegisterVars("strconv", map[string]interface{}{
+		"ErrRange":  strconv.ErrRange,
+		"ErrSyntax": strconv.ErrSyntax,
+		"IntSize":   int64(strconv.IntSize),
+	})
+	self.RegisterTypes("strconv", []interface{}{
+		(*strconv.NumError)(nil),
+	})
+
+	self.RegisterFuncMap("strings", map[string]interface{}{
+		"Clone":          strings.Clone,
+		"Compare":        strings.Compare,
+		"Contains":       strings.Contains,
+		"ContainsAny":    strings.ContainsAny,
+		"ContainsFunc":   strings.ContainsFunc,
+		"ContainsRune":   strings.ContainsRune,
+		"Count":          strings.Count,
+		"Cut":            strings.Cut,
+		"CutPrefix":      strings.CutPrefix,
+		"CutSuffix":      strings.CutSuffix,
+		"EqualFold":      strings.EqualFold,
+		"Fields":         strings.Fields,
+		"FieldsFunc":     strings.FieldsFunc,
+		"HasPrefix":      strings.HasPrefix,
+		"HasSuffix":      strings.HasSuffix,
+		"Index":          strings.Index,
+		"IndexAny":       strings.IndexAny,
+		"IndexByte":      strings.IndexByte,
+		"IndexFunc":      strings.IndexFunc,
+		"IndexRune":      strings.IndexRune,
+		"Join":           strings.Join,
+		"LastIndex":      strings.LastIndex,
+		"LastIndexAny":   strings.LastIndexAny,
+		"LastIndexByte":  strings.LastIndexByte,
+		"LastIndexFunc":  strings.LastIndexFunc,
+		"Map":            strings.Map,
+		"NewReader":      strings.NewReader,
+		"NewReplacer":    strings.NewReplacer,
+		"Repeat":         strings.Repeat,
+		"Replace":        strings.Replace,
+		"ReplaceAll":     strings.ReplaceAll,
+		"Split":          strings.Split,
+		"SplitAfter":     strings.SplitAfter,
+		"SplitAfterN":    strings.SplitAfterN,
+		"SplitN":         strings.SplitN,
+		"Title":          strings.Title,
+		"ToLower":        strings.ToLower,
+		"ToLowerSpecial": strings.ToLowerSpecial,
+		"ToTitle":        strings.ToTitle,
+		"ToTitleSpecial": strings.ToTitleSpecial,
+		"ToUpper":        strings.ToUpper,
+		"ToUpperSpecial": strings.ToUpperSpecial,
+		"ToValidUTF8":    strings.ToValidUTF8,
+		"Trim":           strings.Trim,
+		"TrimFunc":       strings.TrimFunc,
+		"TrimLeft":       strings.TrimLeft,
+		"TrimLeftFunc":   strings.TrimLeftFunc,
+		"TrimPrefix":     strings.TrimPrefix,
+		"TrimRight":      strings.TrimRight,
+		"TrimRightFunc":  strings.TrimRightFunc,
+		"TrimSpace":      strings.TrimSpace,
+		"TrimSuffix":     strings.TrimSuffix,
+	})
+	self.RegisterTypes("strings", []interface{}{
+		(*strings.Builder)(nil),
+		(*strings.Reader)(nil),
+		(*strings.Replacer)(nil),
+	})
+
+	self.RegisterFuncMap("time", map[string]interface{}{
+		"After":                  time.After,
+		"AfterFunc":              time.AfterFunc,
+		"Date":                   time.Date,
+		"FixedZone":              time.FixedZone,
+		"LoadLocation":           time.LoadLocation,
+		"LoadLocationFromTZData": time.LoadLocationFromTZData,
+		"NewTicker":              time.NewTicker,
+		"NewTimer":               time.NewTimer,
+		"Now":                    time.Now,
+		"Parse":                  time.Parse,
+		"ParseDuration":          time.ParseDuration,
+		"ParseInLocation":        time.ParseInLocation,
+		"Since":                  time.Since,
+		"Sleep":                  time.Sleep,
+		"Tick":                   time.Tick,
+		"Unix":                   time.Unix,
+		"UnixMicro":              time.UnixMicro,
+		"UnixMilli":              time.UnixMilli,
+		"Until":                  time.Until,
+	})
+	self.RegisterVars("time", map[string]interface{}{
+		"ANSIC":       time.ANSIC,
+		"April":       time.April,
+		"August":      time.August,
+		"DateOnly":    time.DateOnly,
+		"DateTime":    time.DateTime,
+		"December":    time.December,
+		"February":    time.February,
+		"Friday":      time.Friday,
+		"Hour":        time.Hour,
+		"January":     time.January,
+		"July":        time.July,
+		"June":        time.June,
+		"Kitchen":     time.Kitchen,
+		"Layout":      time.Layout,
+		"Local":       time.Local,
+		"March":       time.March,
+		"May":         time.May,
+		"Microsecond": time.Microsecond,
+		"Millisecond": time.Millisecond,
+		"Minute":      time.Minute,
+		"Monday":      time.Monday,
+		"Nanosecond":  time.Nanosecond,
+		"November":    time.November,
+		"October":     time.October,
+		"RFC1123":     time.RFC1123,
+		"RFC1123Z":    time.RFC1123Z,
+		"RFC3339":     time.RFC3339,
+		"RFC3339Nano": time.RFC3339Nano,
+		"RFC822":      time.RFC822,
+		"RFC822Z":     time.RFC822Z,
+		"RFC850":      time.RFC850,
+		"RubyDate":    time.RubyDate,
+		"Saturday":    time.Saturday,
+		"Second":      time.Second,
+		"September":   time.September,
+		"Stamp":       time.Stamp,
+		"StampMicro":  time.StampMicro,
+		"StampMilli":  time.StampMilli,
+		"StampNano":   time.StampNano,
+		"Sunday":      time.Sunday,
+		"Thursday":    time.Thursday,
+		"TimeOnly":    time.TimeOnly,
+		"Tuesday":     time.Tuesday,
+		"UTC":         time.UTC,
+		"UnixDate":    time.UnixDate,
+		"Wednesday":   time.Wednesday,
+	})
+	self.RegisterTypes("time", []interface{}{
+		(*time.Duration)(nil),
+		(*time.Location)(nil),
+		(*time.Month)(nil),
+		(*time.ParseError)(nil),
+		(*time.Ticker)(nil),
+		(*time.Time)(nil),
+		(*time.Timer)(nil),
+		(*time.Weekday)(nil),
+	})
+
+	self.RegisterFuncMap("unicode", map[string]interface{}{
+		"In":         unicode.In,
+		"Is":         unicode.Is,
+		"IsControl":  unicode.IsControl,
+		"IsDigit":    unicode.IsDigit,
+		"IsGraphic":  unicode.IsGraphic,
+		"IsLetter":   unicode.IsLetter,
+		"IsLower":    unicode.IsLower,
+		"IsMark":     unicode.IsMark,
+		"IsNumber":   unicode.IsNumber,
+		"IsOneOf":    unicode.IsOneOf,
+		"IsPrint":    unicode.IsPrint,
+		"IsPunct":    unicode.IsPunct,
+		"IsSpace":    unicode.IsSpace,
+		"IsSymbol":   unicode.IsSymbol,
+		"IsTitle":    unicode.IsTitle,
+		"IsUpper":    unicode.IsUpper,
+		"SimpleFold": unicode.SimpleFold,
+		"To":         unicode.To,
+		"ToLower":    unicode.ToLower,
+		"ToTitle":    unicode.ToTitle,
+		"ToUpper":    unicode.ToUpper,
+	})
+	self.RegisterVars("unicode", map[string]interface{}{
+		"ASCII_Hex_Digit":                    unicode.ASCII_Hex_Digit,
+		"Adlam":                              unicode.Adlam,
+		"Ahom":                               unicode.Ahom,
+		"Anatolian_Hieroglyphs":              unicode.Anatolian_Hieroglyphs,
+		"Arabic":                             unicode.Arabic,
+		"Armenian":                           unicode.Armenian,
+		"Avestan":                            unicode.Avestan,
+		"AzeriCase":                          unicode.AzeriCase,
+		"Balinese":                           unicode.Balinese,
+		"Bamum":                              unicode.Bamum,
+		"Bassa_Vah":                          unicode.Bassa_Vah,
+		"Batak":                              unicode.Batak,
+		"Bengali":                            unicode.Bengali,
+		"Bhaiksuki":                          unicode.Bhaiksuki,
+		"Bidi_Control":                       unicode.Bidi_Control,
+		"Bopomofo":                           unicode.Bopomofo,
+		"Brahmi":                             unicode.Brahmi,
+		"Braille":                            unicode.Braille,
+		"Buginese":                           unicode.Buginese,
+		"Buhid":                              unicode.Buhid,
+		"C":                                  unicode.C,
+		"Canadian_Aboriginal":                unicode.Canadian_Aboriginal,
+		"Carian":                             unicode.Carian,
+		"CaseRanges":                         unicode.CaseRanges,
+		"Categories":                         unicode.Categories,
+		"Caucasian_Albanian":                 unicode.Caucasian_Albanian,
+		"Cc":                                 unicode.Cc,
+		"Cf":                                 unicode.Cf,
+		"Chakma":                             unicode.Chakma,
+		"Cham":                               unicode.Cham,
+		"Cherokee":                           unicode.Cherokee,
+		"Chorasmian":                         unicode.Chorasmian,
+		"Co":                                 unicode.Co,
+		"Common":                             unicode.Common,
+		"Coptic":                             unicode.Coptic,
+		"Cs":                                 unicode.Cs,
+		"Cuneiform":                          unicode.Cuneiform,
+		"Cypriot":                            unicode.Cypriot,
+		"Cypro_Minoan":                       unicode.Cypro_Minoan,
+		"Cyrillic":                           unicode.Cyrillic,
+		"Dash":                               unicode.Dash,
+		"Deprecated":                         unicode.Deprecated,
+		"Deseret":                            unicode.Deseret,
+		"Devanagari":                         unicode.Devanagari,
+		"Diacritic":                          unicode.Diacritic,
+		"Digit":                              unicode.Digit,
+		"Dives_Akuru":                        unicode.Dives_Akuru,
+		"Dogra":                              unicode.Dogra,
+		"Duployan":                           unicode.Duployan,
+		"Egyptian_Hieroglyphs":               unicode.Egyptian_Hieroglyphs,
+		"Elbasan":                            unicode.Elbasan,
+		"Elymaic":                            unicode.Elymaic,
+		"Ethiopic":                           unicode.Ethiopic,
+		"Extender":                           unicode.Extender,
+		"FoldCategory":                       unicode.FoldCategory,
+		"FoldScript":                         unicode.FoldScript,
+		"Georgian":                           unicode.Georgian,
+		"Glagolitic":                         unicode.Glagolitic,
+		"Gothic":                             unicode.Gothic,
+		"Grantha":                            unicode.Grantha,
+		"GraphicRanges":                      unicode.GraphicRanges,
+		"Greek":                              unicode.Greek,
+		"Gujarati":                           unicode.Gujarati,
+		"Gunjala_Gondi":                      unicode.Gunjala_Gondi,
+		"Gurmukhi":                           unicode.Gurmukhi,
+		"Han":                                unicode.Han,
+		"Hangul":                             unicode.Hangul,
+		"Hanifi_Rohingya":                    unicode.Hanifi_Rohingya,
+		"Hanunoo":                            unicode.Hanunoo,
+		"Hatran":                             unicode.Hatran,
+		"Hebrew":                             unicode.Hebrew,
+		"Hex_Digit":                          unicode.Hex_Digit,
+		"Hiragana":                           unicode.Hiragana,
+		"Hyphen":                             unicode.Hyphen,
+		"IDS_Binary_Operator":                unicode.IDS_Binary_Operator,
+		"IDS_Trinary_Operator":               unicode.IDS_Trinary_Operator,
+		"Ideographic":                        unicode.Ideographic,
+		"Imperial_Aramaic":                   unicode.Imperial_Aramaic,
+		"Inherited":                          unicode.Inherited,
+		"Inscriptional_Pahlavi":              unicode.Inscriptional_Pahlavi,
+		"Inscriptional_Parthian":             unicode.Inscriptional_Parthian,
+		"Javanese":                           unicode.Javanese,
+		"Join_Control":                       unicode.Join_Control,
+		"Kaithi":                             unicode.Kaithi,
+		"Kannada":                            unicode.Kannada,
+		"Katakana":                           unicode.Katakana,
+		"Kawi":                               unicode.Kawi,
+		"Kayah_Li":                           unicode.Kayah_Li,
+		"Kharoshthi":                         unicode.Kharoshthi,
+		"Khitan_Small_Script":                unicode.Khitan_Small_Script,
+		"Khmer":                              unicode.Khmer,
+		"Khojki":                             unicode.Khojki,
+		"Khudawadi":                          unicode.Khudawadi,
+		"L":                                  unicode.L,
+		"Lao":                                unicode.Lao,
+		"Latin":                              unicode.Latin,
+		"Lepcha":                             unicode.Lepcha,
+		"Letter":                             unicode.Letter,
+		"Limbu":                              unicode.Limbu,
+		"Linear_A":                           unicode.Linear_A,
+		"Linear_B":                           unicode.Linear_B,
+		"Lisu":                               unicode.Lisu,
+		"Ll":                                 unicode.Ll,
+		"Lm":                                 unicode.Lm,
+		"Lo":                                 unicode.Lo,
+		"Logical_Order_Exception":            unicode.Logical_Order_Exception,
+		"Lower":                              unicode.Lower,
+		"LowerCase":                          int64(unicode.LowerCase),
+		"Lt":                                 unicode.Lt,
+		"Lu":                                 unicode.Lu,
+		"Lycian":                             unicode.Lycian,
+		"Lydian":                             unicode.Lydian,
+		"M":                                  unicode.M,
+		"Mahajani":                           unicode.Mahajani,
+		"Makasar":                            unicode.Makasar,
+		"Malayalam":                          unicode.Malayalam,
+		"Mandaic":                            unicode.Mandaic,
+		"Manichaean":                         unicode.Manichaean,
+		"Marchen":                            unicode.Marchen,
+		"Mark":                               unicode.Mark,
+		"Masaram_Gondi":                      unicode.Masaram_Gondi,
+		"MaxASCII":                           rune(unicode.MaxASCII),
+		"MaxCase":                            int64(unicode.MaxCase),
+		"MaxLatin1":                          rune(unicode.MaxLatin1),
+		"MaxRune":                            rune(unicode.MaxRune),
+		"Mc":                                 unicode.Mc,
+		"Me":                                 unicode.Me,
+		"Medefaidrin":                        unicode.Medefaidrin,
+		"Meetei_Mayek":                       unicode.Meetei_Mayek,
+		"Mende_Kikakui":                      unicode.Mende_Kikakui,
+		"Meroitic_Cursive":                   unicode.Meroitic_Cursive,
+		"Meroitic_Hieroglyphs":               unicode.Meroitic_Hieroglyphs,
+		"Miao":                               unicode.Miao,
+		"Mn":                                 unicode.Mn,
+		"Modi":                               unicode.Modi,
+		"Mongolian":                          unicode.Mongolian,
+		"Mro":                                unicode.Mro,
+		"Multani":                            unicode.Multani,
+		"Myanmar":                            unicode.Myanmar,
+		"N":                                  unicode.N,
+		"Nabataean":                          unicode.Nabataean,
+		"Nag_Mundari":                        unicode.Nag_Mundari,
+		"Nandinagari":                        unicode.Nandinagari,
+		"Nd":                                 unicode.Nd,
+		"New_Tai_Lue":                        unicode.New_Tai_Lue,
+		"Newa":                               unicode.Newa,
+		"Nko":                                unicode.Nko,
+		"Nl":                                 unicode.Nl,
+		"No":                                 unicode.No,
+		"Noncharacter_Code_Point":            unicode.Noncharacter_Code_Point,
+		"Number":                             unicode.Number,
+		"Nushu":                              unicode.Nushu,
+		"Nyiakeng_Puachue_Hmong":             unicode.Nyiakeng_Puachue_Hmong,
+		"Ogham":                              unicode.Ogham,
+		"Ol_Chiki":                           unicode.Ol_Chiki,
+		"Old_Hungarian":                      unicode.Old_Hungarian,
+		"Old_Italic":                         unicode.Old_Italic,
+		"Old_North_Arabian":                  unicode.Old_North_Arabian,
+		"Old_Permic":                         unicode.Old_Permic,
+		"Old_Persian":                        unicode.Old_Persian,
+		"Old_Sogdian":                        unicode.Old_Sogdian,
+		"Old_South_Arabian":                  unicode.Old_South_Arabian,
+		"Old_Turkic":                         unicode.Old_Turkic,
+		"Old_Uyghur":                         unicode.Old_Uyghur,
+		"Oriya":                              unicode.Oriya,
+		"Osage":                              unicode.Osage,
+		"Osmanya":                            unicode.Osmanya,
+		"Other":                              unicode.Other,
+		"Other_Alphabetic":                   unicode.Other_Alphabetic,
+		"Other_Default_Ignorable_Code_Point": unicode.Other_Default_Ignorable_Code_Point,
+		"Other_Grapheme_Extend":              unicode.Other_Grapheme_Extend,
+		"Other_ID_Continue":                  unicode.Other_ID_Continue,
+		"Other_ID_Start":                     unicode.Other_ID_Start,
+		"Other_Lowercase":                    unicode.Other_Lowercase,
+		"Other_Math":                         unicode.Other_Math,
+		"Other_Uppercase":                    unicode.Other_Uppercase,
+		"P":                                  unicode.P,
+		"Pahawh_Hmong":                       unicode.Pahawh_Hmong,
+		"Palmyrene":                          unicode.Palmyrene,
+		"Pattern_Syntax":                     unicode.Pattern_Syntax,
+		"Pattern_White_Space":                unicode.Pattern_White_Space,
+		"Pau_Cin_Hau":                        unicode.Pau_Cin_Hau,
+		"Pc":                                 unicode.Pc,
+		"Pd":                                 unicode.Pd,
+		"Pe":                                 unicode.Pe,
+		"Pf":                                 unicode.Pf,
+		"Phags_Pa":                           unicode.Phags_Pa,
+		"Phoenician":                         unicode.Phoenician,
+		"Pi":                                 unicode.Pi,
+		"Po":                                 unicode.Po,
+		"Prepended_Concatenation_Mark":       unicode.Prepended_Concatenation_Mark,
+		"PrintRanges":                        unicode.PrintRanges,
+		"Properties":                         unicode.Properties,
+		"Ps":                                 unicode.Ps,
+		"Psalter_Pahlavi":                    unicode.Psalter_Pahlavi,
+		"Punct":                              unicode.Punct,
+		"Quotation_Mark":                     unicode.Quotation_Mark,
+		"Radical":                            unicode.Radical,
+		"Regional_Indicator":                 unicode.Regional_Indicator,
+		"Rejang":                             unicode.Rejang,
+		"ReplacementChar":                    rune(unicode.ReplacementChar),
+		"Runic":                              unicode.Runic,
+		"S":                                  unicode.S,
+		"STerm":                              unicode.STerm,
+		"Samaritan":                          unicode.Samaritan,
+		"Saurashtra":                         unicode.Saurashtra,
+		"Sc":                                 unicode.Sc,
+		"Scripts":                            unicode.Scripts,
+		"Sentence_Terminal":                  unicode.Sentence_Terminal,
+		"Sharada":                            unicode.Sharada,
+		"Shavian":                            unicode.Shavian,
+		"Siddham":                            unicode.Siddham,
+		"SignWriting":                        unicode.SignWriting,
+		"Sinhala":                            unicode.Sinhala,
+		"Sk":                                 unicode.Sk,
+		"Sm":                                 unicode.Sm,
+		"So":                                 unicode.So,
+		"Soft_Dotted":                        unicode.Soft_Dotted,
+		"Sogdian":                            unicode.Sogdian,
+		"Sora_Sompeng":                       unicode.Sora_Sompeng,
+		"Soyombo":                            unicode.Soyombo,
+		"Space":                              unicode.Space,
+		"Sundanese":                          unicode.Sundanese,
+		"Syloti_Nagri":                       unicode.Syloti_Nagri,
+		"Symbol":                             unicode.Symbol,
+		"Syriac":                             unicode.Syriac,
+		"Tagalog":                            unicode.Tagalog,
+		"Tagbanwa":                           unicode.Tagbanwa,
+		"Tai_Le":                             unicode.Tai_Le,
+		"Tai_Tham":                           unicode.Tai_Tham,
+		"Tai_Viet":                           unicode.Tai_Viet,
+		"Takri":                              unicode.Takri,
+		"Tamil":                              unicode.Tamil,
+		"Tangsa":                             unicode.Tangsa,
+		"Tangut":                             unicode.Tangut,
+		"Telugu":                             unicode.Telugu,
+		"Terminal_Punctuation":               unicode.Terminal_Punctuation,
+		"Thaana":                             unicode.Thaana,
+		"Thai":                               unicode.Thai,
+		"Tibetan":                            unicode.Tibetan,
+		"Tifinagh":                           unicode.Tifinagh,
+		"Tirhuta":                            unicode.Tirhuta,
+		"Title":                              unicode.Title,
+		"TitleCase":                          int64(unicode.TitleCase),
+		"Toto":                               unicode.Toto,
+		"TurkishCase":                        unicode.TurkishCase,
+		"Ugaritic":                           unicode.Ugaritic,
+		"Unified_Ideograph":                  unicode.Unified_Ideograph,
+		"Upper":                              unicode.Upper,
+		"UpperCase":                          int64(unicode.UpperCase),
+		"UpperLower":                         rune(unicode.UpperLower),
+		"Vai":                                unicode.Vai,
+		"Variation_Selector":                 unicode.Variation_Selector,
+		"Version":                            unicode.Version,
+		"Vithkuqi":                           unicode.Vithkuqi,
+		"Wancho":                             unicode.Wancho,
+		"Warang_Citi":                        unicode.Warang_Citi,
+		"White_Space":                        unicode.White_Space,
+		"Yezidi":                             unicode.Yezidi,
+		"Yi":                                 unicode.Yi,
+		"Z":                                  unicode.Z,
+		"Zanabazar_Square":                   unicode.Zanabazar_Square,
+		"Zl":                                 unicode.Zl,
+		"Zp":                                 unicode.Zp,
+		"Zs":                                 unicode.Zs,
+	})
+	self.RegisterTypes("unicode", []interface{}{
+		(*unicode.CaseRange)(nil),
+		(*unicode.Range16)(nil),
+		(*unicode.Range32)(nil),
+		(*unicode.RangeTable)(nil),
+		(*unicode.SpecialCase)(nil),
+	})
+
+	self.RegisterFuncMap("unicode/utf8", map[string]interface{}{
+		"AppendRune":             utf8.AppendRune,
+		"DecodeLastRune":         utf8.DecodeLastRune,
+		"DecodeLastRuneInString": utf8.DecodeLastRuneInString,
+		"DecodeRune":             utf8.DecodeRune,
+		"DecodeRuneInString":     utf8.DecodeRuneInString,
+		"EncodeRune":             utf8.EncodeRune,
+		"FullRune":               utf8.FullRune,
+		"FullRuneInString":       utf8.FullRuneInString,
+		"RuneCount":              utf8.RuneCount,
+		"RuneCountInString":      utf8.RuneCountInString,
+		"RuneLen":                utf8.RuneLen,
+		"RuneStart":              utf8.RuneStart,
+		"Valid":                  utf8.Valid,
+		"ValidRune":              utf8.ValidRune,
+		"ValidString":            utf8.ValidString,
+	})
+	self.RegisterVars("unicode/utf8", map[string]interface{}{
+		"MaxRune":   rune(utf8.MaxRune),
+		"RuneError": rune(utf8.RuneError),
+		"RuneSelf":  int64(utf8.RuneSelf),
+		"UTFMax":    int64(utf8.UTFMax),
+	})
+}
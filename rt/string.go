@@ -1,9 +1,13 @@
 package rt
 
 import (
+	"fmt"
+	"math/big"
 	"regexp"
 	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 /// string
@@ -12,6 +16,16 @@ type StringObject struct {
 	Property
 
 	Val string
+	// the runes of Val, made the first time an index counts runes so a
+	// loop over a string doesn't convert it once per character
+	runes []rune
+}
+
+func (self *StringObject) chars() []rune {
+	if self.runes == nil {
+		self.runes = []rune(self.Val)
+	}
+	return self.runes
 }
 
 func (self *StringObject) Name() string {
@@ -32,18 +46,33 @@ func (self *StringObject) ToString(rt *Runtime, args ...Object) []Object {
 
 /// methods
 
+// strings are indexed by rune, Bytes() gives the raw utf-8 encoding
 func (self *StringObject) Length(rt *Runtime, args ...Object) (results []Object) {
-	ret := rt.NewIntegerObject(len(self.Val))
+	ret := rt.NewIntegerObject(utf8.RuneCountInString(self.Val))
 	results = append(results, ret)
 	return
 }
 
 func (self *StringObject) Size(rt *Runtime, args ...Object) (results []Object) {
-	ret := rt.NewIntegerObject(len(self.Val))
+	ret := rt.NewIntegerObject(utf8.RuneCountInString(self.Val))
 	results = append(results, ret)
 	return
 }
 
+func (self *StringObject) Bytes(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewBytesObject([]byte(self.Val)))
+	return
+}
+
+func (self *StringObject) Runes(rt *Runtime, args ...Object) (results []Object) {
+	vals := []Object{}
+	for _, r := range self.Val {
+		vals = append(vals, rt.NewRuneObject(r))
+	}
+	results = append(results, rt.NewArrayObject(vals))
+	return
+}
+
 func (self *StringObject) Trim(rt *Runtime, args ...Object) (results []Object) {
 	val := strings.TrimSpace(self.Val)
 	results = append(results, rt.NewStringObject(val))
@@ -51,11 +80,11 @@ func (self *StringObject) Trim(rt *Runtime, args ...Object) (results []Object) {
 }
 
 func (self *StringObject) ParseInt(rt *Runtime, args ...Object) (results []Object) {
-	v, err := strconv.ParseInt(self.Val, 10, 32)
-	if err != nil {
+	v, ok := new(big.Int).SetString(self.Val, 10)
+	if !ok {
 		results = append(results, rt.Nil)
 	} else {
-		results = append(results, rt.NewIntegerObject(int(v)))
+		results = append(results, rt.NewInteger(v))
 	}
 	return
 }
@@ -71,19 +100,300 @@ func (self *StringObject) ParseFloat(rt *Runtime, args ...Object) (results []Obj
 }
 
 func (self *StringObject) Split(rt *Runtime, args ...Object) (results []Object) {
-	pat := args[0].String()
+	re := rt.regexpArg("string::Split", args[0])
 	n := -1
 	if len(args) == 2 {
-		n = args[1].(*IntegerObject).Val
+		n = rt.intArg("string::Split", args[1])
 	}
-	re := regexp.MustCompile(pat)
 	parts := re.Split(self.Val, n)
+	results = append(results, rt.stringArray(parts))
+	return
+}
 
-	arr := []Object{}
-	for _, part := range parts {
-		arr = append(arr, rt.NewStringObject(part))
+func (self *Runtime) stringArray(strs []string) Object {
+	arr := make([]Object, len(strs))
+	for i, str := range strs {
+		arr[i] = self.NewStringObject(str)
 	}
-	results = append(results, rt.NewArrayObject(arr))
+	return self.NewArrayObject(arr)
+}
+
+func (self *Runtime) intArg(method string, arg Object) int {
+	n, ok := arg.(*IntegerObject)
+	if !ok {
+		self.Fatalf("%s needs an integer, %s given", method, arg.Name())
+	}
+	return n.Val
+}
+
+func (self *StringObject) Upcase(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewStringObject(strings.ToUpper(self.Val)))
+	return
+}
+
+func (self *StringObject) Downcase(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewStringObject(strings.ToLower(self.Val)))
+	return
+}
+
+// the first rune upper case and the rest lower case
+func (self *StringObject) Capitalize(rt *Runtime, args ...Object) (results []Object) {
+	val := strings.ToLower(self.Val)
+	if r, size := utf8.DecodeRuneInString(val); size > 0 {
+		val = string(unicode.ToUpper(r)) + val[size:]
+	}
+	results = append(results, rt.NewStringObject(val))
+	return
+}
+
+func (self *StringObject) StartsWith(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewBoolObject(strings.HasPrefix(self.Val, args[0].String())))
+	return
+}
+
+func (self *StringObject) EndsWith(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewBoolObject(strings.HasSuffix(self.Val, args[0].String())))
+	return
+}
+
+func (self *StringObject) Include(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewBoolObject(strings.Contains(self.Val, args[0].String())))
+	return
+}
+
+// the rune index of the first occurrence of a substring, -1 when there is none
+func (self *StringObject) Index(rt *Runtime, args ...Object) (results []Object) {
+	i := strings.Index(self.Val, args[0].String())
+	if i > 0 {
+		i = utf8.RuneCountInString(self.Val[:i])
+	}
+	results = append(results, rt.NewIntegerObject(i))
+	return
+}
+
+// Replace(old, new) replaces every occurrence of old, Replace(old, new, n)
+// the first n
+func (self *StringObject) Replace(rt *Runtime, args ...Object) (results []Object) {
+	n := -1
+	if len(args) > 2 {
+		n = rt.intArg("string::Replace", args[2])
+	}
+	val := strings.Replace(self.Val, args[0].String(), args[1].String(), n)
+	results = append(results, rt.NewStringObject(val))
+	return
+}
+
+// Gsub(pattern, repl) replaces every match of a regexp. repl is a string in
+// which $1 or ${name} expand to groups, or a function called with the match
+// and as many groups as it takes more arguments
+func (self *StringObject) Gsub(rt *Runtime, args ...Object) (results []Object) {
+	re := rt.regexpArg("string::Gsub", args[0])
+	results = append(results, rt.NewStringObject(rt.replaceAll(re, self.Val, args[1])))
+	return
+}
+
+func (self *Runtime) replaceAll(re *regexp.Regexp, src string, repl Object) string {
+	switch repl.(type) {
+	case *ClosureObject, *GoFuncObject, *FuncObject:
+	default:
+		return re.ReplaceAllString(src, repl.String())
+	}
+
+	n := arity(repl)
+	s := ""
+	last := 0
+	for _, loc := range re.FindAllStringSubmatchIndex(src, -1) {
+		args := []Object{}
+		for i, group := range submatches(src, loc) {
+			if i < n {
+				args = append(args, self.NewStringObject(group))
+			}
+		}
+		s += src[last:loc[0]] + self.Call(repl, args...).String()
+		last = loc[1]
+	}
+	return s + src[last:]
+}
+
+// the lines without their line endings
+func (self *StringObject) Lines(rt *Runtime, args ...Object) (results []Object) {
+	lines := []string{}
+	if self.Val != "" {
+		lines = strings.Split(strings.TrimSuffix(self.Val, "\n"), "\n")
+	}
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\r")
+	}
+	results = append(results, rt.stringArray(lines))
+	return
+}
+
+// the runes as one character strings
+func (self *StringObject) Chars(rt *Runtime, args ...Object) (results []Object) {
+	chars := []string{}
+	for _, r := range self.Val {
+		chars = append(chars, string(r))
+	}
+	results = append(results, rt.stringArray(chars))
+	return
+}
+
+func (self *StringObject) Repeat(rt *Runtime, args ...Object) (results []Object) {
+	n := rt.intArg("string::Repeat", args[0])
+	if n < 0 {
+		rt.Fatalf("string::Repeat negative count %d", n)
+	}
+	results = append(results, rt.NewStringObject(strings.Repeat(self.Val, n)))
+	return
+}
+
+// the string as a fmt format, numbers, strings, runes and bools are passed as
+// go values and other objects format as their string
+func (self *StringObject) Format(rt *Runtime, args ...Object) (results []Object) {
+	vals := make([]interface{}, len(args))
+	for i, arg := range args {
+		switch arg := arg.(type) {
+		case *IntegerObject:
+			vals[i] = arg.Val
+		case *BigIntObject:
+			vals[i] = arg.Val
+		case *FloatObject:
+			vals[i] = arg.Val
+		case *StringObject:
+			vals[i] = arg.Val
+		case *RuneObject:
+			vals[i] = arg.Val
+		case *BoolObject:
+			vals[i] = arg.Val
+		default:
+			vals[i] = arg.String()
+		}
+	}
+	results = append(results, rt.NewStringObject(fmt.Sprintf(self.Val, vals...)))
+	return
+}
+
+// Center(width), Ljust(width) and Rjust(width) pad up to width runes with
+// spaces or with the runes of the optional second argument
+func (self *StringObject) Center(rt *Runtime, args ...Object) (results []Object) {
+	n, fill := self.padding(rt, "string::Center", args)
+	val := padding(n/2, fill) + self.Val + padding(n-n/2, fill)
+	results = append(results, rt.NewStringObject(val))
+	return
+}
+
+func (self *StringObject) Ljust(rt *Runtime, args ...Object) (results []Object) {
+	n, fill := self.padding(rt, "string::Ljust", args)
+	results = append(results, rt.NewStringObject(self.Val+padding(n, fill)))
+	return
+}
+
+func (self *StringObject) Rjust(rt *Runtime, args ...Object) (results []Object) {
+	n, fill := self.padding(rt, "string::Rjust", args)
+	results = append(results, rt.NewStringObject(padding(n, fill)+self.Val))
+	return
+}
+
+func (self *StringObject) padding(rt *Runtime, method string, args []Object) (int, []rune) {
+	n := rt.intArg(method, args[0]) - utf8.RuneCountInString(self.Val)
+	fill := []rune(" ")
+	if len(args) > 1 {
+		fill = []rune(args[1].String())
+		if len(fill) == 0 {
+			rt.Fatalf("%s empty padding", method)
+		}
+	}
+	if n < 0 {
+		n = 0
+	}
+	return n, fill
+}
+
+func padding(n int, fill []rune) string {
+	runes := make([]rune, n)
+	for i := range runes {
+		runes[i] = fill[i%len(fill)]
+	}
+	return string(runes)
+}
+
+func (self *StringObject) Reverse(rt *Runtime, args ...Object) (results []Object) {
+	runes := []rune(self.Val)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	results = append(results, rt.NewStringObject(string(runes)))
+	return
+}
+
+// Strip() removes leading and trailing white space, Strip(chars) any of the
+// runes in chars. Lstrip and Rstrip only strip one side
+func (self *StringObject) Strip(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewStringObject(strip(self.Val, args, true, true)))
+	return
+}
+
+func (self *StringObject) Lstrip(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewStringObject(strip(self.Val, args, true, false)))
+	return
+}
+
+func (self *StringObject) Rstrip(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewStringObject(strip(self.Val, args, false, true)))
+	return
+}
+
+func strip(s string, args []Object, left, right bool) string {
+	cut := unicode.IsSpace
+	if len(args) > 0 {
+		chars := args[0].String()
+		cut = func(r rune) bool { return strings.ContainsRune(chars, r) }
+	}
+	if left {
+		s = strings.TrimLeftFunc(s, cut)
+	}
+	if right {
+		s = strings.TrimRightFunc(s, cut)
+	}
+	return s
+}
+
+// ToInt() parses a decimal integer, ToInt(base) one in base 2 to 36, base 0
+// takes it from a 0x, 0o or 0b prefix. nil when the string is no integer
+func (self *StringObject) ToInt(rt *Runtime, args ...Object) (results []Object) {
+	base := 10
+	if len(args) > 0 {
+		base = rt.intArg("string::ToInt", args[0])
+		if base != 0 && (base < 2 || base > 36) {
+			rt.Fatalf("string::ToInt invalid base %d", base)
+		}
+	}
+	v, ok := new(big.Int).SetString(self.Val, base)
+	if !ok {
+		results = append(results, rt.Nil)
+	} else {
+		results = append(results, rt.NewInteger(v))
+	}
+	return
+}
+
+// ToRegexp() compiles the string as a pattern, ToRegexp(flags) with flags
+func (self *StringObject) ToRegexp(rt *Runtime, args ...Object) (results []Object) {
+	flags := ""
+	if len(args) > 0 {
+		flags = args[0].String()
+	}
+	re, err := rt.compileRegexp(self.Val, flags)
+	if err != nil {
+		rt.Fatalf("invalid regexp /%s/%s: %v", self.Val, flags, err)
+	}
+	results = append(results, rt.NewRegexpObject(re, self.Val, flags))
+	return
+}
+
+// the interned symbol with this name, for property access by a computed name
+func (self *StringObject) ToSymbol(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.Intern(self.Val))
 	return
 }
 
@@ -95,11 +405,17 @@ func (self *StringObject) OP__add__(rt *Runtime, args ...Object) (results []Obje
 	return
 }
 
-func (self *StringObject) OP__add_assign__(rt *Runtime, args ...Object) (results []Object) {
-	self.Val += args[0].String()
+// s =~ re tests for a match, re may also be a pattern string
+func (self *StringObject) OP__match__(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewBoolObject(rt.regexpArg("=~", args[0]).MatchString(self.Val)))
 	return
 }
 
+// s * n repeats s
+func (self *StringObject) OP__mul__(rt *Runtime, args ...Object) (results []Object) {
+	return self.Repeat(rt, args...)
+}
+
 func (self *StringObject) OP__eql__(rt *Runtime, args ...Object) (results []Object) {
 	cmp := self.Val == args[0].String()
 	results = append(results, rt.NewBoolObject(cmp))
@@ -112,28 +428,67 @@ func (self *StringObject) OP__neq__(rt *Runtime, args ...Object) (results []Obje
 	return
 }
 
+// the other side of an ordering, only strings order with strings
+func (self *StringObject) operand(rt *Runtime, method string, obj Object) string {
+	other, ok := obj.(*StringObject)
+	if !ok {
+		rt.Fatalf("string::%s unsupported operand %s", method, obj.Name())
+	}
+	return other.Val
+}
+
+// strings order by their bytes
+func (self *StringObject) OP__lss__(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewBoolObject(self.Val < self.operand(rt, "<", args[0])))
+	return
+}
+
+func (self *StringObject) OP__gtr__(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewBoolObject(self.Val > self.operand(rt, ">", args[0])))
+	return
+}
+
+func (self *StringObject) OP__leq__(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewBoolObject(self.Val <= self.operand(rt, "<=", args[0])))
+	return
+}
+
+func (self *StringObject) OP__geq__(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewBoolObject(self.Val >= self.operand(rt, ">=", args[0])))
+	return
+}
+
 func (self *StringObject) OP__get_index__(rt *Runtime, args ...Object) (results []Object) {
-	idx := args[0].(*IntegerObject)
-	ch := string(self.Val[idx.Val])
-	obj := rt.NewStringObject(ch)
+	runes := self.chars()
+	obj := rt.NewRuneObject(runes[rt.index(args[0], len(runes))])
 	results = append(results, obj)
 	return
 }
 
 func (self *StringObject) OP__slice__(rt *Runtime, args ...Object) (results []Object) {
-	low := 0
-	high := len(self.Val)
+	runes := self.chars()
+	lo, hi, step := rt.sliceIndices(args, len(runes))
 
-	lo := args[0]
-	if lo != nil {
-		low = lo.(*IntegerObject).Val
+	if step == 1 {
+		results = append(results, rt.NewStringObject(string(runes[lo:hi])))
+		return
 	}
-	ho := args[1]
-	if ho != nil {
-		high = ho.(*IntegerObject).Val
+	sub := make([]rune, 0, sliceLen(lo, hi, step))
+	for i := lo; len(sub) < cap(sub); i += step {
+		sub = append(sub, runes[i])
 	}
+	results = append(results, rt.NewStringObject(string(sub)))
+	return
+}
 
-	ret := rt.NewStringObject(self.Val[low:high])
-	results = append(results, ret)
+// for i, r = range s, i counts runes like the index operator
+func (self *StringObject) OP__iter__(rt *Runtime, args ...Object) (results []Object) {
+	idx := args[0].(*IntegerObject)
+	runes := self.chars()
+	if idx.Val < len(runes) {
+		results = append(results, args[0], rt.NewRuneObject(runes[idx.Val]), rt.True)
+	} else {
+		results = append(results, rt.False)
+	}
 	return
 }
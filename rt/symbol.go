@@ -0,0 +1,61 @@
+package rt
+
+import (
+	"fmt"
+)
+
+/// symbol
+
+// symbols are interned, there is one SymbolObject per name so two symbols
+// are equal exactly when they are the same pointer
+type SymbolObject struct {
+	Property
+
+	Val  string
+	hash string
+}
+
+// Intern returns the symbol for name, creating it on first use
+func (self *Runtime) Intern(name string) *SymbolObject {
+	sym, ok := self.symbols[name]
+	if !ok {
+		sym = &SymbolObject{Property: MakeProperty(nil, &self.symbolProperties), Val: name}
+		sym.hash = fmt.Sprintf("%p", sym)
+		self.symbols[name] = sym
+	}
+	return sym
+}
+
+func (self *SymbolObject) Name() string {
+	return "symbol"
+}
+
+// computed once when interned, it never collides with a string key
+func (self *SymbolObject) HashCode() string {
+	return self.hash
+}
+
+func (self *SymbolObject) String() string {
+	return self.Val
+}
+
+func (self *SymbolObject) ToString(rt *Runtime, args ...Object) []Object {
+	return []Object{rt.NewStringObject(self.String())}
+}
+
+func (self *SymbolObject) Inspect(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewStringObject(":"+self.Val))
+	return
+}
+
+/// operators
+
+func (self *SymbolObject) OP__eql__(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewBoolObject(self == args[0]))
+	return
+}
+
+func (self *SymbolObject) OP__neq__(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewBoolObject(self != args[0]))
+	return
+}
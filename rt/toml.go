@@ -0,0 +1,85 @@
+package rt
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+/// toml module
+
+// decoding into go maps loses the order of the keys, so the keys of a table
+// are sorted back by where they first appear in the document
+type tomlDecoder struct {
+	rt    *Runtime
+	order map[string]int
+}
+
+func (self *tomlDecoder) value(path string, v interface{}) Object {
+	rt := self.rt
+	switch v := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.SliceStable(keys, func(i, j int) bool {
+			return self.position(path, keys[i], keys[j])
+		})
+		slots := make([]Slot, len(keys))
+		for i, k := range keys {
+			slots[i] = Slot{rt.NewStringObject(k), self.value(path+"\x00"+k, v[k])}
+		}
+		return rt.NewDictObject(slots)
+	case []map[string]interface{}:
+		vals := make([]Object, len(v))
+		for i, table := range v {
+			vals[i] = self.value(path, table)
+		}
+		return rt.NewArrayObject(vals)
+	case []interface{}:
+		vals := make([]Object, len(v))
+		for i, elem := range v {
+			vals[i] = self.value(path, elem)
+		}
+		return rt.NewArrayObject(vals)
+	case time.Time:
+		return rt.NewGoObject(v)
+	}
+	return rt.GoValueToObject(v)
+}
+
+// whether key a of a table comes before key b, keys missing from the
+// document go last in name order
+func (self *tomlDecoder) position(path, a, b string) bool {
+	i, oka := self.order[path+"\x00"+a]
+	j, okb := self.order[path+"\x00"+b]
+	if oka && okb {
+		return i < j
+	}
+	if oka || okb {
+		return oka
+	}
+	return a < b
+}
+
+// toml.Parse(src) decodes a document of a string, bytes or a reader into a
+// dict, tables are dicts and datetimes go time.Time objects
+func tomlParse(rt *Runtime, args ...Object) []Object {
+	var doc map[string]interface{}
+	md, err := toml.NewDecoder(rt.readerArg("toml.Parse", args[0])).Decode(&doc)
+	if err != nil {
+		rt.Fatalf("%s", err)
+	}
+
+	dec := &tomlDecoder{rt, map[string]int{}}
+	for i, key := range md.Keys() {
+		path := "\x00" + strings.Join(key, "\x00")
+		if _, ok := dec.order[path]; !ok {
+			dec.order[path] = i
+		}
+	}
+	return []Object{dec.value("", doc)}
+}
@@ -0,0 +1,150 @@
+package rt
+
+import (
+	"fmt"
+)
+
+/// tuple
+
+// tuples are immutable arrays, they are born frozen and hash by their
+// elements and the types of them, so equal tuples are the same dict or set
+// key and #(1) is not #("1")
+type TupleObject struct {
+	Property
+	Enumerable
+
+	Vals []Object
+}
+
+func (self *Runtime) NewTupleObject(vals []Object) *TupleObject {
+	obj := &TupleObject{Property: MakeProperty(nil, &self.tupleProperties), Vals: vals}
+	obj.owner = obj
+	obj.frozen = true
+	return obj
+}
+
+func (self *TupleObject) Name() string {
+	return "tuple"
+}
+
+func (self *TupleObject) HashCode() string {
+	s := "#("
+	for _, val := range self.Vals {
+		// length prefixed, no element can run into the next one
+		key := setKey(val)
+		s += fmt.Sprintf("%d:%s", len(key), key)
+	}
+	return s + ")"
+}
+
+// element by element, an element only equals one of its own type
+func (self *TupleObject) equal(rt *Runtime, obj Object) bool {
+	other, ok := obj.(*TupleObject)
+	if !ok || len(other.Vals) != len(self.Vals) {
+		return false
+	}
+	for i, val := range self.Vals {
+		if val.Name() != other.Vals[i].Name() || !rt.invokeBool(val, "__eql__", other.Vals[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (self *TupleObject) String() string {
+	s := "#("
+	ln := len(self.Vals)
+	for i, val := range self.Vals {
+		s += val.String()
+		if i < ln-1 {
+			s += ","
+		}
+	}
+	s += ")"
+	return s
+}
+
+func (self *TupleObject) ToString(rt *Runtime, args ...Object) []Object {
+	return []Object{rt.NewStringObject(self.String())}
+}
+
+func (self *TupleObject) Freeze(rt *Runtime, args ...Object) (results []Object) {
+	for _, val := range self.Vals {
+		val.Freeze(rt)
+	}
+	results = append(results, self)
+	return
+}
+
+func (self *TupleObject) Length(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewIntegerObject(len(self.Vals)))
+	return
+}
+
+func (self *TupleObject) Size(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewIntegerObject(len(self.Vals)))
+	return
+}
+
+func (self *TupleObject) ToArray(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewArrayObject(append([]Object{}, self.Vals...)))
+	return
+}
+
+/// operators
+
+func (self *TupleObject) OP__iter__(rt *Runtime, args ...Object) (results []Object) {
+	idx := args[0].(*IntegerObject)
+	if idx.Val < len(self.Vals) {
+		obj := self.Vals[idx.Val]
+		results = append(results, args[0], obj, rt.True)
+	} else {
+		results = append(results, rt.False)
+	}
+	return
+}
+
+func (self *TupleObject) OP__add__(rt *Runtime, args ...Object) (results []Object) {
+	other, ok := args[0].(*TupleObject)
+	if !ok {
+		rt.Fatalf("tuple::+ unsupported operand %s", args[0].Name())
+	}
+	vals := append(append([]Object{}, self.Vals...), other.Vals...)
+	results = append(results, rt.NewTupleObject(vals))
+	return
+}
+
+func (self *TupleObject) OP__eql__(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewBoolObject(self.equal(rt, args[0])))
+	return
+}
+
+func (self *TupleObject) OP__neq__(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, rt.NewBoolObject(!self.equal(rt, args[0])))
+	return
+}
+
+func (self *TupleObject) OP__get_index__(rt *Runtime, args ...Object) (results []Object) {
+	results = append(results, self.Vals[rt.index(args[0], len(self.Vals))])
+	return
+}
+
+func (self *TupleObject) OP__set_index__(rt *Runtime, args ...Object) (results []Object) {
+	rt.checkFrozen(self)
+	return
+}
+
+func (self *TupleObject) OP__slice__(rt *Runtime, args ...Object) (results []Object) {
+	lo, hi, step := rt.sliceIndices(args, len(self.Vals))
+	vals := make([]Object, 0, sliceLen(lo, hi, step))
+	for i := lo; len(vals) < cap(vals); i += step {
+		vals = append(vals, self.Vals[i])
+	}
+	results = append(results, rt.NewTupleObject(vals))
+	return
+}
+
+func (self *TupleObject) OP__set_slice__(rt *Runtime, args ...Object) (results []Object) {
+	rt.checkFrozen(self)
+	return
+}
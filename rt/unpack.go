@@ -0,0 +1,73 @@
+package rt
+
+import (
+	"reflect"
+)
+
+/// destructuring
+
+// the elements of an array or tuple matched by an array pattern
+func sequenceVals(obj Object) ([]Object, bool) {
+	switch seq := obj.(type) {
+	case *ArrayObject:
+		return seq.Vals, true
+	case *TupleObject:
+		return seq.Vals, true
+	}
+	return nil, false
+}
+
+// UnpackArray splits obj for an array pattern of num elements. when rest
+// is not -1 the pattern has a ...rest at that position which takes the
+// elements left over as an array
+func (self *Runtime) UnpackArray(obj Object, num, rest int) (results []Object) {
+	vals, ok := sequenceVals(obj)
+	if !ok {
+		self.Fatalf("cannot destructure %s with an array pattern", obj.Name())
+	}
+
+	if rest < 0 && len(vals) != num {
+		self.Fatalf("array pattern expects %d elements, got %d", num, len(vals))
+	}
+	if rest >= 0 && len(vals) < num {
+		self.Fatalf("array pattern expects at least %d elements, got %d", num, len(vals))
+	}
+
+	if rest < 0 {
+		return append(results, vals...)
+	}
+	tail := num - rest
+	results = append(results, vals[:rest]...)
+	results = append(results, self.NewArrayObject(append([]Object{}, vals[rest:len(vals)-tail]...)))
+	results = append(results, vals[len(vals)-tail:]...)
+	return
+}
+
+// UnpackDict looks up every key of a dict pattern, or field of a go struct,
+// a missing key is an error
+func (self *Runtime) UnpackDict(obj Object, keys []Object) (results []Object) {
+	if gobj, ok := obj.(*GoObject); ok {
+		for _, key := range keys {
+			f, ok := gobj.field(key)
+			if !ok {
+				self.Fatalf("%s has no exported field %s", reflect.TypeOf(gobj.obj), key)
+			}
+			results = append(results, self.goResult(f))
+		}
+		return
+	}
+
+	dict, ok := obj.(*DictObject)
+	if !ok {
+		self.Fatalf("cannot destructure %s with a dict pattern", obj.Name())
+	}
+
+	for _, key := range keys {
+		val, ok := dict.get(key)
+		if !ok {
+			self.Fatalf("dict pattern key %s not found in %s", key, dict)
+		}
+		results = append(results, val)
+	}
+	return
+}
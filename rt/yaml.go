@@ -0,0 +1,196 @@
+package rt
+
+import (
+	"io"
+	"math/big"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+/// yaml module
+
+// yaml documents become the values json gives, mappings are dicts with string
+// keys in document order, and timestamps are go time.Time objects
+type yamlDecoder struct {
+	rt *Runtime
+
+	expanding map[*yaml.Node]bool // anchors whose aliases are being expanded
+	aliased   int                 // open alias expansions
+	decoded   int                 // values decoded
+	expanded  int                 // values decoded through aliases
+}
+
+func newYamlDecoder(rt *Runtime) *yamlDecoder {
+	return &yamlDecoder{rt: rt, expanding: map[*yaml.Node]bool{}}
+}
+
+// follow enters the anchor an alias refers to, the returned function leaves
+// it. an anchor inside itself is an error, and like yaml.v3 decoding into go
+// values, a document must not expand its aliases to far more values than
+// it has
+func (self *yamlDecoder) follow(alias *yaml.Node) (*yaml.Node, func()) {
+	anchor := alias.Alias
+	if self.expanding[anchor] {
+		self.rt.Fatalf("yaml: line %d: anchor %s contains an alias to itself", alias.Line, alias.Value)
+	}
+	self.expanding[anchor] = true
+	self.aliased++
+	return anchor, func() {
+		delete(self.expanding, anchor)
+		self.aliased--
+	}
+}
+
+// the share of values from aliases yaml.v3 allows, less for large documents
+func allowedAliasRatio(decoded int) float64 {
+	switch {
+	case decoded <= 400000:
+		return 0.99
+	case decoded >= 4000000:
+		return 0.10
+	}
+	return 0.99 - 0.89*(float64(decoded-400000)/3600000)
+}
+
+func (self *yamlDecoder) value(node *yaml.Node) Object {
+	rt := self.rt
+	self.decoded++
+	if self.aliased > 0 {
+		self.expanded++
+		if self.expanded > 100 && float64(self.expanded) > allowedAliasRatio(self.decoded)*float64(self.decoded) {
+			rt.Fatalf("yaml: document contains excessive aliasing")
+		}
+	}
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return rt.Nil
+		}
+		return self.value(node.Content[0])
+	case yaml.AliasNode:
+		anchor, leave := self.follow(node)
+		val := self.value(anchor)
+		leave()
+		return val
+	case yaml.SequenceNode:
+		vals := make([]Object, len(node.Content))
+		for i, elem := range node.Content {
+			vals[i] = self.value(elem)
+		}
+		return rt.NewArrayObject(vals)
+	case yaml.MappingNode:
+		dict := rt.NewDictObject(nil).(*DictObject)
+		self.merge(dict, node, false)
+		return dict
+	}
+	return self.scalar(node)
+}
+
+// merge puts the pairs of a mapping into dict, keys from a << merge never
+// replace the keys the mapping has itself
+func (self *yamlDecoder) merge(dict *DictObject, node *yaml.Node, merged bool) {
+	if node.Kind == yaml.AliasNode {
+		anchor, leave := self.follow(node)
+		defer leave()
+		node = anchor
+	}
+	if node.Kind != yaml.MappingNode {
+		self.rt.Fatalf("yaml: line %d: << needs a mapping", node.Line)
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, val := node.Content[i], node.Content[i+1]
+		if key.ShortTag() == "!!merge" {
+			if val.Kind == yaml.SequenceNode {
+				for _, m := range val.Content {
+					self.merge(dict, m, true)
+				}
+			} else {
+				self.merge(dict, val, true)
+			}
+			continue
+		}
+		k := self.key(key)
+		if _, ok := dict.get(k); ok && merged {
+			continue
+		}
+		dict.put(k, self.value(val))
+	}
+}
+
+func (self *yamlDecoder) key(node *yaml.Node) Object {
+	if node.Kind == yaml.ScalarNode && node.ShortTag() == "!!str" {
+		return self.rt.NewStringObject(node.Value)
+	}
+	return self.value(node)
+}
+
+func (self *yamlDecoder) scalar(node *yaml.Node) Object {
+	rt := self.rt
+	if node.ShortTag() == "!!binary" {
+		var b string
+		self.decode(node, &b)
+		return rt.NewBytesObject([]byte(b))
+	}
+
+	var v interface{}
+	self.decode(node, &v)
+	switch v := v.(type) {
+	case nil:
+		return rt.Nil
+	case time.Time:
+		return rt.NewGoObject(v)
+	case float64:
+		// integers too big for int64 resolve to floats
+		if b, ok := new(big.Int).SetString(node.Value, 0); ok {
+			return rt.NewInteger(b)
+		}
+	}
+	return rt.GoValueToObject(v)
+}
+
+func (self *yamlDecoder) decode(node *yaml.Node, v interface{}) {
+	if err := node.Decode(v); err != nil {
+		self.rt.Fatalf("%s", err)
+	}
+}
+
+// the documents of src one by one
+func (self *Runtime) yamlDocuments(fname string, src Object, each func(Object) bool) {
+	dec := yaml.NewDecoder(self.readerArg(fname, src))
+	yd := newYamlDecoder(self)
+	for {
+		var doc yaml.Node
+		err := dec.Decode(&doc)
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			self.Fatalf("%s", err)
+		}
+		if !each(yd.value(&doc)) {
+			return
+		}
+	}
+}
+
+// yaml.Parse(src) decodes the first document of a string, bytes or a reader,
+// nil when there is none
+func yamlParse(rt *Runtime, args ...Object) []Object {
+	var val Object = rt.Nil
+	rt.yamlDocuments("yaml.Parse", args[0], func(doc Object) bool {
+		val = doc
+		return false
+	})
+	return []Object{val}
+}
+
+// yaml.ParseAll(src) is an array of every document of src
+func yamlParseAll(rt *Runtime, args ...Object) []Object {
+	docs := []Object{}
+	rt.yamlDocuments("yaml.ParseAll", args[0], func(doc Object) bool {
+		docs = append(docs, doc)
+		return true
+	})
+	return []Object{rt.NewArrayObject(docs)}
+}
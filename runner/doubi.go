@@ -3,9 +3,12 @@
 package runner
 
 import (
+	"errors"
 	"fmt"
 	"io/ioutil"
+	"strings"
 
+	"github.com/jxwr/doby/ast"
 	"github.com/jxwr/doby/comp"
 	"github.com/jxwr/doby/parser"
 	"github.com/jxwr/doby/rt"
@@ -48,21 +51,29 @@ func (self *Runner) RegisterVars(name string, vars map[string]interface{}) {
 	self.runtime.RegisterVars(name, vars)
 }
 
-func (self *Runner) Run(filename string) {
-	var contents []byte
-	var err error
-
-	fmt.Println("=============> ", filename, " <=============")
-
-	contents, err = ioutil.ReadFile(filename)
-	if err != nil {
-		return
-	}
+func (self *Runner) RegisterTypes(name string, types []interface{}) {
+	self.runtime.RegisterTypes(name, types)
+}
 
+// compile parses a script and builds its IR, the root closure is
+// irb.RootClosure(). a config returns its final expression, and stops at a
+// syntax error which it returns rather than prints
+func (self *Runner) compile(filename string, contents []byte, config bool) error {
 	parser.ProgramAst = nil
 	lexer := parser.NewLexer(filename, string(contents))
+	lexer.Quiet = config
 	self.irb.SetLexer(lexer)
+	self.runtime.PosInfo = lexer.PosInfo
 	parser.DobyParse(lexer)
+	if config && len(lexer.Errors) > 0 {
+		return errors.New(strings.TrimSpace(lexer.Errors[0]))
+	}
+
+	if n := len(parser.ProgramAst); config && n > 0 {
+		if stmt, ok := parser.ProgramAst[n-1].(*ast.ExprStmt); ok {
+			parser.ProgramAst[n-1] = &ast.ReturnStmt{Results: []ast.Expr{stmt.X}}
+		}
+	}
 
 	for _, stmt := range parser.ProgramAst {
 		stmt.Accept(self.attr)
@@ -73,6 +84,22 @@ func (self *Runner) Run(filename string) {
 	for _, stmt := range parser.ProgramAst {
 		stmt.Accept(irb)
 	}
+	return nil
+}
+
+func (self *Runner) Run(filename string) {
+	var contents []byte
+	var err error
+
+	fmt.Println("=============> ", filename, " <=============")
+
+	contents, err = ioutil.ReadFile(filename)
+	if err != nil {
+		return
+	}
+
+	self.compile(filename, contents, false)
+	irb := self.irb
 
 	if self.dumpInstrs {
 		irb.RootClosure().DumpClosureProto()
@@ -89,3 +116,64 @@ func (self *Runner) Run(filename string) {
 		self.runtime.Stack.Print()
 	}
 }
+
+// an error of a config script, the Fail hooks panic with it
+type configError string
+
+// LoadConfig runs a config script and returns the dict its last expression
+// gives, converted by rt.ObjectToGo. nested dicts are maps too, timestamps
+// parsed by the yaml and toml modules stay time.Time. errors of the script
+// are returned, nothing is printed
+func (self *Runner) LoadConfig(filename string) (conf map[string]interface{}, err error) {
+	contents, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	// every config is compiled on its own
+	self.attr, self.irb = comp.NewAttr(), comp.NewIRBuilder()
+	self.attr.Quiet = true
+	fail := func(msg string) { panic(configError(msg)) }
+	self.irb.Fail, self.runtime.Fail = fail, fail
+	running := false
+	defer func() {
+		self.runtime.Fail = nil
+		r := recover()
+		if r == nil {
+			return
+		}
+		msg, ok := r.(configError)
+		if !ok {
+			panic(r)
+		}
+		if running {
+			// the vm stopped holding the lock, with its stack half used
+			self.runtime.Unlock()
+			self.runtime.Stack = rt.NewStack()
+		}
+		conf, err = nil, fmt.Errorf("config %s: %s", filename, strings.TrimSpace(string(msg)))
+	}()
+
+	if err := self.compile(filename, contents, true); err != nil {
+		return nil, fmt.Errorf("config %s: %s", filename, err)
+	}
+	irb := self.irb
+	vm := vm.NewVM(irb.RootClosure(), irb.ClosureTable(), self.runtime)
+	self.runtime.Runner = vm
+
+	// the script returns its last expression, or nil
+	running = true
+	vm.Run()
+	running = false
+	last := self.runtime.Stack.Pop()
+
+	dict, ok := last.(*rt.DictObject)
+	if !ok {
+		return nil, fmt.Errorf("config %s gives %s, it must end with a dict", filename, last.Name())
+	}
+	val, err := rt.ObjectToGo(dict)
+	if err != nil {
+		return nil, fmt.Errorf("config %s: %s", filename, err)
+	}
+	return val.(map[string]interface{}), nil
+}
@@ -18,14 +18,6 @@ func init() {
 	flag.StringVar(&input, "i", "", "input file")
 }
 
-func MakeRect(x, y, h, w int32) sdl.Rect {
-	return sdl.Rect{x, y, h, w}
-}
-
-func NewRect(x, y, h, w int32) *sdl.Rect {
-	return &sdl.Rect{x, y, h, w}
-}
-
 func main() {
 	f, err := os.Create("prof")
 	if err != nil {
@@ -43,9 +35,11 @@ func main() {
 	r := runner.NewRunner()
 
 	r.RegisterFunctions("sdl", []interface{}{
-		MakeRect, NewRect, sdl.CreateWindow, sdl.Delay, sdl.PollEvent,
+		sdl.CreateWindow, sdl.Delay, sdl.PollEvent,
 	})
 
+	r.RegisterTypes("sdl", []interface{}{sdl.Rect{}})
+
 	r.RegisterVars("sdl", map[string]interface{}{
 		"WINDOWPOS_UNDEFINED": sdl.WINDOWPOS_UNDEFINED,
 		"WINDOW_SHOWN":        sdl.WINDOW_SHOWN,
@@ -19,6 +19,8 @@ const (
 	IMAG   // 123.45i
 	CHAR   // 'a'
 	STRING // "abc"
+	SYMBOL // :abc
+	REGEXP // /abc/i
 	literal_end
 
 	operator_beg
@@ -28,6 +30,7 @@ const (
 	MUL // *
 	QUO // /
 	REM // %
+	POW // **
 
 	AND     // &
 	OR      // |
@@ -41,6 +44,7 @@ const (
 	MUL_ASSIGN // *=
 	QUO_ASSIGN // /=
 	REM_ASSIGN // %=
+	POW_ASSIGN // **=
 
 	AND_ASSIGN     // &=
 	OR_ASSIGN      // |=
@@ -78,6 +82,8 @@ const (
 	RBRACE    // }
 	SEMICOLON // ;
 	COLON     // :
+
+	MATCH_OP // =~
 	operator_end
 
 	keyword_beg
@@ -129,12 +135,15 @@ var Tokens = [...]string{
 	IMAG:   "IMAG",
 	CHAR:   "CHAR",
 	STRING: "STRING",
+	SYMBOL: "SYMBOL",
+	REGEXP: "REGEXP",
 
 	ADD: "+",
 	SUB: "-",
 	MUL: "*",
 	QUO: "/",
 	REM: "%",
+	POW: "**",
 
 	AND:     "&",
 	OR:      "|",
@@ -148,6 +157,7 @@ var Tokens = [...]string{
 	MUL_ASSIGN: "*=",
 	QUO_ASSIGN: "/=",
 	REM_ASSIGN: "%=",
+	POW_ASSIGN: "**=",
 
 	AND_ASSIGN:     "&=",
 	OR_ASSIGN:      "|=",
@@ -186,6 +196,8 @@ var Tokens = [...]string{
 	SEMICOLON: ";",
 	COLON:     ":",
 
+	MATCH_OP: "=~",
+
 	BREAK:    "break",
 	CASE:     "case",
 	CHAN:     "chan",
@@ -14,6 +14,7 @@ type ClosureProto struct {
 	instrs             []Instr
 	args               []string
 	seq                int
+	generator          bool
 }
 
 func NewClosureProto(outer *ClosureProto) *ClosureProto {
@@ -34,6 +35,24 @@ func (self *ClosureProto) Seq() int {
 	return self.seq
 }
 
+// a generator proto suspends at every YIELD instead of running to the end
+func (self *ClosureProto) SetGenerator() {
+	self.generator = true
+}
+
+func (self *ClosureProto) IsGenerator() bool {
+	return self.generator
+}
+
+// the parameters in order, a pattern parameter has a hidden name
+func (self *ClosureProto) AddArg(name string) {
+	self.args = append(self.args, name)
+}
+
+func (self *ClosureProto) NumArgs() int {
+	return len(self.args)
+}
+
 func (self *ClosureProto) OuterClosureProto() *ClosureProto {
 	return self.outerClosureProto
 }
@@ -65,8 +84,8 @@ func (self *ClosureProto) Emit(instr Instr) int {
 
 func (self *ClosureProto) DumpClosureProto() {
 	fmt.Println()
-	fmt.Printf("CLOSURE seq:%d local:%d upval:%d\n", self.seq,
-		len(self.localVariables), len(self.upvalVariables))
+	fmt.Printf("CLOSURE seq:%d local:%d upval:%d generator:%v\n", self.seq,
+		len(self.localVariables), len(self.upvalVariables), self.generator)
 	for k, v := range self.localVariables {
 		fmt.Printf("  .local %d %s\n", v, k)
 	}
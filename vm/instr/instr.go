@@ -2,6 +2,9 @@ package instr
 
 import (
 	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
 )
 
 type InstrType int
@@ -11,8 +14,13 @@ const (
 	PUSH_TRUE
 	PUSH_FALSE
 	PUSH_INT
+	PUSH_BIGINT
 	PUSH_STRING
+	PUSH_SYMBOL
+	PUSH_REGEXP
 	PUSH_FLOAT
+	PUSH_COMPLEX
+	PUSH_RUNE
 	LOAD_LOCAL
 	LOAD_UPVAL
 	SET_LOCAL
@@ -21,6 +29,7 @@ const (
 	NEW_ARRAY
 	NEW_DICT
 	NEW_SET
+	NEW_TUPLE
 	LABEL
 	JUMP
 	JUMP_IF_FALSE
@@ -32,6 +41,21 @@ const (
 	RAISE_RETURN
 	RAISE_BREAK
 	RAISE_CONTINUE
+	YIELD
+	CONCAT
+	UNPACK
+	UNPACK_DICT
+	APPEND
+	JUMP_IF_NIL
+	MATCH_VALUE
+	MATCH_RANGE
+	MATCH_TYPE
+	MATCH_ARRAY
+	MATCH_DICT
+	NO_MATCH
+	CLOSE_ITER
+	NEW_RANGE
+	OPEN_ITER
 )
 
 var TypName = map[InstrType]string{
@@ -39,8 +63,13 @@ var TypName = map[InstrType]string{
 	PUSH_TRUE:      "PUSH_TRUE",
 	PUSH_FALSE:     "PUSH_FALSE",
 	PUSH_INT:       "PUSH_INT",
+	PUSH_BIGINT:    "PUSH_BIGINT",
 	PUSH_STRING:    "PUSH_STRING",
+	PUSH_SYMBOL:    "PUSH_SYMBOL",
+	PUSH_REGEXP:    "PUSH_REGEXP",
 	PUSH_FLOAT:     "PUSH_FLOAT",
+	PUSH_COMPLEX:   "PUSH_COMPLEX",
+	PUSH_RUNE:      "PUSH_RUNE",
 	LOAD_LOCAL:     "LOAD_LOCAL",
 	LOAD_UPVAL:     "LOAD_UPVAL",
 	SET_LOCAL:      "SET_LOCAL",
@@ -49,6 +78,7 @@ var TypName = map[InstrType]string{
 	NEW_ARRAY:      "NEW_ARRAY",
 	NEW_DICT:       "NEW_DICT",
 	NEW_SET:        "NEW_SET",
+	NEW_TUPLE:      "NEW_TUPLE",
 	LABEL:          "LABEL",
 	JUMP:           "JUMP",
 	JUMP_IF_FALSE:  "JUMP_IF_FALSE",
@@ -60,6 +90,21 @@ var TypName = map[InstrType]string{
 	RAISE_RETURN:   "RAISE_RETURN",
 	RAISE_BREAK:    "RAISE_BREAK",
 	RAISE_CONTINUE: "RAISE_CONTINUE",
+	YIELD:          "YIELD",
+	CONCAT:         "CONCAT",
+	UNPACK:         "UNPACK",
+	UNPACK_DICT:    "UNPACK_DICT",
+	APPEND:         "APPEND",
+	JUMP_IF_NIL:    "JUMP_IF_NIL",
+	MATCH_VALUE:    "MATCH_VALUE",
+	MATCH_RANGE:    "MATCH_RANGE",
+	MATCH_TYPE:     "MATCH_TYPE",
+	MATCH_ARRAY:    "MATCH_ARRAY",
+	MATCH_DICT:     "MATCH_DICT",
+	NO_MATCH:       "NO_MATCH",
+	CLOSE_ITER:     "CLOSE_ITER",
+	NEW_RANGE:      "NEW_RANGE",
+	OPEN_ITER:      "OPEN_ITER",
 }
 
 type Instr interface {
@@ -105,6 +150,16 @@ func PushInt(val int) *PushIntInstr {
 	return instr
 }
 
+type PushBigIntInstr struct {
+	Typ InstrType
+	Val *big.Int
+}
+
+func PushBigInt(val *big.Int) *PushBigIntInstr {
+	instr := &PushBigIntInstr{PUSH_BIGINT, val}
+	return instr
+}
+
 type PushFloatInstr struct {
 	Typ InstrType
 	Val float64
@@ -115,6 +170,26 @@ func PushFloat(val float64) *PushFloatInstr {
 	return instr
 }
 
+type PushComplexInstr struct {
+	Typ InstrType
+	Val complex128
+}
+
+func PushComplex(val complex128) *PushComplexInstr {
+	instr := &PushComplexInstr{PUSH_COMPLEX, val}
+	return instr
+}
+
+type PushRuneInstr struct {
+	Typ InstrType
+	Val rune
+}
+
+func PushRune(val rune) *PushRuneInstr {
+	instr := &PushRuneInstr{PUSH_RUNE, val}
+	return instr
+}
+
 type PushStringInstr struct {
 	Typ InstrType
 	Val string
@@ -125,6 +200,42 @@ func PushString(val string) *PushStringInstr {
 	return instr
 }
 
+type PushSymbolInstr struct {
+	Typ  InstrType
+	Name string
+}
+
+func PushSymbol(name string) *PushSymbolInstr {
+	instr := &PushSymbolInstr{PUSH_SYMBOL, name}
+	return instr
+}
+
+// the pattern of a regexp literal is compiled once, when the literal is
+// built, and shared by every run of the instruction
+type PushRegexpInstr struct {
+	Typ     InstrType
+	Pattern string
+	Flags   string
+	Re      *regexp.Regexp
+}
+
+func PushRegexp(pattern, flags string, re *regexp.Regexp) *PushRegexpInstr {
+	instr := &PushRegexpInstr{PUSH_REGEXP, pattern, flags, re}
+	return instr
+}
+
+// CompileRegexp compiles a pattern with the flags i, m, s and U of go's
+// (?flags) syntax
+func CompileRegexp(pattern, flags string) (*regexp.Regexp, error) {
+	if i := strings.IndexFunc(flags, func(r rune) bool { return !strings.ContainsRune("imsU", r) }); i >= 0 {
+		return nil, fmt.Errorf("unknown regexp flag %q", flags[i])
+	}
+	if flags != "" {
+		pattern = "(?" + flags + ")" + pattern
+	}
+	return regexp.Compile(pattern)
+}
+
 type LoadLocalInstr struct {
 	Typ    InstrType
 	Offset int
@@ -169,10 +280,16 @@ type SendMethodInstr struct {
 	Typ    InstrType
 	Method string
 	Num    int
+	Pos    int // source offset shown by runtime errors, 0 if unknown
 }
 
 func SendMethod(method string, offset int) *SendMethodInstr {
-	instr := &SendMethodInstr{SEND_METHOD, method, offset}
+	instr := &SendMethodInstr{SEND_METHOD, method, offset, 0}
+	return instr
+}
+
+func SendMethodAt(method string, offset, pos int) *SendMethodInstr {
+	instr := &SendMethodInstr{SEND_METHOD, method, offset, pos}
 	return instr
 }
 
@@ -206,6 +323,27 @@ func NewSet(num int) *NewSetInstr {
 	return instr
 }
 
+type NewTupleInstr struct {
+	Typ InstrType
+	Num int
+}
+
+func NewTuple(num int) *NewTupleInstr {
+	instr := &NewTupleInstr{NEW_TUPLE, num}
+	return instr
+}
+
+// pops the high and the low bound of lo..hi
+type NewRangeInstr struct {
+	Typ InstrType
+	Pos int
+}
+
+func NewRange(pos int) *NewRangeInstr {
+	instr := &NewRangeInstr{NEW_RANGE, pos}
+	return instr
+}
+
 type LabelInstr struct {
 	Typ   InstrType
 	Label string
@@ -314,6 +452,158 @@ func RaiseContinue() *RaiseContinueInstr {
 	return instr
 }
 
+type YieldInstr struct {
+	Typ InstrType
+}
+
+func Yield() *YieldInstr {
+	instr := &YieldInstr{YIELD}
+	return instr
+}
+
+type ConcatInstr struct {
+	Typ InstrType
+	Num int
+}
+
+func Concat(num int) *ConcatInstr {
+	instr := &ConcatInstr{CONCAT, num}
+	return instr
+}
+
+// Rest is the position of a ...rest element, -1 when there is none
+type UnpackInstr struct {
+	Typ  InstrType
+	Num  int
+	Rest int
+	Pos  int
+}
+
+func Unpack(num, rest, pos int) *UnpackInstr {
+	instr := &UnpackInstr{UNPACK, num, rest, pos}
+	return instr
+}
+
+type UnpackDictInstr struct {
+	Typ InstrType
+	Num int
+	Pos int
+}
+
+func UnpackDict(num, pos int) *UnpackDictInstr {
+	instr := &UnpackDictInstr{UNPACK_DICT, num, pos}
+	return instr
+}
+
+// pops a value and appends it to the array in a local, used to build
+// comprehensions without a method call per element
+type AppendInstr struct {
+	Typ    InstrType
+	Offset int
+}
+
+func Append(offset int) *AppendInstr {
+	instr := &AppendInstr{APPEND, offset}
+	return instr
+}
+
+// closes the value a range loop iterates when the loop is left, an
+// abandoned generator stops instead of waiting forever
+type CloseIterInstr struct {
+	Typ    InstrType
+	Offset int
+}
+
+func CloseIter(offset int) *CloseIterInstr {
+	instr := &CloseIterInstr{CLOSE_ITER, offset}
+	return instr
+}
+
+// replaces the value a loop is about to iterate with what it walks, a dict
+// is walked by a snapshot of its entries
+type OpenIterInstr struct {
+	Typ    InstrType
+	Offset int
+}
+
+func OpenIter(offset int) *OpenIterInstr {
+	instr := &OpenIterInstr{OPEN_ITER, offset}
+	return instr
+}
+
+// pops a value and jumps when it is nil, for ?? and ?.
+type JumpIfNilInstr struct {
+	Typ    InstrType
+	Target int
+}
+
+func JumpIfNil(target int) *JumpIfNilInstr {
+	instr := &JumpIfNilInstr{JUMP_IF_NIL, target}
+	return instr
+}
+
+// the match instructions pop the subject, and the pattern operands pushed
+// before it, and push whether the subject fits the pattern
+type MatchValueInstr struct {
+	Typ InstrType
+}
+
+func MatchValue() *MatchValueInstr {
+	instr := &MatchValueInstr{MATCH_VALUE}
+	return instr
+}
+
+type MatchRangeInstr struct {
+	Typ InstrType
+}
+
+func MatchRange() *MatchRangeInstr {
+	instr := &MatchRangeInstr{MATCH_RANGE}
+	return instr
+}
+
+type MatchTypeInstr struct {
+	Typ  InstrType
+	Name string
+}
+
+func MatchType(name string) *MatchTypeInstr {
+	instr := &MatchTypeInstr{MATCH_TYPE, name}
+	return instr
+}
+
+type MatchArrayInstr struct {
+	Typ  InstrType
+	Num  int
+	Rest int
+}
+
+func MatchArray(num, rest int) *MatchArrayInstr {
+	instr := &MatchArrayInstr{MATCH_ARRAY, num, rest}
+	return instr
+}
+
+type MatchDictInstr struct {
+	Typ InstrType
+	Num int
+}
+
+func MatchDict(num int) *MatchDictInstr {
+	instr := &MatchDictInstr{MATCH_DICT, num}
+	return instr
+}
+
+// pops the subject of a match no clause matched and reports it
+type NoMatchInstr struct {
+	Typ InstrType
+	Pos int
+}
+
+func NoMatch(pos int) *NoMatchInstr {
+	instr := &NoMatchInstr{NO_MATCH, pos}
+	return instr
+}
+
 var _t = func(args ...interface{}) string {
 	s := ""
 	for _, arg := range args {
@@ -326,8 +616,13 @@ func (n *PushNilInstr) String() string       { return TypName[n.Typ] }
 func (n *PushTrueInstr) String() string      { return TypName[n.Typ] }
 func (n *PushFalseInstr) String() string     { return TypName[n.Typ] }
 func (n *PushIntInstr) String() string       { return _t(TypName[n.Typ], n.Val) }
+func (n *PushBigIntInstr) String() string    { return _t(TypName[n.Typ], n.Val) }
 func (n *PushFloatInstr) String() string     { return _t(TypName[n.Typ], n.Val) }
+func (n *PushComplexInstr) String() string   { return _t(TypName[n.Typ], n.Val) }
+func (n *PushRuneInstr) String() string      { return _t(TypName[n.Typ], n.Val) }
 func (n *PushStringInstr) String() string    { return _t(TypName[n.Typ], n.Val) }
+func (n *PushSymbolInstr) String() string    { return _t(TypName[n.Typ], n.Name) }
+func (n *PushRegexpInstr) String() string    { return _t(TypName[n.Typ], n.Pattern, n.Flags) }
 func (n *LoadLocalInstr) String() string     { return _t(TypName[n.Typ], n.Offset) }
 func (n *LoadUpvalInstr) String() string     { return _t(TypName[n.Typ], n.Offset) }
 func (n *SetLocalInstr) String() string      { return _t(TypName[n.Typ], n.Offset) }
@@ -336,6 +631,8 @@ func (n *SendMethodInstr) String() string    { return _t(TypName[n.Typ], n.Metho
 func (n *NewArrayInstr) String() string      { return _t(TypName[n.Typ], n.Num) }
 func (n *NewDictInstr) String() string       { return _t(TypName[n.Typ], n.Num) }
 func (n *NewSetInstr) String() string        { return _t(TypName[n.Typ], n.Num) }
+func (n *NewTupleInstr) String() string      { return _t(TypName[n.Typ], n.Num) }
+func (n *NewRangeInstr) String() string      { return TypName[n.Typ] }
 func (n *LabelInstr) String() string         { return _t(TypName[n.Typ], n.Label) }
 func (n *JumpInstr) String() string          { return _t(TypName[n.Typ], n.Target) }
 func (n *JumpIfFalseInstr) String() string   { return _t(TypName[n.Typ], n.Target) }
@@ -347,13 +644,32 @@ func (n *PushClosureInstr) String() string   { return _t(TypName[n.Typ], n.Seq)
 func (n *RaiseReturnInstr) String() string   { return _t(TypName[n.Typ], n.Num) }
 func (n *RaiseBreakInstr) String() string    { return TypName[n.Typ] }
 func (n *RaiseContinueInstr) String() string { return TypName[n.Typ] }
+func (n *YieldInstr) String() string         { return TypName[n.Typ] }
+func (n *ConcatInstr) String() string        { return _t(TypName[n.Typ], n.Num) }
+func (n *UnpackInstr) String() string        { return _t(TypName[n.Typ], n.Num, n.Rest) }
+func (n *UnpackDictInstr) String() string    { return _t(TypName[n.Typ], n.Num) }
+func (n *AppendInstr) String() string        { return _t(TypName[n.Typ], n.Offset) }
+func (n *CloseIterInstr) String() string     { return _t(TypName[n.Typ], n.Offset) }
+func (n *OpenIterInstr) String() string      { return _t(TypName[n.Typ], n.Offset) }
+func (n *JumpIfNilInstr) String() string     { return _t(TypName[n.Typ], n.Target) }
+func (n *MatchValueInstr) String() string    { return TypName[n.Typ] }
+func (n *MatchRangeInstr) String() string    { return TypName[n.Typ] }
+func (n *MatchTypeInstr) String() string     { return _t(TypName[n.Typ], n.Name) }
+func (n *MatchArrayInstr) String() string    { return _t(TypName[n.Typ], n.Num, n.Rest) }
+func (n *MatchDictInstr) String() string     { return _t(TypName[n.Typ], n.Num) }
+func (n *NoMatchInstr) String() string       { return TypName[n.Typ] }
 
 func (n *PushNilInstr) Type() InstrType       { return n.Typ }
 func (n *PushTrueInstr) Type() InstrType      { return n.Typ }
 func (n *PushFalseInstr) Type() InstrType     { return n.Typ }
 func (n *PushIntInstr) Type() InstrType       { return n.Typ }
+func (n *PushBigIntInstr) Type() InstrType    { return n.Typ }
 func (n *PushFloatInstr) Type() InstrType     { return n.Typ }
+func (n *PushComplexInstr) Type() InstrType   { return n.Typ }
+func (n *PushRuneInstr) Type() InstrType      { return n.Typ }
 func (n *PushStringInstr) Type() InstrType    { return n.Typ }
+func (n *PushSymbolInstr) Type() InstrType    { return n.Typ }
+func (n *PushRegexpInstr) Type() InstrType    { return n.Typ }
 func (n *LoadLocalInstr) Type() InstrType     { return n.Typ }
 func (n *LoadUpvalInstr) Type() InstrType     { return n.Typ }
 func (n *SetLocalInstr) Type() InstrType      { return n.Typ }
@@ -362,6 +678,8 @@ func (n *SendMethodInstr) Type() InstrType    { return n.Typ }
 func (n *NewArrayInstr) Type() InstrType      { return n.Typ }
 func (n *NewDictInstr) Type() InstrType       { return n.Typ }
 func (n *NewSetInstr) Type() InstrType        { return n.Typ }
+func (n *NewTupleInstr) Type() InstrType      { return n.Typ }
+func (n *NewRangeInstr) Type() InstrType      { return n.Typ }
 func (n *LabelInstr) Type() InstrType         { return n.Typ }
 func (n *JumpInstr) Type() InstrType          { return n.Typ }
 func (n *JumpIfFalseInstr) Type() InstrType   { return n.Typ }
@@ -373,13 +691,32 @@ func (n *PushClosureInstr) Type() InstrType   { return n.Typ }
 func (n *RaiseReturnInstr) Type() InstrType   { return n.Typ }
 func (n *RaiseBreakInstr) Type() InstrType    { return n.Typ }
 func (n *RaiseContinueInstr) Type() InstrType { return n.Typ }
+func (n *YieldInstr) Type() InstrType         { return n.Typ }
+func (n *ConcatInstr) Type() InstrType        { return n.Typ }
+func (n *UnpackInstr) Type() InstrType        { return n.Typ }
+func (n *UnpackDictInstr) Type() InstrType    { return n.Typ }
+func (n *JumpIfNilInstr) Type() InstrType     { return n.Typ }
+func (n *MatchValueInstr) Type() InstrType    { return n.Typ }
+func (n *MatchRangeInstr) Type() InstrType    { return n.Typ }
+func (n *MatchTypeInstr) Type() InstrType     { return n.Typ }
+func (n *MatchArrayInstr) Type() InstrType    { return n.Typ }
+func (n *MatchDictInstr) Type() InstrType     { return n.Typ }
+func (n *NoMatchInstr) Type() InstrType       { return n.Typ }
+func (n *AppendInstr) Type() InstrType        { return n.Typ }
+func (n *CloseIterInstr) Type() InstrType     { return n.Typ }
+func (n *OpenIterInstr) Type() InstrType      { return n.Typ }
 
 func (n *PushNilInstr) Accept(v Visitor)       { v.VisitPushNil(n) }
 func (n *PushTrueInstr) Accept(v Visitor)      { v.VisitPushTrue(n) }
 func (n *PushFalseInstr) Accept(v Visitor)     { v.VisitPushFalse(n) }
 func (n *PushIntInstr) Accept(v Visitor)       { v.VisitPushInt(n) }
+func (n *PushBigIntInstr) Accept(v Visitor)    { v.VisitPushBigInt(n) }
 func (n *PushFloatInstr) Accept(v Visitor)     { v.VisitPushFloat(n) }
+func (n *PushComplexInstr) Accept(v Visitor)   { v.VisitPushComplex(n) }
+func (n *PushRuneInstr) Accept(v Visitor)      { v.VisitPushRune(n) }
 func (n *PushStringInstr) Accept(v Visitor)    { v.VisitPushString(n) }
+func (n *PushSymbolInstr) Accept(v Visitor)    { v.VisitPushSymbol(n) }
+func (n *PushRegexpInstr) Accept(v Visitor)    { v.VisitPushRegexp(n) }
 func (n *LoadLocalInstr) Accept(v Visitor)     { v.VisitLoadLocal(n) }
 func (n *LoadUpvalInstr) Accept(v Visitor)     { v.VisitLoadUpval(n) }
 func (n *SetLocalInstr) Accept(v Visitor)      { v.VisitSetLocal(n) }
@@ -388,6 +725,8 @@ func (n *SendMethodInstr) Accept(v Visitor)    { v.VisitSendMethod(n) }
 func (n *NewArrayInstr) Accept(v Visitor)      { v.VisitNewArray(n) }
 func (n *NewDictInstr) Accept(v Visitor)       { v.VisitNewDict(n) }
 func (n *NewSetInstr) Accept(v Visitor)        { v.VisitNewSet(n) }
+func (n *NewTupleInstr) Accept(v Visitor)      { v.VisitNewTuple(n) }
+func (n *NewRangeInstr) Accept(v Visitor)      { v.VisitNewRange(n) }
 func (n *LabelInstr) Accept(v Visitor)         { v.VisitLabel(n) }
 func (n *JumpInstr) Accept(v Visitor)          { v.VisitJump(n) }
 func (n *JumpIfFalseInstr) Accept(v Visitor)   { v.VisitJumpIfFalse(n) }
@@ -399,3 +738,17 @@ func (n *PushClosureInstr) Accept(v Visitor)   { v.VisitPushClosure(n) }
 func (n *RaiseReturnInstr) Accept(v Visitor)   { v.VisitRaiseReturn(n) }
 func (n *RaiseBreakInstr) Accept(v Visitor)    { v.VisitRaiseBreak(n) }
 func (n *RaiseContinueInstr) Accept(v Visitor) { v.VisitRaiseContinue(n) }
+func (n *YieldInstr) Accept(v Visitor)         { v.VisitYield(n) }
+func (n *ConcatInstr) Accept(v Visitor)        { v.VisitConcat(n) }
+func (n *UnpackInstr) Accept(v Visitor)        { v.VisitUnpack(n) }
+func (n *UnpackDictInstr) Accept(v Visitor)    { v.VisitUnpackDict(n) }
+func (n *AppendInstr) Accept(v Visitor)        { v.VisitAppend(n) }
+func (n *CloseIterInstr) Accept(v Visitor)     { v.VisitCloseIter(n) }
+func (n *OpenIterInstr) Accept(v Visitor)      { v.VisitOpenIter(n) }
+func (n *JumpIfNilInstr) Accept(v Visitor)     { v.VisitJumpIfNil(n) }
+func (n *MatchValueInstr) Accept(v Visitor)    { v.VisitMatchValue(n) }
+func (n *MatchRangeInstr) Accept(v Visitor)    { v.VisitMatchRange(n) }
+func (n *MatchTypeInstr) Accept(v Visitor)     { v.VisitMatchType(n) }
+func (n *MatchArrayInstr) Accept(v Visitor)    { v.VisitMatchArray(n) }
+func (n *MatchDictInstr) Accept(v Visitor)     { v.VisitMatchDict(n) }
+func (n *NoMatchInstr) Accept(v Visitor)       { v.VisitNoMatch(n) }
@@ -5,8 +5,13 @@ type Visitor interface {
 	VisitPushTrue(ir *PushTrueInstr)
 	VisitPushFalse(ir *PushFalseInstr)
 	VisitPushInt(ir *PushIntInstr)
+	VisitPushBigInt(ir *PushBigIntInstr)
 	VisitPushFloat(ir *PushFloatInstr)
+	VisitPushComplex(ir *PushComplexInstr)
+	VisitPushRune(ir *PushRuneInstr)
 	VisitPushString(ir *PushStringInstr)
+	VisitPushSymbol(ir *PushSymbolInstr)
+	VisitPushRegexp(ir *PushRegexpInstr)
 	VisitLoadLocal(ir *LoadLocalInstr)
 	VisitLoadUpval(ir *LoadUpvalInstr)
 	VisitSetLocal(ir *SetLocalInstr)
@@ -15,6 +20,8 @@ type Visitor interface {
 	VisitNewArray(ir *NewArrayInstr)
 	VisitNewDict(ir *NewDictInstr)
 	VisitNewSet(ir *NewSetInstr)
+	VisitNewTuple(ir *NewTupleInstr)
+	VisitNewRange(ir *NewRangeInstr)
 	VisitLabel(ir *LabelInstr)
 	VisitJump(ir *JumpInstr)
 	VisitJumpIfFalse(ir *JumpIfFalseInstr)
@@ -26,4 +33,18 @@ type Visitor interface {
 	VisitRaiseReturn(ir *RaiseReturnInstr)
 	VisitRaiseBreak(ir *RaiseBreakInstr)
 	VisitRaiseContinue(ir *RaiseContinueInstr)
+	VisitYield(ir *YieldInstr)
+	VisitConcat(ir *ConcatInstr)
+	VisitUnpack(ir *UnpackInstr)
+	VisitUnpackDict(ir *UnpackDictInstr)
+	VisitAppend(ir *AppendInstr)
+	VisitCloseIter(ir *CloseIterInstr)
+	VisitOpenIter(ir *OpenIterInstr)
+	VisitJumpIfNil(ir *JumpIfNilInstr)
+	VisitMatchValue(ir *MatchValueInstr)
+	VisitMatchRange(ir *MatchRangeInstr)
+	VisitMatchType(ir *MatchTypeInstr)
+	VisitMatchArray(ir *MatchArrayInstr)
+	VisitMatchDict(ir *MatchDictInstr)
+	VisitNoMatch(ir *NoMatchInstr)
 }
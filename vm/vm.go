@@ -1,7 +1,6 @@
 package vm
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/jxwr/doby/rt"
@@ -14,16 +13,30 @@ type VM struct {
 	mods    map[string]*rt.DictObject
 	frame   *rt.Frame
 	runtime *rt.Runtime
+	gen     *rt.GeneratorObject
 }
 
 func NewVM(c *instr.ClosureProto, cs map[int]*instr.ClosureProto, runtime *rt.Runtime) *VM {
 	vm := &VM{cc: c, cs: cs, runtime: runtime, mods: map[string]*rt.DictObject{}}
+	mod, _ := runtime.Env.LookUp("builtin")
+	vm.mods["builtin"] = mod.(*rt.DictObject)
 	return vm
 }
 
+// the runtime is locked while doby code runs, see rt.Runtime.Lock
 func (self *VM) Run() {
+	self.runtime.Lock()
 	obj := self.runtime.NewClosureObject(self.cc, nil)
+	self.runtime.Mark()
 	self.RunClosure(obj)
+	self.runtime.Unlock()
+}
+
+// the generator body gets a vm of its own, it runs in another goroutine
+// and must not touch the frame of the caller
+func (self *VM) RunGenerator(gen *rt.GeneratorObject) {
+	vm := &VM{cc: self.cc, cs: self.cs, mods: self.mods, runtime: self.runtime, gen: gen}
+	vm.RunClosure(gen.Closure())
 }
 
 func (self *VM) RunClosure(obj *rt.ClosureObject) {
@@ -32,6 +45,7 @@ func (self *VM) RunClosure(obj *rt.ClosureObject) {
 
 	self.frame = rt.NewFrame(c.NumLocalVariable(), c.NumUpvalVariable(), obj.Frame)
 	instrs := c.Instrs()
+	returned := false
 	for i := 0; i < len(instrs); i++ {
 		instrs[i].Accept(self)
 		if self.frame.JumpTarget > 0 {
@@ -48,9 +62,13 @@ func (self *VM) RunClosure(obj *rt.ClosureObject) {
 		}
 		if self.frame.NeedReturn {
 			self.frame.NeedReturn = false
+			returned = true
 			break
 		}
 	}
+	if !returned {
+		self.runtime.ReturnNil(self.runtime.PopMark())
+	}
 	self.frame = f
 }
 
@@ -76,16 +94,39 @@ func (self *VM) VisitPushInt(ir *instr.PushIntInstr) {
 	self.runtime.Push(obj)
 }
 
+func (self *VM) VisitPushBigInt(ir *instr.PushBigIntInstr) {
+	obj := self.runtime.NewBigIntObject(ir.Val)
+	self.runtime.Push(obj)
+}
+
 func (self *VM) VisitPushFloat(ir *instr.PushFloatInstr) {
 	obj := self.runtime.NewFloatObject(ir.Val)
 	self.runtime.Push(obj)
 }
 
+func (self *VM) VisitPushComplex(ir *instr.PushComplexInstr) {
+	obj := self.runtime.NewComplexObject(ir.Val)
+	self.runtime.Push(obj)
+}
+
+func (self *VM) VisitPushRune(ir *instr.PushRuneInstr) {
+	obj := self.runtime.NewRuneObject(ir.Val)
+	self.runtime.Push(obj)
+}
+
 func (self *VM) VisitPushString(ir *instr.PushStringInstr) {
 	obj := self.runtime.NewStringObject(ir.Val)
 	self.runtime.Push(obj)
 }
 
+func (self *VM) VisitPushSymbol(ir *instr.PushSymbolInstr) {
+	self.runtime.Push(self.runtime.Intern(ir.Name))
+}
+
+func (self *VM) VisitPushRegexp(ir *instr.PushRegexpInstr) {
+	self.runtime.Push(self.runtime.NewRegexpObject(ir.Re, ir.Pattern, ir.Flags))
+}
+
 func (self *VM) VisitLoadLocal(ir *instr.LoadLocalInstr) {
 	obj := self.frame.Locals[ir.Offset]
 	self.runtime.Push(obj)
@@ -122,6 +163,7 @@ func (self *VM) VisitSetUpval(ir *instr.SetUpvalInstr) {
 }
 
 func (self *VM) VisitSendMethod(ir *instr.SendMethodInstr) {
+	self.runtime.Pos = ir.Pos
 	obj := self.runtime.Pop()
 
 	// closure object is a function defined in doby code, mark stack and rewind manually
@@ -131,6 +173,14 @@ func (self *VM) VisitSendMethod(ir *instr.SendMethodInstr) {
 	if ir.Method == "__call__" {
 		switch v := obj.(type) {
 		case *rt.ClosureObject:
+			if v.Proto.IsGenerator() {
+				args := make([]rt.Object, ir.Num)
+				for i := ir.Num - 1; i >= 0; i-- {
+					args[i] = self.runtime.Pop()
+				}
+				self.runtime.Push(self.runtime.NewGeneratorObject(v, args))
+				return
+			}
 			// take care of the stack
 			self.runtime.MarkN(-(ir.Num))
 			self.RunClosure(v)
@@ -154,7 +204,15 @@ func (self *VM) VisitSendMethod(ir *instr.SendMethodInstr) {
 				self.runtime.Push(ret)
 			}
 		default:
-			fmt.Printf("%T", v)
+			// objects with a __call__ method like go types
+			args := make([]rt.Object, ir.Num)
+			for i := ir.Num - 1; i >= 0; i-- {
+				args[i] = self.runtime.Pop()
+			}
+			rets := rt.Invoke(self.runtime, v, "__call__", args...)
+			for _, ret := range rets {
+				self.runtime.Push(ret)
+			}
 		}
 	} else {
 		args := make([]rt.Object, ir.Num)
@@ -166,6 +224,8 @@ func (self *VM) VisitSendMethod(ir *instr.SendMethodInstr) {
 			self.runtime.Push(ret)
 		}
 	}
+	// the callee moved the position, errors after the call are reported here
+	self.runtime.Pos = ir.Pos
 }
 
 func (self *VM) VisitNewArray(ir *instr.NewArrayInstr) {
@@ -178,14 +238,13 @@ func (self *VM) VisitNewArray(ir *instr.NewArrayInstr) {
 }
 
 func (self *VM) VisitNewDict(ir *instr.NewDictInstr) {
-	fieldMap := map[string]rt.Slot{}
-
-	for i := 0; i < ir.Num; i++ {
+	slots := make([]rt.Slot, ir.Num)
+	for i := ir.Num - 1; i >= 0; i-- {
 		val := self.runtime.Pop()
 		key := self.runtime.Pop()
-		fieldMap[key.HashCode()] = rt.Slot{key, val}
+		slots[i] = rt.Slot{Key: key, Val: val}
 	}
-	obj := self.runtime.NewDictObject(fieldMap)
+	obj := self.runtime.NewDictObject(slots)
 	self.runtime.Push(obj)
 }
 
@@ -198,6 +257,65 @@ func (self *VM) VisitNewSet(ir *instr.NewSetInstr) {
 	self.runtime.Push(obj)
 }
 
+func (self *VM) VisitNewTuple(ir *instr.NewTupleInstr) {
+	elems := make([]rt.Object, ir.Num)
+	for i := ir.Num - 1; i >= 0; i-- {
+		elems[i] = self.runtime.Pop()
+	}
+	obj := self.runtime.NewTupleObject(elems)
+	self.runtime.Push(obj)
+}
+
+func (self *VM) VisitNewRange(ir *instr.NewRangeInstr) {
+	self.runtime.Pos = ir.Pos
+	hi := self.runtime.Pop()
+	lo := self.runtime.Pop()
+	self.runtime.Push(self.runtime.NewRangeObject(lo, hi))
+}
+
+func (self *VM) VisitConcat(ir *instr.ConcatInstr) {
+	parts := make([]string, ir.Num)
+	for i := ir.Num - 1; i >= 0; i-- {
+		parts[i] = self.runtime.Pop().String()
+	}
+	obj := self.runtime.NewStringObject(strings.Join(parts, ""))
+	self.runtime.Push(obj)
+}
+
+func (self *VM) VisitUnpack(ir *instr.UnpackInstr) {
+	self.runtime.Pos = ir.Pos
+	obj := self.runtime.Pop()
+	for _, val := range self.runtime.UnpackArray(obj, ir.Num, ir.Rest) {
+		self.runtime.Push(val)
+	}
+}
+
+// the keys are pushed after the dict being destructured
+func (self *VM) VisitUnpackDict(ir *instr.UnpackDictInstr) {
+	self.runtime.Pos = ir.Pos
+	keys := make([]rt.Object, ir.Num)
+	for i := ir.Num - 1; i >= 0; i-- {
+		keys[i] = self.runtime.Pop()
+	}
+	obj := self.runtime.Pop()
+	for _, val := range self.runtime.UnpackDict(obj, keys) {
+		self.runtime.Push(val)
+	}
+}
+
+func (self *VM) VisitAppend(ir *instr.AppendInstr) {
+	arr := self.frame.Locals[ir.Offset].(*rt.ArrayObject)
+	arr.Vals = append(arr.Vals, self.runtime.Pop())
+}
+
+func (self *VM) VisitOpenIter(ir *instr.OpenIterInstr) {
+	self.frame.Locals[ir.Offset] = self.runtime.OpenIter(self.frame.Locals[ir.Offset])
+}
+
+func (self *VM) VisitCloseIter(ir *instr.CloseIterInstr) {
+	self.runtime.CloseIter(self.frame.Locals[ir.Offset])
+}
+
 func (self *VM) VisitLabel(ir *instr.LabelInstr) {}
 
 func (self *VM) VisitJump(ir *instr.JumpInstr) {
@@ -205,13 +323,55 @@ func (self *VM) VisitJump(ir *instr.JumpInstr) {
 }
 
 func (self *VM) VisitJumpIfFalse(ir *instr.JumpIfFalseInstr) {
-	obj := self.runtime.Pop().(*rt.BoolObject)
+	if !rt.Truthy(self.runtime.Pop()) {
+		self.frame.JumpTarget = ir.Target
+	}
+}
 
-	if !obj.Val {
+func (self *VM) VisitJumpIfNil(ir *instr.JumpIfNilInstr) {
+	if _, ok := self.runtime.Pop().(*rt.NilObject); ok {
 		self.frame.JumpTarget = ir.Target
 	}
 }
 
+func (self *VM) VisitMatchValue(ir *instr.MatchValueInstr) {
+	obj := self.runtime.Pop()
+	pat := self.runtime.Pop()
+	self.runtime.Push(self.runtime.NewBoolObject(self.runtime.MatchValue(obj, pat)))
+}
+
+func (self *VM) VisitMatchRange(ir *instr.MatchRangeInstr) {
+	obj := self.runtime.Pop()
+	hi := self.runtime.Pop()
+	lo := self.runtime.Pop()
+	self.runtime.Push(self.runtime.NewBoolObject(self.runtime.MatchRange(obj, lo, hi)))
+}
+
+func (self *VM) VisitMatchType(ir *instr.MatchTypeInstr) {
+	obj := self.runtime.Pop()
+	self.runtime.Push(self.runtime.NewBoolObject(self.runtime.MatchType(obj, ir.Name)))
+}
+
+func (self *VM) VisitMatchArray(ir *instr.MatchArrayInstr) {
+	obj := self.runtime.Pop()
+	self.runtime.Push(self.runtime.NewBoolObject(self.runtime.MatchArray(obj, ir.Num, ir.Rest)))
+}
+
+func (self *VM) VisitMatchDict(ir *instr.MatchDictInstr) {
+	obj := self.runtime.Pop()
+	keys := make([]rt.Object, ir.Num)
+	for i := ir.Num - 1; i >= 0; i-- {
+		keys[i] = self.runtime.Pop()
+	}
+	self.runtime.Push(self.runtime.NewBoolObject(self.runtime.MatchDict(obj, keys)))
+}
+
+func (self *VM) VisitNoMatch(ir *instr.NoMatchInstr) {
+	self.runtime.Pos = ir.Pos
+	obj := self.runtime.Pop()
+	self.runtime.Fatalf("no case matches %s", obj)
+}
+
 func (self *VM) VisitImport(ir *instr.ImportInstr) {
 	mod, _ := self.runtime.Env.LookUp(ir.Path)
 	xs := strings.Split(ir.Path, "/")
@@ -243,6 +403,10 @@ func (self *VM) VisitRaiseBreak(ir *instr.RaiseBreakInstr) {
 	self.frame.NeedBreak = true
 }
 
+func (self *VM) VisitYield(ir *instr.YieldInstr) {
+	self.gen.Yield(self.runtime.Pop())
+}
+
 func (self *VM) VisitRaiseContinue(ir *instr.RaiseContinueInstr) {
 	self.frame.NeedContinue = true
 }